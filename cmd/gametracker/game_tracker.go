@@ -2,13 +2,27 @@ package main
 
 import (
 	"game-tracker/internal/app"
+	"game-tracker/internal/cli"
 	"game-tracker/internal/config"
+	"github.com/spf13/pflag"
 	"go.uber.org/zap"
 	"log"
+	"os"
 )
 
 func main() {
-	cfg := config.LoadGlobalConfig()
+	cmd, err := cli.FromArgs(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cmd != nil && cmd.RegisterFlags != nil {
+		cmd.RegisterFlags(pflag.CommandLine)
+	}
+
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	unsugared, err := createLogger(cfg)
 	if err != nil {
@@ -16,6 +30,13 @@ func main() {
 	}
 	logger := unsugared.Sugar()
 
+	if cmd != nil {
+		if err := cli.Execute(cmd, cfg, logger); err != nil {
+			logger.Fatalw("command failed", "command", cmd.Name, "error", err)
+		}
+		return
+	}
+
 	app.Run(cfg, logger)
 }
 
@@ -0,0 +1,83 @@
+package achievements
+
+import (
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Engine evaluates achievement rules against finished games
+type Engine struct {
+	rules []*Rule
+	byId  map[string]*Rule
+}
+
+func NewEngine(rules []*Rule) *Engine {
+	byId := make(map[string]*Rule, len(rules))
+	for _, r := range rules {
+		byId[r.Id] = r
+	}
+
+	return &Engine{rules: rules, byId: byId}
+}
+
+// Load creates an engine with the rules in a YAML file
+func Load(path string) (*Engine, error) {
+	rules, err := LoadRules(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEngine(rules), nil
+}
+
+// Rule returns the rule with the id, or nil if it has been removed from the rules file
+func (e *Engine) Rule(id string) *Rule {
+	return e.byId[id]
+}
+
+// Evaluate returns the achievements earned by everyone who took part in the game.
+// Achievements the players already have are included, the repository ignores them when unlocking.
+func (e *Engine) Evaluate(game *model.HistoricGame, stats []*model.PlayerStats, now time.Time) []*model.PlayerAchievement {
+	statsByPlayer := make(map[uuid.UUID]*model.PlayerStats, len(stats))
+	for _, s := range stats {
+		statsByPlayer[s.PlayerId] = s
+	}
+
+	var earned []*model.PlayerAchievement
+	for _, result := range game.PlayerResults() {
+		f := playerFacts(game, result, statsByPlayer[result.PlayerId])
+
+		for _, r := range e.rules {
+			if !r.matches(game, f) {
+				continue
+			}
+
+			earned = append(earned, &model.PlayerAchievement{
+				Id:            primitive.NewObjectID(),
+				PlayerId:      result.PlayerId,
+				AchievementId: r.Id,
+				GameId:        game.Id,
+				GameModeId:    game.GameModeId,
+				UnlockedAt:    now,
+			})
+		}
+	}
+
+	return earned
+}
+
+func (r *Rule) matches(game *model.HistoricGame, f facts) bool {
+	if r.GameModeId != "" && r.GameModeId != game.GameModeId {
+		return false
+	}
+
+	for _, c := range r.Conditions {
+		if !c.matches(f) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,115 @@
+package achievements
+
+import (
+	"game-tracker/internal/repository/model"
+)
+
+// facts are the values conditions are evaluated against. Booleans are 1 or 0.
+type facts map[string]float64
+
+const (
+	FactWon               = "won"
+	FactLost              = "lost"
+	FactLeftEarly         = "leftEarly"
+	FactPlayerCount       = "playerCount"
+	FactDurationSeconds   = "durationSeconds"
+	FactTimeInGameSeconds = "timeInGameSeconds"
+
+	FactBlockSumoKills          = "blockSumo.kills"
+	FactBlockSumoFinalKills     = "blockSumo.finalKills"
+	FactBlockSumoRemainingLives = "blockSumo.remainingLives"
+	// FactBlockSumoLivesLost is only known for games whose live data was available when they finished
+	FactBlockSumoLivesLost = "blockSumo.livesLost"
+
+	FactTowerDefenceOwnHealth        = "towerDefence.ownHealth"
+	FactTowerDefenceOwnHealthPercent = "towerDefence.ownHealthPercent"
+	FactTowerDefenceEnemyHealth      = "towerDefence.enemyHealth"
+
+	// Stats facts are the player's totals in the game mode, including the game being evaluated
+	FactStatsGamesPlayed = "stats.gamesPlayed"
+	FactStatsWins        = "stats.wins"
+	FactStatsLosses      = "stats.losses"
+	FactStatsKills       = "stats.kills"
+	FactStatsFinalKills  = "stats.finalKills"
+	FactStatsWinStreak   = "stats.winStreak"
+)
+
+var knownFacts = map[string]bool{
+	FactWon: true, FactLost: true, FactLeftEarly: true, FactPlayerCount: true, FactDurationSeconds: true,
+	FactTimeInGameSeconds: true,
+
+	FactBlockSumoKills: true, FactBlockSumoFinalKills: true, FactBlockSumoRemainingLives: true,
+	FactBlockSumoLivesLost: true,
+
+	FactTowerDefenceOwnHealth: true, FactTowerDefenceOwnHealthPercent: true, FactTowerDefenceEnemyHealth: true,
+
+	FactStatsGamesPlayed: true, FactStatsWins: true, FactStatsLosses: true, FactStatsKills: true,
+	FactStatsFinalKills: true, FactStatsWinStreak: true,
+}
+
+// playerFacts collects the facts about a player's game. Facts that don't apply to the game are left out.
+func playerFacts(game *model.HistoricGame, result *model.PlayerResult, stats *model.PlayerStats) facts {
+	f := facts{
+		FactWon:               boolFact(result.Won),
+		FactLost:              boolFact(result.Lost),
+		FactLeftEarly:         boolFact(result.LeftEarly),
+		FactPlayerCount:       float64(len(game.Players)),
+		FactDurationSeconds:   game.Duration.Seconds(),
+		FactTimeInGameSeconds: result.TimeInGame.Seconds(),
+	}
+
+	switch data := game.GameData.(type) {
+	case *model.HistoricBlockSumoData:
+		if data.Scoreboard != nil {
+			if entry, ok := data.Scoreboard.Entries[result.PlayerId]; ok {
+				f[FactBlockSumoKills] = float64(entry.Kills)
+				f[FactBlockSumoFinalKills] = float64(entry.FinalKills)
+				f[FactBlockSumoRemainingLives] = float64(entry.RemainingLives)
+			}
+		}
+		if livesLost, ok := data.LivesLost(result.PlayerId); ok {
+			f[FactBlockSumoLivesLost] = float64(livesLost)
+		}
+	case *model.HistoricTowerDefenceData:
+		own, enemy, ok := towerHealth(data, result.TeamId)
+		if !ok {
+			break
+		}
+
+		f[FactTowerDefenceOwnHealth] = float64(own)
+		f[FactTowerDefenceEnemyHealth] = float64(enemy)
+		if data.MaxHealth > 0 {
+			f[FactTowerDefenceOwnHealthPercent] = float64(own) / float64(data.MaxHealth) * 100
+		}
+	}
+
+	if stats != nil {
+		f[FactStatsGamesPlayed] = float64(stats.GamesPlayed)
+		f[FactStatsWins] = float64(stats.Wins)
+		f[FactStatsLosses] = float64(stats.Losses)
+		f[FactStatsKills] = float64(stats.Kills)
+		f[FactStatsFinalKills] = float64(stats.FinalKills)
+		f[FactStatsWinStreak] = float64(stats.WinStreak)
+	}
+
+	return f
+}
+
+// towerHealth returns the health of the team's tower and the enemy tower, or false if the player wasn't on a team
+func towerHealth(data *model.HistoricTowerDefenceData, teamId string) (int32, int32, bool) {
+	switch teamId {
+	case model.TowerDefenceRedTeamId:
+		return data.RedHealth, data.BlueHealth, true
+	case model.TowerDefenceBlueTeamId:
+		return data.BlueHealth, data.RedHealth, true
+	}
+
+	return 0, 0, false
+}
+
+func boolFact(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
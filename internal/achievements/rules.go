@@ -0,0 +1,132 @@
+package achievements
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+)
+
+// Rule is an achievement unlocked by a player the first time a game they took part in matches all of its conditions
+type Rule struct {
+	Id          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// GameModeId limits the rule to a game mode, empty for any
+	GameModeId string `yaml:"gameModeId"`
+
+	Conditions []*Condition `yaml:"conditions"`
+}
+
+type Operator string
+
+const (
+	OpEq  Operator = "eq"
+	OpNe  Operator = "ne"
+	OpGt  Operator = "gt"
+	OpGte Operator = "gte"
+	OpLt  Operator = "lt"
+	OpLte Operator = "lte"
+)
+
+// Condition compares a fact about the player's game to a number or boolean
+type Condition struct {
+	Fact  string   `yaml:"fact"`
+	Op    Operator `yaml:"op"`
+	Value any      `yaml:"value"`
+
+	// value is Value as a number, booleans being 1 or 0 like their facts
+	value float64
+}
+
+type rulesFile struct {
+	Achievements []*Rule `yaml:"achievements"`
+}
+
+// LoadRules reads and validates the rules in a YAML file
+func LoadRules(path string) ([]*Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read achievements file: %w", err)
+	}
+
+	var file rulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse achievements file: %w", err)
+	}
+
+	ids := make(map[string]bool, len(file.Achievements))
+	for i, r := range file.Achievements {
+		if r.Id == "" {
+			return nil, fmt.Errorf("achievement %d has no id", i)
+		}
+		if ids[r.Id] {
+			return nil, fmt.Errorf("duplicate achievement id %s", r.Id)
+		}
+		ids[r.Id] = true
+
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("invalid achievement %s: %w", r.Id, err)
+		}
+	}
+
+	return file.Achievements, nil
+}
+
+func (r *Rule) validate() error {
+	if r.Name == "" {
+		r.Name = r.Id
+	}
+	if len(r.Conditions) == 0 {
+		return fmt.Errorf("no conditions")
+	}
+
+	for _, c := range r.Conditions {
+		if !knownFacts[c.Fact] {
+			return fmt.Errorf("unknown fact %q", c.Fact)
+		}
+
+		switch c.Op {
+		case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+		default:
+			return fmt.Errorf("unknown operator %q of fact %s", c.Op, c.Fact)
+		}
+
+		switch v := c.Value.(type) {
+		case int:
+			c.value = float64(v)
+		case float64:
+			c.value = v
+		case bool:
+			c.value = boolFact(v)
+		default:
+			return fmt.Errorf("value of fact %s must be a number or boolean", c.Fact)
+		}
+	}
+
+	return nil
+}
+
+// matches returns false if the fact doesn't apply to the game, e.g. a Block Sumo fact in a Tower Defence game
+func (c *Condition) matches(f facts) bool {
+	v, ok := f[c.Fact]
+	if !ok {
+		return false
+	}
+
+	switch c.Op {
+	case OpEq:
+		return v == c.value
+	case OpNe:
+		return v != c.value
+	case OpGt:
+		return v > c.value
+	case OpGte:
+		return v >= c.value
+	case OpLt:
+		return v < c.value
+	case OpLte:
+		return v <= c.value
+	}
+
+	return false
+}
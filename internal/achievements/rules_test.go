@@ -0,0 +1,186 @@
+package achievements
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		wantErr   bool
+		wantName  string
+		wantValue float64
+	}{
+		{
+			name: "valid",
+			yaml: `achievements:
+  - id: first-win
+    name: First Win
+    conditions:
+      - {fact: won, op: eq, value: true}`,
+			wantName:  "First Win",
+			wantValue: 1,
+		},
+		{
+			name: "name defaults to the id",
+			yaml: `achievements:
+  - id: killer
+    gameModeId: blocksumo
+    conditions:
+      - {fact: blockSumo.kills, op: gte, value: 10}`,
+			wantName:  "killer",
+			wantValue: 10,
+		},
+		{
+			name: "fractional value",
+			yaml: `achievements:
+  - id: close-call
+    conditions:
+      - {fact: towerDefence.ownHealthPercent, op: lt, value: 0.5}`,
+			wantName:  "close-call",
+			wantValue: 0.5,
+		},
+		{
+			name: "false is zero",
+			yaml: `achievements:
+  - id: stayed
+    conditions:
+      - {fact: leftEarly, op: eq, value: false}`,
+			wantName: "stayed",
+		},
+		{name: "not yaml", yaml: `achievements: [`, wantErr: true},
+		{
+			name: "no id",
+			yaml: `achievements:
+  - name: First Win
+    conditions:
+      - {fact: won, op: eq, value: true}`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate id",
+			yaml: `achievements:
+  - id: first-win
+    conditions:
+      - {fact: won, op: eq, value: true}
+  - id: first-win
+    conditions:
+      - {fact: lost, op: eq, value: true}`,
+			wantErr: true,
+		},
+		{
+			name: "no conditions",
+			yaml: `achievements:
+  - id: first-win`,
+			wantErr: true,
+		},
+		{
+			name: "unknown fact",
+			yaml: `achievements:
+  - id: first-win
+    conditions:
+      - {fact: victories, op: eq, value: true}`,
+			wantErr: true,
+		},
+		{
+			name: "unknown operator",
+			yaml: `achievements:
+  - id: first-win
+    conditions:
+      - {fact: won, op: is, value: true}`,
+			wantErr: true,
+		},
+		{
+			name: "no operator",
+			yaml: `achievements:
+  - id: first-win
+    conditions:
+      - {fact: won, value: true}`,
+			wantErr: true,
+		},
+		{
+			name: "string value",
+			yaml: `achievements:
+  - id: first-win
+    conditions:
+      - {fact: won, op: eq, value: "yes"}`,
+			wantErr: true,
+		},
+		{
+			name: "no value",
+			yaml: `achievements:
+  - id: first-win
+    conditions:
+      - {fact: won, op: eq}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "achievements.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0o600); err != nil {
+				t.Fatalf("failed to write rules: %v", err)
+			}
+
+			rules, err := LoadRules(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(rules) != 1 {
+				t.Fatalf("got %d rules, want 1", len(rules))
+			}
+			if rules[0].Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", rules[0].Name, tt.wantName)
+			}
+			if v := rules[0].Conditions[0].value; v != tt.wantValue {
+				t.Errorf("value = %v, want %v", v, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestLoadRulesMissingFile(t *testing.T) {
+	if _, err := LoadRules(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadRules() of a missing file succeeded")
+	}
+}
+
+func TestConditionMatches(t *testing.T) {
+	f := facts{FactWon: 1, FactBlockSumoKills: 5}
+
+	tests := []struct {
+		name      string
+		condition Condition
+		want      bool
+	}{
+		{name: "eq", condition: Condition{Fact: FactWon, Op: OpEq, value: 1}, want: true},
+		{name: "eq mismatch", condition: Condition{Fact: FactWon, Op: OpEq, value: 0}, want: false},
+		{name: "ne", condition: Condition{Fact: FactWon, Op: OpNe, value: 0}, want: true},
+		{name: "gt", condition: Condition{Fact: FactBlockSumoKills, Op: OpGt, value: 5}, want: false},
+		{name: "gte", condition: Condition{Fact: FactBlockSumoKills, Op: OpGte, value: 5}, want: true},
+		{name: "lt", condition: Condition{Fact: FactBlockSumoKills, Op: OpLt, value: 6}, want: true},
+		{name: "lte", condition: Condition{Fact: FactBlockSumoKills, Op: OpLte, value: 4}, want: false},
+		{
+			name:      "fact that doesn't apply to the game",
+			condition: Condition{Fact: FactTowerDefenceOwnHealth, Op: OpLt, value: 100},
+			want:      false,
+		},
+		{name: "unknown operator", condition: Condition{Fact: FactWon, Op: "is", value: 1}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.condition.matches(f); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
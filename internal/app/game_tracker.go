@@ -2,9 +2,15 @@ package app
 
 import (
 	"context"
+	"game-tracker/internal/achievements"
 	"game-tracker/internal/config"
+	"game-tracker/internal/gateway"
 	"game-tracker/internal/kafka"
+	"game-tracker/internal/live"
 	"game-tracker/internal/repository"
+	"game-tracker/internal/seasons"
+	"game-tracker/internal/service"
+	"game-tracker/internal/webhook"
 	"go.uber.org/zap"
 	"os/signal"
 	"sync"
@@ -24,9 +30,71 @@ func Run(cfg config.Config, logger *zap.SugaredLogger) {
 		logger.Fatalw("failed to create repository", err)
 	}
 
-	kafka.NewConsumer(ctx, wg, cfg.Kafka, logger, repo)
+	if !repo.SupportsTransactions() {
+		if cfg.Kafka.EventsTopic != "" || cfg.Webhooks.Enabled || cfg.Live.Source == config.LiveSourceChangeStream {
+			logger.Fatalw("mongo must run as a replica set to publish events, send webhooks or watch a change stream",
+				"eventsTopic", cfg.Kafka.EventsTopic, "webhooksEnabled", cfg.Webhooks.Enabled, "liveSource", cfg.Live.Source)
+		}
+		logger.Warnw("mongo isn't a replica set, finished games and their stats are saved without transactions")
+	}
+
+	if cfg.Erasure.Secret == "" {
+		erased, err := repo.HasErasures(ctx)
+		if err != nil {
+			logger.Fatalw("failed to check for erased players", "error", err)
+		}
+		if erased {
+			logger.Fatalw("players have been erased, the erasure secret is required to keep them out of new games")
+		}
+	}
+
+	if cfg.MongoDB.MigrateOnStartup {
+		migrated, err := repo.MigrateGames(ctx, cfg.MongoDB.MigrationBatchSize)
+		if err != nil {
+			logger.Fatalw("failed to migrate games", "error", err)
+		}
+		logger.Infow("migrated games", "count", migrated)
+	}
 
-	//service.RunServices(ctx, logger, wg, cfg, repo) todo: add services
+	var relay *kafka.OutboxRelay
+	if cfg.Kafka.EventsTopic != "" {
+		producer := kafka.NewProducer(ctx, wg, cfg.Kafka, logger)
+		relay = kafka.NewOutboxRelay(ctx, wg, logger, repo, producer)
+	}
+
+	hub := live.NewHub(logger)
+	localHub := hub
+	if cfg.Live.Source == config.LiveSourceChangeStream {
+		live.WatchRepository(ctx, wg, logger, repo, hub)
+		localHub = nil
+	}
+
+	var webhooks *webhook.Dispatcher
+	if cfg.Webhooks.Enabled {
+		webhooks = webhook.NewDispatcher(ctx, wg, logger, repo)
+	}
+
+	var achievementEngine *achievements.Engine
+	if cfg.Achievements.File != "" {
+		if achievementEngine, err = achievements.Load(cfg.Achievements.File); err != nil {
+			logger.Fatalw("failed to load achievements", "error", err)
+		}
+	}
+
+	kafka.NewConsumer(ctx, wg, cfg.Kafka, logger, repo, relay, localHub, webhooks, achievementEngine, cfg.Seasons,
+		cfg.Erasure)
+
+	if len(cfg.Seasons) > 0 {
+		seasons.NewRollover(logger, repo, cfg.Seasons).Start(ctx, wg)
+	}
+
+	if cfg.HTTP.Port != 0 {
+		gateway.NewServer(ctx, wg, cfg.HTTP, cfg.Seasons, logger, repo, hub)
+	}
+
+	if cfg.GRPCPort != 0 {
+		service.RunServices(ctx, logger, wg, cfg, repo, hub)
+	}
 
 	wg.Wait()
 	logger.Info("shutting down")
@@ -0,0 +1,345 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const restoreBatchSize = 500
+
+// Archiver moves historic games older than their game mode's retention period into gzip'd JSON lines files
+// and restores them on request. Each line is a game document in canonical extended JSON.
+type Archiver struct {
+	logger *zap.SugaredLogger
+	repo   repository.Repository
+	dir    string
+}
+
+func NewArchiver(logger *zap.SugaredLogger, repo repository.Repository, dir string) *Archiver {
+	return &Archiver{
+		logger: logger,
+		repo:   repo,
+		dir:    dir,
+	}
+}
+
+// Archive archives the games of each game mode that ended more than the configured number of days before now.
+func (a *Archiver) Archive(ctx context.Context, retentionDays map[string]int, now time.Time) ([]*model.Archive, error) {
+	archives := make([]*model.Archive, 0, len(retentionDays))
+	for gameModeId, days := range retentionDays {
+		cutoff := now.AddDate(0, 0, -days)
+
+		archive, err := a.archiveGameMode(ctx, gameModeId, cutoff)
+		if err != nil {
+			return archives, fmt.Errorf("failed to archive %s: %w", gameModeId, err)
+		}
+		if archive == nil {
+			a.logger.Infow("no games to archive", "gameModeId", gameModeId, "cutoff", cutoff)
+			continue
+		}
+
+		a.logger.Infow("archived games", "gameModeId", gameModeId, "path", archive.Path, "games", archive.GameCount,
+			"from", archive.From, "to", archive.To)
+		archives = append(archives, archive)
+	}
+
+	return archives, nil
+}
+
+// archiveGameMode writes every game of the mode that ended before the cutoff to a new archive file,
+// saves its manifest and only then deletes the games. It returns nil if there was nothing to archive.
+func (a *Archiver) archiveGameMode(ctx context.Context, gameModeId string, cutoff time.Time) (*model.Archive, error) {
+	filter := repository.HistoricGameFilter{GameModeId: gameModeId, To: &cutoff}
+
+	// Aggregates must be computed before the games are deleted so the stats don't change
+	aggregates, err := a.repo.AggregateHistoricGames(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate games: %w", err)
+	}
+
+	archive := &model.Archive{
+		Id:         primitive.NewObjectID(),
+		GameModeId: gameModeId,
+		CreatedAt:  time.Now(),
+		Aggregates: aggregates,
+	}
+
+	modeDir := filepath.Join(a.dir, gameModeId)
+	if err := os.MkdirAll(modeDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	tmpPath := filepath.Join(modeDir, archive.Id.Hex()+".jsonl.gz.tmp")
+	ids, playerIds, err := a.writeGames(ctx, tmpPath, filter, archive)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, os.Remove(tmpPath)
+	}
+
+	archive.GameCount = len(ids)
+	archive.PlayerIds = playerIds
+	archive.Path = filepath.Join(modeDir, fmt.Sprintf("%s_%s_%s_%s.jsonl.gz", gameModeId,
+		archive.From.UTC().Format("20060102"), archive.To.UTC().Format("20060102"), archive.Id.Hex()))
+
+	if err := os.Rename(tmpPath, archive.Path); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to move archive into place: %w", err)
+	}
+
+	if err := a.repo.SaveArchive(ctx, archive); err != nil {
+		return nil, fmt.Errorf("failed to save archive manifest: %w", err)
+	}
+
+	deleted, err := a.repo.DeleteHistoricGames(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete archived games: %w", err)
+	}
+	if deleted != int64(len(ids)) {
+		a.logger.Warnw("deleted fewer games than were archived", "gameModeId", gameModeId, "archived", len(ids), "deleted", deleted)
+	}
+
+	return archive, nil
+}
+
+// writeGames streams the games matching the filter into a gzip'd JSON lines file, setting the archive's time range.
+func (a *Archiver) writeGames(ctx context.Context, path string, filter repository.HistoricGameFilter,
+	archive *model.Archive) ([]primitive.ObjectID, []uuid.UUID, error) {
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	w := bufio.NewWriter(gz)
+
+	ids := make([]primitive.ObjectID, 0)
+	players := make(map[uuid.UUID]struct{})
+
+	err = a.repo.StreamHistoricGames(ctx, filter, func(raw bson.Raw) error {
+		game, err := repository.DecodeHistoricGame(raw)
+		if err != nil {
+			return err
+		}
+
+		line, err := bson.MarshalExtJSON(raw, true, false)
+		if err != nil {
+			return fmt.Errorf("failed to marshal game %s: %w", game.Id.Hex(), err)
+		}
+
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("failed to write game %s: %w", game.Id.Hex(), err)
+		}
+
+		if len(ids) == 0 {
+			archive.From = game.EndTime
+		}
+		archive.To = game.EndTime
+		ids = append(ids, game.Id)
+
+		for _, id := range game.ReferencedPlayerIds() {
+			players[id] = struct{}{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to stream games: %w", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		return nil, nil, fmt.Errorf("failed to flush archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return nil, nil, fmt.Errorf("failed to sync archive: %w", err)
+	}
+
+	playerIds := make([]uuid.UUID, 0, len(players))
+	for id := range players {
+		playerIds = append(playerIds, id)
+	}
+
+	return ids, playerIds, nil
+}
+
+// Restore reloads every archive of the game mode overlapping the range back into the database,
+// then removes the archive. Archives are restored whole, so games slightly outside the range may be restored too.
+func (a *Archiver) Restore(ctx context.Context, gameModeId string, from *time.Time, to *time.Time) (int64, error) {
+	archives, err := a.repo.GetArchives(ctx, gameModeId, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get archives: %w", err)
+	}
+
+	var total int64
+	for _, archive := range archives {
+		restored, err := a.restoreArchive(ctx, archive)
+		total += restored
+		if err != nil {
+			return total, fmt.Errorf("failed to restore archive %s: %w", archive.Path, err)
+		}
+
+		a.logger.Infow("restored archive", "path", archive.Path, "games", restored)
+	}
+
+	return total, nil
+}
+
+func (a *Archiver) restoreArchive(ctx context.Context, archive *model.Archive) (int64, error) {
+	var restored int64
+	batch := make([]bson.Raw, 0, restoreBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		count, err := a.repo.RestoreHistoricGames(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		restored += count
+		batch = batch[:0]
+		return nil
+	}
+
+	err := readGames(archive.Path, func(raw bson.Raw) error {
+		batch = append(batch, raw)
+		if len(batch) >= restoreBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return restored, err
+	}
+
+	if err := flush(); err != nil {
+		return restored, err
+	}
+
+	// The manifest is deleted first so the archive's aggregates aren't counted twice if removing the file fails
+	if err := a.repo.DeleteArchive(ctx, archive.Id); err != nil {
+		return restored, fmt.Errorf("failed to delete archive manifest: %w", err)
+	}
+
+	if err := os.Remove(archive.Path); err != nil {
+		a.logger.Errorw("failed to remove restored archive file", "path", archive.Path, "error", err)
+	}
+
+	return restored, nil
+}
+
+// ErasePlayer rewrites the archive files, replacing the player with the pseudonym in every game.
+// The archive manifests are pseudonymised by repository.Repository#ErasePlayer, which returns the paths to rewrite.
+func ErasePlayer(paths []string, playerId uuid.UUID, pseudonym uuid.UUID) error {
+	for _, path := range paths {
+		if err := erasePlayerFromFile(path, playerId, pseudonym); err != nil {
+			return fmt.Errorf("failed to erase player from %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func erasePlayerFromFile(path string, playerId uuid.UUID, pseudonym uuid.UUID) error {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	w := bufio.NewWriter(gz)
+
+	err = readGames(path, func(raw bson.Raw) error {
+		game, err := repository.DecodeHistoricGame(raw)
+		if err != nil {
+			return err
+		}
+
+		if game.ReplacePlayer(playerId, pseudonym) {
+			if raw, err = repository.EncodeHistoricGame(game); err != nil {
+				return err
+			}
+		}
+
+		line, err := bson.MarshalExtJSON(raw, true, false)
+		if err != nil {
+			return fmt.Errorf("failed to marshal game %s: %w", game.Id.Hex(), err)
+		}
+
+		_, err = w.Write(append(line, '\n'))
+		return err
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// readGames calls fn for every game in an archive file
+func readGames(path string, fn func(raw bson.Raw) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip reader: %w", err)
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		var raw bson.Raw
+		if err := bson.UnmarshalExtJSON(scanner.Bytes(), true, &raw); err != nil {
+			return fmt.Errorf("failed to parse line %d: %w", line, err)
+		}
+
+		if err := fn(raw); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	return nil
+}
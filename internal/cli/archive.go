@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/archive"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"time"
+)
+
+var (
+	restoreGameModeId string
+	restoreFrom       string
+	restoreTo         string
+)
+
+var archiveCommand = &Command{
+	Name:        "archive",
+	Description: "Move historic games older than each game mode's retention period into archive files",
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		if len(cfg.Retention.Days) == 0 {
+			return fmt.Errorf("no retention periods configured, set retention-days")
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			archiver := archive.NewArchiver(logger, repo, cfg.Retention.ArchiveDir)
+
+			archives, err := archiver.Archive(ctx, cfg.Retention.Days, time.Now())
+			if err != nil {
+				return err
+			}
+
+			logger.Infow("archival complete", "archives", len(archives))
+			return nil
+		})
+	},
+}
+
+var restoreArchiveCommand = &Command{
+	Name:        "restore-archive",
+	Description: "Reload archived historic games of a game mode back into the database",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&restoreGameModeId, "game-mode", "", "Game mode of the archives to restore")
+		flags.StringVar(&restoreFrom, "from", "", "Restore archives containing games that ended on or after this date (YYYY-MM-DD)")
+		flags.StringVar(&restoreTo, "to", "", "Restore archives containing games that ended before this date (YYYY-MM-DD)")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		if restoreGameModeId == "" {
+			return fmt.Errorf("--game-mode is required")
+		}
+
+		from, err := parseDateFlag("from", restoreFrom)
+		if err != nil {
+			return err
+		}
+
+		to, err := parseDateFlag("to", restoreTo)
+		if err != nil {
+			return err
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			archiver := archive.NewArchiver(logger, repo, cfg.Retention.ArchiveDir)
+
+			restored, err := archiver.Restore(ctx, restoreGameModeId, from, to)
+			if err != nil {
+				return err
+			}
+
+			logger.Infow("restore complete", "gameModeId", restoreGameModeId, "games", restored)
+			return nil
+		})
+	},
+}
+
+// parseDateFlag parses an optional YYYY-MM-DD flag value as midnight UTC
+func parseDateFlag(name string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s date: %w", name, err)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/erasure"
+	"game-tracker/internal/repository"
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+)
+
+var (
+	erasePlayerId          string
+	erasePlayerRequestedBy string
+)
+
+var erasePlayerCommand = &Command{
+	Name:        "erase-player",
+	Description: "Pseudonymise a player across all stored games and delete their derived data (GDPR erasure)",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&erasePlayerId, "player-id", "", "UUID of the player to erase")
+		flags.StringVar(&erasePlayerRequestedBy, "requested-by", "", "Staff member or ticket the erasure was requested by")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		playerId, err := uuid.Parse(erasePlayerId)
+		if err != nil {
+			return fmt.Errorf("invalid player id: %w", err)
+		}
+
+		if erasePlayerRequestedBy == "" {
+			return fmt.Errorf("--requested-by is required for the audit record")
+		}
+		if cfg.Erasure.Secret == "" {
+			return fmt.Errorf("--erasure-secret is required so the player can be recognised without storing their id")
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			audit, err := erasure.ErasePlayer(ctx, repo, cfg.Erasure, playerId, erasePlayerRequestedBy)
+			if err != nil {
+				return err
+			}
+
+			logger.Infow("erased player", "auditId", audit.Id.Hex(), "pseudonym", audit.Pseudonym,
+				"liveGames", len(audit.LiveGameIds), "historicGames", len(audit.HistoricGameIds),
+				"archives", len(audit.ArchivePaths), "deletedPlayerRecords", audit.DeletedPlayerRecords,
+				"seasonSnapshots", audit.SeasonIds, "outboxEvents", audit.OutboxEvents)
+			return nil
+		})
+	},
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/export"
+	"game-tracker/internal/repository"
+	"github.com/spf13/pflag"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+	"io"
+	"os"
+)
+
+var (
+	exportGamesFormat     string
+	exportGamesGameModeId string
+	exportGamesMapId      string
+	exportGamesFrom       string
+	exportGamesTo         string
+	exportGamesOutput     string
+)
+
+var exportGamesCommand = &Command{
+	Name:        "export-games",
+	Description: "Export historic games matching a filter as CSV, JSON Lines or Parquet",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&exportGamesFormat, "format", string(export.FormatJSONL), "Output format: csv, jsonl or parquet")
+		flags.StringVar(&exportGamesGameModeId, "game-mode", "", "Only export games of this game mode")
+		flags.StringVar(&exportGamesMapId, "map", "", "Only export games played on this map")
+		flags.StringVar(&exportGamesFrom, "from", "", "Only export games that ended on or after this date (YYYY-MM-DD)")
+		flags.StringVar(&exportGamesTo, "to", "", "Only export games that ended before this date (YYYY-MM-DD)")
+		flags.StringVar(&exportGamesOutput, "out", "", "File to write the export to (default stdout, required for parquet)")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		format, err := export.ParseFormat(exportGamesFormat)
+		if err != nil {
+			return err
+		}
+
+		if format == export.FormatParquet && exportGamesOutput == "" {
+			return fmt.Errorf("--out is required for parquet exports")
+		}
+
+		filter := repository.HistoricGameFilter{GameModeId: exportGamesGameModeId, MapId: exportGamesMapId}
+		if filter.From, err = parseDateFlag("from", exportGamesFrom); err != nil {
+			return err
+		}
+		if filter.To, err = parseDateFlag("to", exportGamesTo); err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if exportGamesOutput != "" {
+			f, err := os.Create(exportGamesOutput)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		w, err := export.NewGameWriter(out, format)
+		if err != nil {
+			return err
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			count := 0
+			err := repo.StreamHistoricGames(ctx, filter, func(raw bson.Raw) error {
+				game, err := repository.DecodeHistoricGame(raw)
+				if err != nil {
+					return err
+				}
+
+				if err := w.Write(export.GameRecordFromModel(game)); err != nil {
+					return fmt.Errorf("failed to write game %s: %w", game.Id.Hex(), err)
+				}
+
+				count++
+				if count%10000 == 0 {
+					logger.Infow("exporting games", "exported", count)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := w.Close(); err != nil {
+				return fmt.Errorf("failed to finish export: %w", err)
+			}
+
+			logger.Infow("exported games", "games", count, "format", format)
+			return nil
+		})
+	},
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/export"
+	"game-tracker/internal/repository"
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"os"
+)
+
+var (
+	exportPlayerId     string
+	exportPlayerOutput string
+	exportPlayerZip    bool
+)
+
+var exportPlayerCommand = &Command{
+	Name:        "export-player",
+	Description: "Export everything stored about a player as a JSON document",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&exportPlayerId, "player-id", "", "UUID of the player to export")
+		flags.StringVar(&exportPlayerOutput, "out", "", "File to write the export to (default <player-id>.json or .zip)")
+		flags.BoolVar(&exportPlayerZip, "zip", false, "Write the JSON document inside a zip archive")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		playerId, err := uuid.Parse(exportPlayerId)
+		if err != nil {
+			return fmt.Errorf("invalid player id: %w", err)
+		}
+
+		output := exportPlayerOutput
+		if output == "" {
+			output = playerId.String() + ".json"
+			if exportPlayerZip {
+				output = playerId.String() + ".zip"
+			}
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			bundle, err := export.BuildPlayerBundle(ctx, repo, playerId)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+
+			if err := export.WriteBundle(f, bundle, exportPlayerZip); err != nil {
+				return err
+			}
+
+			logger.Infow("exported player", "playerId", playerId, "games", len(bundle.Games), "output", output)
+			return f.Close()
+		})
+	},
+}
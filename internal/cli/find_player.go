@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+)
+
+var (
+	findPlayerUsername  string
+	findPlayerId        string
+	findPlayerGameCount int64
+)
+
+var findPlayerCommand = &Command{
+	Name:        "find-player",
+	Description: "Find players by current or former username, or by id, and list their recent games",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&findPlayerUsername, "username", "", "Current or former username (case-insensitive)")
+		flags.StringVar(&findPlayerId, "player-id", "", "Player UUID")
+		flags.Int64Var(&findPlayerGameCount, "games", 10, "Number of recent games to list per player")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			players, err := findPlayers(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			if len(players) == 0 {
+				logger.Infow("no players found")
+				return nil
+			}
+
+			for _, p := range players {
+				previous := make([]string, len(p.PreviousUsernames))
+				for i, u := range p.PreviousUsernames {
+					previous[i] = fmt.Sprintf("%s (%s - %s)", u.Username, u.FirstSeen.Format("2006-01-02"), u.LastSeen.Format("2006-01-02"))
+				}
+
+				logger.Infow("player", "id", p.Id, "username", p.Username, "previousUsernames", previous,
+					"firstSeen", p.FirstSeen, "lastSeen", p.LastSeen)
+
+				games, err := repo.ListHistoricGames(ctx, repository.HistoricGameFilter{PlayerId: p.Id}, 0, findPlayerGameCount)
+				if err != nil {
+					return fmt.Errorf("failed to list games of %s: %w", p.Id, err)
+				}
+
+				for _, g := range games {
+					logger.Infow("game", "playerId", p.Id, "gameId", g.Id.Hex(), "gameModeId", g.GameModeId,
+						"mapId", g.MapId, "endTime", g.EndTime)
+				}
+			}
+
+			return nil
+		})
+	},
+}
+
+func findPlayers(ctx context.Context, repo repository.Repository) ([]*model.Player, error) {
+	if findPlayerId != "" {
+		id, err := uuid.Parse(findPlayerId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid player id: %w", err)
+		}
+
+		player, err := repo.GetPlayer(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		return []*model.Player{player}, nil
+	}
+
+	if findPlayerUsername == "" {
+		return nil, fmt.Errorf("either --username or --player-id is required")
+	}
+
+	return repo.SearchPlayersByUsername(ctx, findPlayerUsername)
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/importer"
+	"game-tracker/internal/repository"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"io"
+	"os"
+)
+
+var (
+	importGamesInput       string
+	importGamesOnDuplicate string
+	importGamesBatchSize   int
+	importGamesDryRun      bool
+)
+
+var importGamesCommand = &Command{
+	Name:        "import-games",
+	Description: "Import historic games from JSON Lines in the export-games format",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&importGamesInput, "in", "", "File to read games from (default stdin)")
+		flags.StringVar(&importGamesOnDuplicate, "on-duplicate", string(repository.DuplicateSkip),
+			"What to do with games that are already stored: upsert, skip or fail")
+		flags.IntVar(&importGamesBatchSize, "batch-size", 500, "Number of games written per batch")
+		flags.BoolVar(&importGamesDryRun, "dry-run", false, "Validate the games without importing them")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		mode := repository.DuplicateMode(importGamesOnDuplicate)
+		switch mode {
+		case repository.DuplicateUpsert, repository.DuplicateSkip, repository.DuplicateFail:
+		default:
+			return fmt.Errorf("invalid --on-duplicate %q, expected upsert, skip or fail", importGamesOnDuplicate)
+		}
+
+		if importGamesBatchSize <= 0 {
+			return fmt.Errorf("--batch-size must be positive")
+		}
+
+		var in io.Reader = os.Stdin
+		if importGamesInput != "" {
+			f, err := os.Open(importGamesInput)
+			if err != nil {
+				return fmt.Errorf("failed to open input file: %w", err)
+			}
+			defer f.Close()
+			in = f
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			imp := importer.NewImporter(logger, repo, mode, importGamesBatchSize)
+			imp.DryRun = importGamesDryRun
+
+			summary, err := imp.Import(ctx, in)
+			logger.Infow("import summary", "lines", summary.Lines, "invalid", summary.Invalid, "inserted", summary.Inserted,
+				"updated", summary.Updated, "skipped", summary.Skipped, "dryRun", importGamesDryRun)
+
+			return err
+		})
+	},
+}
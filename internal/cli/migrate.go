@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"context"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"go.uber.org/zap"
+)
+
+var migrateCommand = &Command{
+	Name:        "migrate",
+	Description: "Upgrade every stored game with an outdated schema version",
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			migrated, err := repo.MigrateGames(ctx, cfg.MongoDB.MigrationBatchSize)
+			if err != nil {
+				return err
+			}
+
+			logger.Infow("migrated games", "count", migrated)
+			return nil
+		})
+	},
+}
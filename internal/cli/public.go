@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// Command is a one-off administrative operation run instead of the tracker, e.g. `game-tracker migrate`.
+type Command struct {
+	Name        string
+	Description string
+
+	// RegisterFlags registers the command's own flags. It is called before the global config is parsed.
+	RegisterFlags func(flags *pflag.FlagSet)
+	Run           func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error
+}
+
+var Commands = map[string]*Command{
+	migrateCommand.Name:        migrateCommand,
+	findPlayerCommand.Name:     findPlayerCommand,
+	erasePlayerCommand.Name:    erasePlayerCommand,
+	exportGamesCommand.Name:    exportGamesCommand,
+	importGamesCommand.Name:    importGamesCommand,
+	replayCommand.Name:         replayCommand,
+	exportPlayerCommand.Name:   exportPlayerCommand,
+	archiveCommand.Name:        archiveCommand,
+	restoreArchiveCommand.Name: restoreArchiveCommand,
+	snapshotSeasonCommand.Name: snapshotSeasonCommand,
+	recomputeCommand.Name:      recomputeCommand,
+
+	addWebhookCommand.Name:        addWebhookCommand,
+	listWebhooksCommand.Name:      listWebhooksCommand,
+	removeWebhookCommand.Name:     removeWebhookCommand,
+	webhookDeliveriesCommand.Name: webhookDeliveriesCommand,
+}
+
+// FromArgs returns the command named by the first argument, or nil if no command was given.
+func FromArgs(args []string) (*Command, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return nil, nil
+	}
+
+	cmd, ok := Commands[args[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown command %q, available commands: %s", args[0], strings.Join(commandNames(), ", "))
+	}
+
+	return cmd, nil
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(Commands))
+	for name := range Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Execute runs the command until it completes or the process is interrupted.
+func Execute(cmd *Command, cfg config.Config, logger *zap.SugaredLogger) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	logger.Infow("running command", "command", cmd.Name)
+	return cmd.Run(ctx, cfg, logger)
+}
+
+// withRepository connects to the repository for the duration of fn.
+func withRepository(ctx context.Context, cfg config.MongoDBConfig, logger *zap.SugaredLogger,
+	fn func(repo repository.Repository) error) error {
+
+	wg := &sync.WaitGroup{}
+	repoCtx, repoCancel := context.WithCancel(ctx)
+	defer func() {
+		repoCancel()
+		wg.Wait()
+	}()
+
+	repo, err := repository.NewMongoRepository(repoCtx, logger, wg, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	return fn(repo)
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/recompute"
+	"game-tracker/internal/repository"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+)
+
+var (
+	recomputeFresh          bool
+	recomputeIgnoreArchives bool
+)
+
+var recomputeCommand = &Command{
+	Name:        "recompute",
+	Description: "Rebuild player stats, streaks, pairs, leaderboards and ratings from historic games and swap them in",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.BoolVar(&recomputeFresh, "fresh", false, "Start over instead of resuming an interrupted recompute, unless it was interrupted while swapping")
+		flags.BoolVar(&recomputeIgnoreArchives, "ignore-archives", false,
+			"Recompute even though archived games exist, dropping them from the rebuilt stats")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			// Only games in the database are walked, so archived games would silently disappear from the stats
+			archives, err := repo.GetArchives(ctx, "", nil, nil)
+			if err != nil {
+				return err
+			}
+			if len(archives) > 0 && !recomputeIgnoreArchives {
+				return fmt.Errorf("%d archives exist and their games would be dropped from the stats, "+
+					"restore them first or pass --ignore-archives", len(archives))
+			}
+
+			checkpoint, err := recompute.NewRecomputer(logger, repo, cfg.Seasons).Run(ctx, !recomputeFresh)
+			if err != nil {
+				// Consumers stay paused until an interrupted swap is resumed
+				if checkpoint != nil {
+					logger.Infow("recompute interrupted, run again to resume", "games", checkpoint.Games,
+						"lastEndTime", checkpoint.LastEndTime, "phase", checkpoint.Phase)
+				}
+				return err
+			}
+
+			logger.Infow("recompute complete", "games", checkpoint.Games, "startedAt", checkpoint.StartedAt)
+			return nil
+		})
+	},
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/kafka"
+	"game-tracker/internal/repository"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"time"
+)
+
+var (
+	replayFrom           string
+	replayTo             string
+	replayOffset         int64
+	replayTargetURI      string
+	replayTargetDatabase string
+)
+
+var replayCommand = &Command{
+	Name:        "replay",
+	Description: "Rebuild games by reprocessing a range of the game-tracker topic into a repository",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&replayFrom, "from", "", "Replay messages produced at or after this time (RFC 3339)")
+		flags.StringVar(&replayTo, "to", "", "Replay messages produced before this time (RFC 3339, default up to the latest message)")
+		flags.Int64Var(&replayOffset, "offset", 0, "Offset to replay every partition from when --from isn't set")
+		flags.StringVar(&replayTargetURI, "target-mongodb-uri", "", "MongoDB URI to write games to (default mongodb-uri)")
+		flags.StringVar(&replayTargetDatabase, "target-mongodb-database", "", "Database to write games to, which must be empty and differ from mongodb-database on the same server (required)")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		opts := kafka.ReplayOptions{Offset: replayOffset}
+
+		var err error
+		if opts.From, err = parseTimeFlag("from", replayFrom); err != nil {
+			return err
+		}
+		if opts.To, err = parseTimeFlag("to", replayTo); err != nil {
+			return err
+		}
+
+		target, err := replayTarget(cfg.MongoDB, replayTargetURI, replayTargetDatabase)
+		if err != nil {
+			return err
+		}
+
+		logger.Infow("replaying games", "from", opts.From, "to", opts.To, "offset", opts.Offset, "database", target.Database)
+
+		// Erased players are looked up in the live database, as the replayed messages still have them
+		return withRepository(ctx, cfg.MongoDB, logger, func(liveRepo repository.Repository) error {
+			if err := checkReplayErasures(ctx, liveRepo, cfg.Erasure); err != nil {
+				return err
+			}
+
+			return withRepository(ctx, target, logger, func(repo repository.Repository) error {
+				if err := checkReplayTargetEmpty(ctx, repo); err != nil {
+					return err
+				}
+
+				result, err := kafka.Replay(ctx, cfg.Kafka, cfg.Seasons, cfg.Erasure, logger, liveRepo, repo, opts)
+				if result != nil {
+					logger.Infow("replay summary", "messages", result.Messages, "started", result.Started,
+						"updated", result.Updated, "finished", result.Finished, "failed", result.Failed, "ignored", result.Ignored)
+				}
+
+				return err
+			})
+		})
+	},
+}
+
+// replayTarget returns the database to replay into. Replaying reprocesses games that are already in the live database,
+// so replaying into it would duplicate their stats, ratings and events.
+func replayTarget(live config.MongoDBConfig, uri string, database string) (config.MongoDBConfig, error) {
+	if database == "" {
+		return config.MongoDBConfig{}, fmt.Errorf("--target-mongodb-database is required")
+	}
+
+	target := live
+	target.Database = database
+	if uri != "" {
+		target.URI = uri
+	}
+
+	if target.URI == live.URI && target.Database == live.Database {
+		return config.MongoDBConfig{}, fmt.Errorf("--target-mongodb-database must differ from the live database %s", live.Database)
+	}
+
+	return target, nil
+}
+
+// checkReplayTargetEmpty refuses to replay into a database that already has games, such as from an earlier replay,
+// as the replayed games would be counted in the stats again
+func checkReplayTargetEmpty(ctx context.Context, repo repository.Repository) error {
+	historic, err := repo.ListHistoricGames(ctx, repository.HistoricGameFilter{}, 0, 1)
+	if err != nil {
+		return fmt.Errorf("failed to check the target database: %w", err)
+	}
+
+	live, err := repo.ListLiveGames(ctx, repository.LiveGameFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to check the target database: %w", err)
+	}
+
+	if len(historic) > 0 || len(live) > 0 {
+		return fmt.Errorf("the target database already has games, drop it or choose another")
+	}
+
+	return nil
+}
+
+// checkReplayErasures refuses to replay without the erasure secret if players have been erased,
+// as the erased players would be brought back in the replayed games
+func checkReplayErasures(ctx context.Context, liveRepo repository.Repository, cfg config.ErasureConfig) error {
+	if cfg.Secret != "" {
+		return nil
+	}
+
+	erased, err := liveRepo.HasErasures(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check for erased players: %w", err)
+	}
+	if erased {
+		return fmt.Errorf("players have been erased, the erasure secret is required to keep them out of replayed games")
+	}
+
+	return nil
+}
+
+// parseTimeFlag parses an optional RFC 3339 flag value, returning the zero time if it isn't set
+func parseTimeFlag(name string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s time: %w", name, err)
+	}
+
+	return t, nil
+}
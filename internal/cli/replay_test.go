@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"testing"
+)
+
+func TestReplayTarget(t *testing.T) {
+	live := config.MongoDBConfig{URI: "mongodb://live:27017", Database: "game-tracker"}
+
+	tests := []struct {
+		name     string
+		uri      string
+		database string
+		want     config.MongoDBConfig
+		wantErr  bool
+	}{
+		{name: "no database", wantErr: true},
+		{name: "live database", database: "game-tracker", wantErr: true},
+		{name: "live database by uri", uri: "mongodb://live:27017", database: "game-tracker", wantErr: true},
+		{
+			name:     "other database",
+			database: "game-tracker-replay",
+			want:     config.MongoDBConfig{URI: "mongodb://live:27017", Database: "game-tracker-replay"},
+		},
+		{
+			name:     "same name on another server",
+			uri:      "mongodb://replay:27017",
+			database: "game-tracker",
+			want:     config.MongoDBConfig{URI: "mongodb://replay:27017", Database: "game-tracker"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replayTarget(live, tt.uri, tt.database)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replayTarget() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("replayTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// gamesRepo lists fixed games. Other repository methods aren't used by the tests.
+type gamesRepo struct {
+	repository.Repository
+	historic []*model.HistoricGame
+	live     []*model.LiveGame
+}
+
+func (r *gamesRepo) ListHistoricGames(_ context.Context, _ repository.HistoricGameFilter, _ int64,
+	_ int64) ([]*model.HistoricGame, error) {
+
+	return r.historic, nil
+}
+
+func (r *gamesRepo) ListLiveGames(_ context.Context, _ repository.LiveGameFilter) ([]*model.LiveGame, error) {
+	return r.live, nil
+}
+
+func TestCheckReplayTargetEmpty(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    *gamesRepo
+		wantErr bool
+	}{
+		{name: "empty", repo: &gamesRepo{}},
+		{name: "historic games", repo: &gamesRepo{historic: []*model.HistoricGame{{}}}, wantErr: true},
+		{name: "live games", repo: &gamesRepo{live: []*model.LiveGame{{}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkReplayTargetEmpty(context.Background(), tt.repo); (err != nil) != tt.wantErr {
+				t.Errorf("checkReplayTargetEmpty() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/seasons"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"time"
+)
+
+var snapshotSeasonId string
+
+var snapshotSeasonCommand = &Command{
+	Name:        "snapshot-season",
+	Description: "Save the hall of fame of an ended season, replacing any existing snapshot",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&snapshotSeasonId, "season", "", "Id of the season to snapshot")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		season := cfg.Seasons.Get(snapshotSeasonId)
+		if season == nil {
+			return fmt.Errorf("unknown season %q, set --season to a configured season", snapshotSeasonId)
+		}
+
+		now := time.Now()
+		if now.Before(season.End) {
+			return fmt.Errorf("season %s hasn't ended yet, it ends at %s", season.Id, season.End)
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			snapshot, err := seasons.NewRollover(logger, repo, cfg.Seasons).Snapshot(ctx, season, now)
+			if err != nil {
+				return err
+			}
+
+			logger.Infow("saved season snapshot", "seasonId", season.Id, "gameModes", len(snapshot.GameModes),
+				"players", len(snapshot.PlayerIds))
+			return nil
+		})
+	},
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"game-tracker/internal/webhook"
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"net/url"
+	"time"
+)
+
+var (
+	addWebhookUrl            string
+	addWebhookSecret         string
+	addWebhookDescription    string
+	addWebhookFormat         string
+	addWebhookGameModeId     string
+	addWebhookServerIdPrefix string
+	addWebhookPlayerIds      []string
+
+	removeWebhookId string
+
+	webhookDeliveriesId    string
+	webhookDeliveriesLimit int64
+)
+
+var addWebhookCommand = &Command{
+	Name:        "add-webhook",
+	Description: "Subscribe a URL to notifications of finished games matching a filter",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&addWebhookUrl, "url", "", "HTTP(S) URL the notifications are POSTed to")
+		flags.StringVar(&addWebhookSecret, "secret", "", "Secret the notifications are signed with, generated if not set")
+		flags.StringVar(&addWebhookDescription, "description", "", "Description of the subscription, e.g. its owner")
+		flags.StringVar(&addWebhookFormat, "format", string(model.WebhookFormatGame), "Payload format: game (full game JSON) or discord (match summary embed for a Discord webhook URL)")
+		flags.StringVar(&addWebhookGameModeId, "game-mode", "", "Only notify games of this game mode")
+		flags.StringVar(&addWebhookServerIdPrefix, "server-prefix", "", "Only notify games on servers with ids starting with this prefix")
+		flags.StringSliceVar(&addWebhookPlayerIds, "players", nil, "Only notify games any of these player UUIDs took part in")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		subscription, err := newWebhookSubscription()
+		if err != nil {
+			return err
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			if err := repo.SaveWebhookSubscription(ctx, subscription); err != nil {
+				return err
+			}
+
+			logger.Infow("added webhook subscription", "id", subscription.Id.Hex(), "url", subscription.Url)
+
+			// The secret is printed once rather than logged, so it doesn't end up in log storage
+			fmt.Printf("webhook secret: %s\n", subscription.Secret)
+			return nil
+		})
+	},
+}
+
+func newWebhookSubscription() (*model.WebhookSubscription, error) {
+	u, err := url.Parse(addWebhookUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("--url must be an HTTP(S) URL")
+	}
+	if err := webhook.CheckURL(u); err != nil {
+		return nil, fmt.Errorf("invalid --url: %w", err)
+	}
+
+	format := model.WebhookFormat(addWebhookFormat)
+	if format != model.WebhookFormatGame && format != model.WebhookFormatDiscord {
+		return nil, fmt.Errorf("--format must be %s or %s", model.WebhookFormatGame, model.WebhookFormatDiscord)
+	}
+
+	playerIds := make([]uuid.UUID, len(addWebhookPlayerIds))
+	for i, idStr := range addWebhookPlayerIds {
+		if playerIds[i], err = uuid.Parse(idStr); err != nil {
+			return nil, fmt.Errorf("invalid player id %q: %w", idStr, err)
+		}
+	}
+
+	secret := addWebhookSecret
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate secret: %w", err)
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	return &model.WebhookSubscription{
+		Id:             primitive.NewObjectID(),
+		Url:            u.String(),
+		Secret:         secret,
+		Description:    addWebhookDescription,
+		Format:         format,
+		GameModeId:     addWebhookGameModeId,
+		ServerIdPrefix: addWebhookServerIdPrefix,
+		PlayerIds:      playerIds,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+var listWebhooksCommand = &Command{
+	Name:        "list-webhooks",
+	Description: "List webhook subscriptions",
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			subscriptions, err := repo.GetWebhookSubscriptions(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(subscriptions) == 0 {
+				logger.Infow("no webhook subscriptions")
+				return nil
+			}
+
+			for _, s := range subscriptions {
+				logger.Infow("webhook subscription", "id", s.Id.Hex(), "url", s.Url, "format", s.Format,
+					"description", s.Description, "gameModeId", s.GameModeId, "serverIdPrefix", s.ServerIdPrefix, "playerIds", s.PlayerIds,
+					"createdAt", s.CreatedAt)
+			}
+
+			return nil
+		})
+	},
+}
+
+var removeWebhookCommand = &Command{
+	Name:        "remove-webhook",
+	Description: "Remove a webhook subscription. Its pending deliveries are abandoned.",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&removeWebhookId, "id", "", "Id of the subscription")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		id, err := primitive.ObjectIDFromHex(removeWebhookId)
+		if err != nil {
+			return fmt.Errorf("invalid --id: %w", err)
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			if err := repo.DeleteWebhookSubscription(ctx, id); err != nil {
+				return err
+			}
+
+			logger.Infow("removed webhook subscription", "id", id.Hex())
+			return nil
+		})
+	},
+}
+
+var webhookDeliveriesCommand = &Command{
+	Name:        "webhook-deliveries",
+	Description: "List the most recent deliveries of a webhook subscription and their attempts",
+	RegisterFlags: func(flags *pflag.FlagSet) {
+		flags.StringVar(&webhookDeliveriesId, "id", "", "Id of the subscription")
+		flags.Int64Var(&webhookDeliveriesLimit, "limit", 20, "Number of deliveries to list")
+	},
+	Run: func(ctx context.Context, cfg config.Config, logger *zap.SugaredLogger) error {
+		id, err := primitive.ObjectIDFromHex(webhookDeliveriesId)
+		if err != nil {
+			return fmt.Errorf("invalid --id: %w", err)
+		}
+
+		return withRepository(ctx, cfg.MongoDB, logger, func(repo repository.Repository) error {
+			deliveries, err := repo.GetWebhookDeliveries(ctx, id, webhookDeliveriesLimit)
+			if err != nil {
+				return err
+			}
+
+			if len(deliveries) == 0 {
+				logger.Infow("no webhook deliveries")
+				return nil
+			}
+
+			for _, d := range deliveries {
+				logger.Infow("webhook delivery", "id", d.Id.Hex(), "gameId", d.GameId.Hex(), "status", d.Status,
+					"attempts", len(d.Attempts), "nextAttemptAt", d.NextAttemptAt, "createdAt", d.CreatedAt,
+					"completedAt", d.CompletedAt)
+
+				for _, a := range d.Attempts {
+					logger.Infow("webhook attempt", "deliveryId", d.Id.Hex(), "time", a.Time, "statusCode", a.StatusCode,
+						"duration", a.Duration, "error", a.Error)
+				}
+			}
+
+			return nil
+		})
+	},
+}
@@ -1,58 +1,170 @@
 package config
 
 import (
+	"fmt"
 	"game-tracker/internal/utils/runtime"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	kafkaHostFlag   = "kafka-host"
 	kafkaPortFlag   = "kafka-port"
+	eventsTopicFlag = "kafka-events-topic"
 	mongoDBURIFlag  = "mongodb-uri"
+	mongoDBNameFlag = "mongodb-database"
 	developmentFlag = "development"
 	grpcPortFlag    = "port"
+
+	httpPortFlag           = "http-port"
+	httpAllowedOriginsFlag = "http-allowed-origins"
+
+	migrateOnStartupFlag   = "migrate-on-startup"
+	migrationBatchSizeFlag = "migration-batch-size"
+
+	liveSourceFlag      = "live-source"
+	webhooksEnabledFlag = "webhooks-enabled"
+
+	achievementsFileFlag = "achievements-file"
+	seasonsFlag          = "seasons"
+
+	archiveDirFlag    = "archive-dir"
+	retentionDaysFlag = "retention-days"
+
+	erasureSecretFlag = "erasure-secret"
 )
 
-func LoadGlobalConfig() Config {
+// LoadGlobalConfig reads the config from the flags and environment, returning an error if a value is invalid
+func LoadGlobalConfig() (Config, error) {
 	viper.SetDefault(kafkaHostFlag, "localhost")
 	viper.SetDefault(kafkaPortFlag, 9092)
+	viper.SetDefault(eventsTopicFlag, "")
 	viper.SetDefault(mongoDBURIFlag, "mongodb://localhost:27017")
+	viper.SetDefault(mongoDBNameFlag, "game-tracker")
 	viper.SetDefault(developmentFlag, true)
 	viper.SetDefault(grpcPortFlag, 10010)
+	viper.SetDefault(httpPortFlag, 8080)
+	viper.SetDefault(httpAllowedOriginsFlag, "")
+	viper.SetDefault(migrateOnStartupFlag, false)
+	viper.SetDefault(migrationBatchSizeFlag, 500)
+	viper.SetDefault(liveSourceFlag, LiveSourceLocal)
+	viper.SetDefault(webhooksEnabledFlag, false)
+	viper.SetDefault(achievementsFileFlag, "")
+	viper.SetDefault(seasonsFlag, "")
+	viper.SetDefault(archiveDirFlag, "archive")
+	viper.SetDefault(retentionDaysFlag, "")
+	viper.SetDefault(erasureSecretFlag, "")
 
 	pflag.String(kafkaHostFlag, viper.GetString(kafkaHostFlag), "Kafka host")
 	pflag.Int32(kafkaPortFlag, viper.GetInt32(kafkaPortFlag), "Kafka port")
+	pflag.String(eventsTopicFlag, viper.GetString(eventsTopicFlag), "Kafka topic the tracker's own events are published to, e.g. game-tracker-events. Empty to disable, requires a MongoDB replica set")
 	pflag.String(mongoDBURIFlag, viper.GetString(mongoDBURIFlag), "MongoDB URI")
+	pflag.String(mongoDBNameFlag, viper.GetString(mongoDBNameFlag), "MongoDB database name")
 	pflag.Bool(developmentFlag, viper.GetBool(developmentFlag), "Development mode")
 	pflag.Int32(grpcPortFlag, viper.GetInt32(grpcPortFlag), "gRPC port")
+	pflag.Int32(httpPortFlag, viper.GetInt32(httpPortFlag), "HTTP gateway port, 0 to disable")
+	pflag.String(httpAllowedOriginsFlag, viper.GetString(httpAllowedOriginsFlag), "Comma separated origins allowed to call the HTTP gateway from a browser, * for any")
+	pflag.Bool(migrateOnStartupFlag, viper.GetBool(migrateOnStartupFlag), "Migrate outdated game documents on startup rather than with the migrate command. Every replica scans the games on each start, and outdated games are upgraded when read either way")
+	pflag.Int32(migrationBatchSizeFlag, viper.GetInt32(migrationBatchSizeFlag), "Number of game documents written per migration batch")
+	pflag.String(liveSourceFlag, viper.GetString(liveSourceFlag), "Where live game changes are read from: local (this replica only) or change-stream (every replica)")
+	pflag.Bool(webhooksEnabledFlag, viper.GetBool(webhooksEnabledFlag), "Send webhook notifications when games finish, requires a MongoDB replica set")
+	pflag.String(achievementsFileFlag, viper.GetString(achievementsFileFlag), "YAML file of achievement rules evaluated when games finish, empty to disable achievements")
+	pflag.String(seasonsFlag, viper.GetString(seasonsFlag), "Comma separated seasons stats are bucketed by, e.g. 2026-1=2026-01-01/2026-04-01. Dates are UTC, the end is exclusive")
+	pflag.String(archiveDirFlag, viper.GetString(archiveDirFlag), "Directory historic game archives are written to")
+	pflag.String(retentionDaysFlag, viper.GetString(retentionDaysFlag), "Days historic games are kept per game mode before archival, e.g. tower-defence=90,block-sumo=30")
+	pflag.String(erasureSecretFlag, viper.GetString(erasureSecretFlag), "Secret the ids of erased players are hashed with. Keep it outside MongoDB, required once a player has been erased")
 	pflag.Parse()
 
 	// Bind the viper flags to environment variables
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	runtime.Must(viper.BindEnv(kafkaHostFlag))
 	runtime.Must(viper.BindEnv(kafkaPortFlag))
+	runtime.Must(viper.BindEnv(eventsTopicFlag))
 	runtime.Must(viper.BindEnv(mongoDBURIFlag))
+	runtime.Must(viper.BindEnv(mongoDBNameFlag))
 	runtime.Must(viper.BindEnv(developmentFlag))
 	runtime.Must(viper.BindEnv(grpcPortFlag))
+	runtime.Must(viper.BindEnv(httpPortFlag))
+	runtime.Must(viper.BindEnv(httpAllowedOriginsFlag))
+	runtime.Must(viper.BindEnv(migrateOnStartupFlag))
+	runtime.Must(viper.BindEnv(migrationBatchSizeFlag))
+	runtime.Must(viper.BindEnv(liveSourceFlag))
+	runtime.Must(viper.BindEnv(webhooksEnabledFlag))
+	runtime.Must(viper.BindEnv(achievementsFileFlag))
+	runtime.Must(viper.BindEnv(seasonsFlag))
+	runtime.Must(viper.BindEnv(archiveDirFlag))
+	runtime.Must(viper.BindEnv(retentionDaysFlag))
+	runtime.Must(viper.BindEnv(erasureSecretFlag))
+
+	retentionDays, err := parseRetentionDays(viper.GetString(retentionDaysFlag))
+	if err != nil {
+		return Config{}, err
+	}
+
+	seasons, err := parseSeasons(viper.GetString(seasonsFlag))
+	if err != nil {
+		return Config{}, err
+	}
+
+	liveSource := viper.GetString(liveSourceFlag)
+	if liveSource != LiveSourceLocal && liveSource != LiveSourceChangeStream {
+		return Config{}, fmt.Errorf("invalid %s %q, expected %s or %s", liveSourceFlag, liveSource,
+			LiveSourceLocal, LiveSourceChangeStream)
+	}
 
 	return Config{
 		Kafka: KafkaConfig{
 			Host: viper.GetString(kafkaHostFlag),
 			Port: int(viper.GetInt32(kafkaPortFlag)),
+
+			EventsTopic: viper.GetString(eventsTopicFlag),
 		},
 		MongoDB: MongoDBConfig{
-			URI: viper.GetString(mongoDBURIFlag),
+			URI:                viper.GetString(mongoDBURIFlag),
+			Database:           viper.GetString(mongoDBNameFlag),
+			MigrateOnStartup:   viper.GetBool(migrateOnStartupFlag),
+			MigrationBatchSize: int(viper.GetInt32(migrationBatchSizeFlag)),
+		},
+		Live: LiveConfig{
+			Source: liveSource,
+		},
+		Webhooks: WebhooksConfig{
+			Enabled: viper.GetBool(webhooksEnabledFlag),
+		},
+		Achievements: AchievementsConfig{
+			File: viper.GetString(achievementsFileFlag),
+		},
+		Seasons: seasons,
+		Retention: RetentionConfig{
+			ArchiveDir: viper.GetString(archiveDirFlag),
+			Days:       retentionDays,
+		},
+		Erasure: ErasureConfig{
+			Secret: viper.GetString(erasureSecretFlag),
+		},
+		HTTP: HTTPConfig{
+			Port:           int(viper.GetInt32(httpPortFlag)),
+			AllowedOrigins: parseList(viper.GetString(httpAllowedOriginsFlag)),
 		},
 		Development: viper.GetBool(developmentFlag),
 		GRPCPort:    int(viper.GetInt32(grpcPortFlag)),
-	}
+	}, nil
 }
 
 type Config struct {
-	Kafka   KafkaConfig
-	MongoDB MongoDBConfig
+	Kafka        KafkaConfig
+	MongoDB      MongoDBConfig
+	Live         LiveConfig
+	Webhooks     WebhooksConfig
+	Achievements AchievementsConfig
+	Seasons      Seasons
+	Retention    RetentionConfig
+	Erasure      ErasureConfig
+	HTTP         HTTPConfig
 
 	Development bool
 
@@ -62,8 +174,174 @@ type Config struct {
 type KafkaConfig struct {
 	Host string
 	Port int
+
+	// EventsTopic is where events are published. Publishing is disabled if it is empty.
+	// Events are queued in a transaction with the data they describe, which requires MongoDB to run as a replica set.
+	EventsTopic string
 }
 
 type MongoDBConfig struct {
-	URI string
+	URI      string
+	Database string
+
+	MigrateOnStartup   bool
+	MigrationBatchSize int
+}
+
+const (
+	LiveSourceLocal        = "local"
+	LiveSourceChangeStream = "change-stream"
+)
+
+type LiveConfig struct {
+	// Source is where live game changes are read from. Local only sees the games processed by this replica,
+	// a change stream sees the games of every replica but requires MongoDB to run as a replica set.
+	Source string
+}
+
+type HTTPConfig struct {
+	// Port is the port of the HTTP gateway. The gateway is disabled if it is 0.
+	Port int
+	// AllowedOrigins are the origins browsers may call the gateway from. A single * allows any origin.
+	AllowedOrigins []string
+}
+
+type WebhooksConfig struct {
+	// Enabled creates and sends webhook deliveries. Deliveries are saved in a transaction with their game,
+	// which requires MongoDB to run as a replica set.
+	Enabled bool
+}
+
+type AchievementsConfig struct {
+	// File is the YAML file of achievement rules. Achievements are disabled if it is empty.
+	File string
+}
+
+// Season is a period stats and leaderboards are bucketed by, ending at the start of End
+type Season struct {
+	Id    string
+	Start time.Time
+	End   time.Time
+}
+
+// Seasons are ordered by start time and never overlap
+type Seasons []*Season
+
+// At returns the season containing the time, or nil if it is outside every season
+func (s Seasons) At(t time.Time) *Season {
+	for _, season := range s {
+		if !t.Before(season.Start) && t.Before(season.End) {
+			return season
+		}
+	}
+
+	return nil
+}
+
+// IdAt returns the id of the season containing the time, or an empty id if it is outside every season
+func (s Seasons) IdAt(t time.Time) string {
+	if season := s.At(t); season != nil {
+		return season.Id
+	}
+
+	return ""
+}
+
+// Get returns the season with the id, or nil if there is no such season
+func (s Seasons) Get(id string) *Season {
+	for _, season := range s {
+		if season.Id == id {
+			return season
+		}
+	}
+
+	return nil
+}
+
+type ErasureConfig struct {
+	// Secret keys the hashes erased players are recorded by. Without it the hashes can't be linked back to
+	// a player id, so it must be kept outside MongoDB.
+	Secret string
+}
+
+type RetentionConfig struct {
+	ArchiveDir string
+
+	// Days is the number of days historic games of each game mode are kept. Game modes not present are kept forever.
+	Days map[string]int
+}
+
+// parseList parses a comma separated list, ignoring empty entries
+func parseList(value string) []string {
+	var list []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// parseRetentionDays parses a comma separated list of gameModeId=days pairs
+func parseRetentionDays(value string) (map[string]int, error) {
+	days := make(map[string]int)
+	if value == "" {
+		return days, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		gameModeId, daysStr, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid retention %q, expected gameModeId=days", pair)
+		}
+
+		d, err := strconv.Atoi(daysStr)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid retention days for %s: %q", gameModeId, daysStr)
+		}
+
+		days[gameModeId] = d
+	}
+
+	return days, nil
+}
+
+const seasonDateLayout = "2006-01-02"
+
+// parseSeasons parses a comma separated list of id=start/end seasons
+func parseSeasons(value string) (Seasons, error) {
+	var seasons Seasons
+	for _, entry := range parseList(value) {
+		id, dates, ok := strings.Cut(entry, "=")
+		startStr, endStr, ok2 := strings.Cut(dates, "/")
+		if !ok || !ok2 || id == "" {
+			return nil, fmt.Errorf("invalid season %q, expected id=start/end", entry)
+		}
+
+		start, err := time.Parse(seasonDateLayout, startStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start of season %s: %q", id, startStr)
+		}
+		end, err := time.Parse(seasonDateLayout, endStr)
+		if err != nil || !end.After(start) {
+			return nil, fmt.Errorf("invalid end of season %s: %q", id, endStr)
+		}
+
+		if seasons.Get(id) != nil {
+			return nil, fmt.Errorf("duplicate season %s", id)
+		}
+		seasons = append(seasons, &Season{Id: id, Start: start, End: end})
+	}
+
+	sort.Slice(seasons, func(i, j int) bool {
+		return seasons[i].Start.Before(seasons[j].Start)
+	})
+	for i := 1; i < len(seasons); i++ {
+		if seasons[i].Start.Before(seasons[i-1].End) {
+			return nil, fmt.Errorf("season %s overlaps season %s", seasons[i].Id, seasons[i-1].Id)
+		}
+	}
+
+	return seasons, nil
 }
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseSeasons(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", value: ""},
+		{name: "single", value: "s1=2026-01-01/2026-04-01", want: []string{"s1"}},
+		{
+			name:  "sorted by start",
+			value: "s2=2026-04-01/2026-07-01, s1=2026-01-01/2026-04-01,",
+			want:  []string{"s1", "s2"},
+		},
+		{name: "gap between seasons", value: "s1=2026-01-01/2026-02-01,s2=2026-03-01/2026-04-01", want: []string{"s1", "s2"}},
+		{name: "no id", value: "=2026-01-01/2026-04-01", wantErr: true},
+		{name: "no dates", value: "s1", wantErr: true},
+		{name: "no end", value: "s1=2026-01-01", wantErr: true},
+		{name: "invalid start", value: "s1=2026-13-01/2026-04-01", wantErr: true},
+		{name: "invalid end", value: "s1=2026-01-01/april", wantErr: true},
+		{name: "ends at its start", value: "s1=2026-01-01/2026-01-01", wantErr: true},
+		{name: "ends before its start", value: "s1=2026-04-01/2026-01-01", wantErr: true},
+		{name: "duplicate", value: "s1=2026-01-01/2026-02-01,s1=2026-03-01/2026-04-01", wantErr: true},
+		{name: "overlapping", value: "s1=2026-01-01/2026-04-01,s2=2026-03-01/2026-07-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seasons, err := parseSeasons(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSeasons() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(seasons) != len(tt.want) {
+				t.Fatalf("got %d seasons, want %d", len(seasons), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if seasons[i].Id != id {
+					t.Errorf("season %d = %s, want %s", i, seasons[i].Id, id)
+				}
+			}
+		})
+	}
+}
+
+func TestSeasonsAt(t *testing.T) {
+	seasons := Seasons{
+		{Id: "s1", Start: date(2026, time.January, 1), End: date(2026, time.April, 1)},
+		{Id: "s2", Start: date(2026, time.May, 1), End: date(2026, time.August, 1)},
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{name: "before every season", t: date(2025, time.December, 31), want: ""},
+		{name: "start is inclusive", t: date(2026, time.January, 1), want: "s1"},
+		{name: "inside", t: date(2026, time.February, 14), want: "s1"},
+		{name: "end is exclusive", t: date(2026, time.April, 1), want: ""},
+		{name: "last instant", t: date(2026, time.April, 1).Add(-time.Nanosecond), want: "s1"},
+		{name: "second season", t: date(2026, time.May, 1), want: "s2"},
+		{name: "after every season", t: date(2026, time.September, 1), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seasons.At(tt.t)
+			if (got == nil) != (tt.want == "") || (got != nil && got.Id != tt.want) {
+				t.Errorf("At() = %v, want %q", got, tt.want)
+			}
+			if id := seasons.IdAt(tt.t); id != tt.want {
+				t.Errorf("IdAt() = %q, want %q", id, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeasonsGet(t *testing.T) {
+	seasons, err := parseSeasons("s1=2026-01-01/2026-04-01")
+	if err != nil {
+		t.Fatalf("parseSeasons() error = %v", err)
+	}
+
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{id: "s1", want: true},
+		{id: "s2", want: false},
+		{id: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := seasons.Get(tt.id); (got != nil) != tt.want {
+				t.Errorf("Get(%q) = %v, want found %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package erasure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/archive"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+)
+
+var ErrNoSecret = errors.New("an erasure secret is required to erase players")
+
+// ErasePlayer erases the player from the repository, then rewrites the archive files referencing them
+func ErasePlayer(ctx context.Context, repo repository.Repository, cfg config.ErasureConfig, playerId uuid.UUID,
+	requestedBy string) (*model.ErasureAudit, error) {
+
+	if cfg.Secret == "" {
+		return nil, ErrNoSecret
+	}
+
+	audit, err := repo.ErasePlayer(ctx, playerId, repository.HashPlayerId([]byte(cfg.Secret), playerId), requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := archive.ErasePlayer(audit.ArchivePaths, playerId, audit.Pseudonym); err != nil {
+		return audit, fmt.Errorf("failed to erase player from archives: %w", err)
+	}
+
+	return audit, nil
+}
+
+// Game is a live or historic game erased players can be replaced in
+type Game interface {
+	ReferencedPlayerIds() []uuid.UUID
+	ReplacePlayer(playerId uuid.UUID, pseudonym uuid.UUID) bool
+}
+
+// ReplaceErasedPlayers replaces the players of the game that have been erased with their pseudonyms,
+// so games that were live when a player was erased don't bring them back. It returns the erased players' ids.
+// Nothing is replaced without a secret, as no player can have been erased.
+func ReplaceErasedPlayers(ctx context.Context, repo repository.Repository, cfg config.ErasureConfig,
+	game Game) (map[uuid.UUID]bool, error) {
+
+	if cfg.Secret == "" {
+		return nil, nil
+	}
+
+	ids := game.ReferencedPlayerIds()
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	hashes := make([]string, len(ids))
+	for i, id := range ids {
+		hashes[i] = repository.HashPlayerId([]byte(cfg.Secret), id)
+	}
+
+	pseudonyms, err := repo.GetErasedPlayers(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	if len(pseudonyms) == 0 {
+		return nil, nil
+	}
+
+	erased := make(map[uuid.UUID]bool, len(pseudonyms))
+	for i, id := range ids {
+		if pseudonym, ok := pseudonyms[hashes[i]]; ok {
+			game.ReplacePlayer(id, pseudonym)
+			erased[id] = true
+		}
+	}
+
+	return erased, nil
+}
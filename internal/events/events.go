@@ -0,0 +1,183 @@
+package events
+
+import (
+	"fmt"
+	pbevents "game-tracker/internal/gen/message/gametracker"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GameRecorded creates the event published after a historic game is saved
+func GameRecorded(game *model.HistoricGame) proto.Message {
+	m := &pbevents.GameRecordedMessage{
+		GameId:     game.Id.Hex(),
+		GameModeId: game.GameModeId,
+		ServerId:   game.ServerId,
+		EndTime:    timestamppb.New(game.EndTime),
+	}
+	if game.MapId != "" {
+		m.MapId = proto.String(game.MapId)
+	}
+
+	if game.StartTime != nil {
+		m.StartTime = timestamppb.New(*game.StartTime)
+	}
+	if game.Duration > 0 {
+		m.Duration = durationpb.New(game.Duration)
+	}
+
+	for _, p := range game.Players {
+		m.Players = append(m.Players, &pbevents.GamePlayer{Id: p.Id.String(), Username: p.Username})
+	}
+
+	if game.WinnerData != nil {
+		for _, id := range game.WinnerData.WinnerIds {
+			m.WinnerIds = append(m.WinnerIds, id.String())
+		}
+		for _, id := range game.WinnerData.LoserIds {
+			m.LoserIds = append(m.LoserIds, id.String())
+		}
+	}
+	if game.WinningTeamId != "" {
+		m.WinningTeamId = proto.String(game.WinningTeamId)
+	}
+
+	for _, p := range game.Participation {
+		if p.LeftEarly {
+			m.LeftEarlyIds = append(m.LeftEarlyIds, p.PlayerId.String())
+		}
+	}
+
+	return m
+}
+
+// AchievementUnlocked creates the event published after a player unlocks an achievement
+func AchievementUnlocked(a *model.PlayerAchievement, name string) proto.Message {
+	return &pbevents.AchievementUnlockedMessage{
+		PlayerId:        a.PlayerId.String(),
+		AchievementId:   a.AchievementId,
+		AchievementName: name,
+		GameId:          a.GameId.Hex(),
+		GameModeId:      a.GameModeId,
+		UnlockedAt:      timestamppb.New(a.UnlockedAt),
+	}
+}
+
+// PlayerStatsUpdated creates the event published after a finished game updated a player's stats
+func PlayerStatsUpdated(stats *model.PlayerStats, gameId string, seasonId string) proto.Message {
+	m := &pbevents.PlayerStatsUpdatedMessage{
+		PlayerId:   stats.PlayerId.String(),
+		GameModeId: stats.GameModeId,
+		GameId:     gameId,
+
+		GamesPlayed: stats.GamesPlayed,
+		Wins:        stats.Wins,
+		Losses:      stats.Losses,
+		Draws:       stats.Draws,
+		LeftEarly:   stats.LeftEarly,
+
+		WinStreak:      stats.WinStreak,
+		BestWinStreak:  stats.BestWinStreak,
+		LossStreak:     stats.LossStreak,
+		BestLossStreak: stats.BestLossStreak,
+
+		TimePlayed: durationpb.New(stats.TimePlayed),
+
+		Kills:      stats.Kills,
+		FinalKills: stats.FinalKills,
+
+		LastPlayed: timestamppb.New(stats.LastPlayed),
+	}
+	if seasonId != "" {
+		m.SeasonId = proto.String(seasonId)
+	}
+
+	return m
+}
+
+// PlayerRatingChanged creates the event published after a finished game changed a player's rating
+func PlayerRatingChanged(rating *model.PlayerRating, gameId string, seasonId string) proto.Message {
+	m := &pbevents.PlayerRatingChangedMessage{
+		PlayerId:   rating.PlayerId.String(),
+		GameModeId: rating.GameModeId,
+		GameId:     gameId,
+
+		Rating:     rating.Rating,
+		Change:     rating.LastChange,
+		PeakRating: rating.PeakRating,
+		Games:      rating.Games,
+
+		LastPlayed: timestamppb.New(rating.LastPlayed),
+	}
+	if seasonId != "" {
+		m.SeasonId = proto.String(seasonId)
+	}
+
+	return m
+}
+
+// ErasePlayer replaces the player's id with the pseudonym in an encoded event and scrubs their username.
+// A nil payload is returned if the event is about the player, such as their stats or achievements,
+// in which case it should be deleted instead.
+func ErasePlayer(protoType string, payload []byte, playerId uuid.UUID, pseudonym uuid.UUID) ([]byte, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(protoType))
+	if err != nil || mt.Descriptor().ParentFile() != pbevents.File_game_tracker_events_proto {
+		return nil, fmt.Errorf("unknown event message %s", protoType)
+	}
+
+	m := mt.New()
+	if err := proto.Unmarshal(payload, m.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if field := mt.Descriptor().Fields().ByName("player_id"); field != nil && m.Get(field).String() == playerId.String() {
+		return nil, nil
+	}
+
+	replacePlayer(m, playerId.String(), pseudonym.String())
+
+	return proto.Marshal(m.Interface())
+}
+
+// replacePlayer replaces the id in every string field of the message and its nested messages.
+// Messages with an id field matching the player, like players, also have their username scrubbed.
+func replacePlayer(m protoreflect.Message, id string, pseudonym string) {
+	fields := m.Descriptor().Fields()
+	isPlayer := false
+	if field := fields.ByName("id"); field != nil && field.Kind() == protoreflect.StringKind {
+		isPlayer = m.Get(field).String() == id
+	}
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.IsMap() || !m.Has(field) {
+			continue
+		}
+
+		switch {
+		case field.IsList() && field.Kind() == protoreflect.StringKind:
+			list := m.Mutable(field).List()
+			for j := 0; j < list.Len(); j++ {
+				if list.Get(j).String() == id {
+					list.Set(j, protoreflect.ValueOfString(pseudonym))
+				}
+			}
+		case field.IsList() && field.Kind() == protoreflect.MessageKind:
+			list := m.Mutable(field).List()
+			for j := 0; j < list.Len(); j++ {
+				replacePlayer(list.Get(j).Message(), id, pseudonym)
+			}
+		case field.Kind() == protoreflect.MessageKind:
+			replacePlayer(m.Mutable(field).Message(), id, pseudonym)
+		case field.Kind() == protoreflect.StringKind && m.Get(field).String() == id:
+			m.Set(field, protoreflect.ValueOfString(pseudonym))
+		case field.Kind() == protoreflect.StringKind && isPlayer && field.Name() == "username":
+			m.Set(field, protoreflect.ValueOfString(model.ErasedUsername))
+		}
+	}
+}
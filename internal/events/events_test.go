@@ -0,0 +1,134 @@
+package events
+
+import (
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"testing"
+	"time"
+)
+
+func TestErasePlayer(t *testing.T) {
+	erased := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	other := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	pseudonym := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	endTime := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	game := &model.HistoricGame{
+		Game: &model.Game{
+			Id:         primitive.NewObjectID(),
+			GameModeId: "block-sumo",
+			Players: []*model.BasicPlayer{
+				{Id: erased, Username: "erased"},
+				{Id: other, Username: "other"},
+			},
+		},
+		EndTime:    endTime,
+		WinnerData: &model.HistoricWinnerData{WinnerIds: []uuid.UUID{erased}, LoserIds: []uuid.UUID{other}},
+	}
+
+	tests := []struct {
+		name    string
+		message proto.Message
+		// deleted is true if the event is about the erased player
+		deleted bool
+		want    map[protoreflect.Name]string
+	}{
+		{
+			name:    "game recorded",
+			message: GameRecorded(game),
+			want:    map[protoreflect.Name]string{"winner_ids": pseudonym.String(), "loser_ids": other.String()},
+		},
+		{
+			name:    "stats of erased player",
+			message: PlayerStatsUpdated(&model.PlayerStats{PlayerId: erased, GameModeId: "block-sumo"}, game.Id.Hex(), ""),
+			deleted: true,
+		},
+		{
+			name:    "stats of other player",
+			message: PlayerStatsUpdated(&model.PlayerStats{PlayerId: other, GameModeId: "block-sumo"}, game.Id.Hex(), ""),
+			want:    map[protoreflect.Name]string{"player_id": other.String()},
+		},
+		{
+			name: "rating of erased player",
+			message: PlayerRatingChanged(&model.PlayerRating{PlayerId: erased, GameModeId: "block-sumo", Rating: 1016,
+				LastChange: 16}, game.Id.Hex(), ""),
+			deleted: true,
+		},
+		{
+			name: "achievement of erased player",
+			message: AchievementUnlocked(&model.PlayerAchievement{PlayerId: erased, AchievementId: "first-win",
+				GameId: game.Id, UnlockedAt: endTime}, "First Win"),
+			deleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := proto.Marshal(tt.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			protoType := string(tt.message.ProtoReflect().Descriptor().FullName())
+
+			erasedPayload, err := ErasePlayer(protoType, payload, erased, pseudonym)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.deleted {
+				if erasedPayload != nil {
+					t.Fatal("expected the event to be deleted")
+				}
+				return
+			}
+
+			m := tt.message.ProtoReflect().New()
+			if err := proto.Unmarshal(erasedPayload, m.Interface()); err != nil {
+				t.Fatal(err)
+			}
+
+			for name, want := range tt.want {
+				field := m.Descriptor().Fields().ByName(name)
+				var got string
+				if field.IsList() {
+					got = m.Get(field).List().Get(0).String()
+				} else {
+					got = m.Get(field).String()
+				}
+				if got != want {
+					t.Errorf("%s = %s, want %s", name, got, want)
+				}
+			}
+
+			if players := m.Descriptor().Fields().ByName("players"); players != nil {
+				list := m.Get(players).List()
+				for i := 0; i < list.Len(); i++ {
+					p := list.Get(i).Message()
+					id := p.Get(p.Descriptor().Fields().ByName("id")).String()
+					username := p.Get(p.Descriptor().Fields().ByName("username")).String()
+
+					switch id {
+					case erased.String():
+						t.Errorf("player %d still has the erased id", i)
+					case pseudonym.String():
+						if username != model.ErasedUsername {
+							t.Errorf("pseudonymised player username = %s, want %s", username, model.ErasedUsername)
+						}
+					case other.String():
+						if username != "other" {
+							t.Errorf("other player username = %s, want other", username)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestErasePlayerUnknownEvent(t *testing.T) {
+	if _, err := ErasePlayer("emortal.message.game_tracker.Unknown", nil, uuid.New(), uuid.New()); err == nil {
+		t.Error("expected an error for an unknown event")
+	}
+}
@@ -0,0 +1,229 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/parquet-go/parquet-go"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatJSONL   Format = "jsonl"
+	FormatParquet Format = "parquet"
+)
+
+var Formats = []Format{FormatCSV, FormatJSONL, FormatParquet}
+
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown export format %q", s)
+}
+
+// GameWriter writes games in one of the bulk export formats. Close must be called to flush the output.
+type GameWriter interface {
+	Write(game *GameRecord) error
+	Close() error
+}
+
+func NewGameWriter(w io.Writer, format Format) (GameWriter, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(flatGameColumns); err != nil {
+			return nil, fmt.Errorf("failed to write header: %w", err)
+		}
+		return &csvGameWriter{w: cw}, nil
+	case FormatJSONL:
+		bw := bufio.NewWriter(w)
+		return &jsonlGameWriter{w: bw, enc: json.NewEncoder(bw)}, nil
+	case FormatParquet:
+		return &parquetGameWriter{w: parquet.NewGenericWriter[FlatGameRecord](w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// FlatGameRecord is a GameRecord flattened into one row per game for the CSV and Parquet formats.
+// Game mode specific columns are empty for games of other modes. Times are nanosecond timestamps in Parquet,
+// as the Parquet library can't write optional times in other units.
+type FlatGameRecord struct {
+	Id             string     `parquet:"id"`
+	GameModeId     string     `parquet:"gameModeId,dict"`
+	MapId          string     `parquet:"mapId,dict"`
+	ServerId       string     `parquet:"serverId"`
+	StartTime      *time.Time `parquet:"startTime,optional"`
+	EndTime        time.Time  `parquet:"endTime"`
+	DurationMillis int64      `parquet:"durationMillis"`
+
+	PlayerCount   int32    `parquet:"playerCount"`
+	PlayerIds     []string `parquet:"playerIds,list"`
+	Usernames     []string `parquet:"usernames,list"`
+	LeftEarlyIds  []string `parquet:"leftEarlyIds,list"`
+	WinnerIds     []string `parquet:"winnerIds,list"`
+	LoserIds      []string `parquet:"loserIds,list"`
+	WinningTeamId string   `parquet:"winningTeamId,optional"`
+
+	TowerDefenceMaxHealth  *int32 `parquet:"towerDefenceMaxHealth,optional"`
+	TowerDefenceRedHealth  *int32 `parquet:"towerDefenceRedHealth,optional"`
+	TowerDefenceBlueHealth *int32 `parquet:"towerDefenceBlueHealth,optional"`
+
+	BlockSumoScoreboard []FlatBlockSumoEntry `parquet:"blockSumoScoreboard,list"`
+}
+
+type FlatBlockSumoEntry struct {
+	PlayerId       string `parquet:"playerId"`
+	RemainingLives int32  `parquet:"remainingLives"`
+	Kills          int32  `parquet:"kills"`
+	FinalKills     int32  `parquet:"finalKills"`
+}
+
+// flatGameColumns are the CSV columns. Lists are joined with ';' and Block Sumo
+// scoreboard entries are written as playerId:remainingLives:kills:finalKills.
+var flatGameColumns = []string{
+	"id", "gameModeId", "mapId", "serverId", "startTime", "endTime", "durationMillis",
+	"playerCount", "playerIds", "usernames", "leftEarlyIds", "winnerIds", "loserIds", "winningTeamId",
+	"towerDefenceMaxHealth", "towerDefenceRedHealth", "towerDefenceBlueHealth",
+	"blockSumoScoreboard",
+}
+
+func FlattenGameRecord(r *GameRecord) FlatGameRecord {
+	f := FlatGameRecord{
+		Id:             r.Id,
+		GameModeId:     r.GameModeId,
+		MapId:          r.MapId,
+		ServerId:       r.ServerId,
+		StartTime:      r.StartTime,
+		EndTime:        r.EndTime,
+		DurationMillis: r.DurationMillis,
+		PlayerCount:    int32(len(r.Players)),
+		WinnerIds:      r.WinnerIds,
+		LoserIds:       r.LoserIds,
+		WinningTeamId:  r.WinningTeamId,
+	}
+
+	for _, p := range r.Players {
+		f.PlayerIds = append(f.PlayerIds, p.Id)
+		f.Usernames = append(f.Usernames, p.Username)
+	}
+
+	for _, p := range r.Participation {
+		if p.LeftEarly {
+			f.LeftEarlyIds = append(f.LeftEarlyIds, p.PlayerId)
+		}
+	}
+
+	if td := r.TowerDefence; td != nil {
+		f.TowerDefenceMaxHealth = &td.MaxHealth
+		f.TowerDefenceRedHealth = &td.RedHealth
+		f.TowerDefenceBlueHealth = &td.BlueHealth
+	}
+
+	if bs := r.BlockSumo; bs != nil {
+		for playerId, e := range bs.Scoreboard {
+			f.BlockSumoScoreboard = append(f.BlockSumoScoreboard, FlatBlockSumoEntry{
+				PlayerId:       playerId,
+				RemainingLives: e.RemainingLives,
+				Kills:          e.Kills,
+				FinalKills:     e.FinalKills,
+			})
+		}
+
+		// Map iteration order is random, so sort for stable output
+		sort.Slice(f.BlockSumoScoreboard, func(i, j int) bool {
+			return f.BlockSumoScoreboard[i].PlayerId < f.BlockSumoScoreboard[j].PlayerId
+		})
+	}
+
+	return f
+}
+
+func (f *FlatGameRecord) csvRow() []string {
+	startTime := ""
+	if f.StartTime != nil {
+		startTime = f.StartTime.UTC().Format(time.RFC3339Nano)
+	}
+
+	scoreboard := make([]string, len(f.BlockSumoScoreboard))
+	for i, e := range f.BlockSumoScoreboard {
+		scoreboard[i] = fmt.Sprintf("%s:%d:%d:%d", e.PlayerId, e.RemainingLives, e.Kills, e.FinalKills)
+	}
+
+	return []string{
+		f.Id, f.GameModeId, f.MapId, f.ServerId, startTime, f.EndTime.UTC().Format(time.RFC3339Nano),
+		strconv.FormatInt(f.DurationMillis, 10),
+		strconv.Itoa(int(f.PlayerCount)),
+		strings.Join(f.PlayerIds, ";"),
+		strings.Join(f.Usernames, ";"),
+		strings.Join(f.LeftEarlyIds, ";"),
+		strings.Join(f.WinnerIds, ";"),
+		strings.Join(f.LoserIds, ";"),
+		f.WinningTeamId,
+		optionalInt(f.TowerDefenceMaxHealth),
+		optionalInt(f.TowerDefenceRedHealth),
+		optionalInt(f.TowerDefenceBlueHealth),
+		strings.Join(scoreboard, ";"),
+	}
+}
+
+func optionalInt(v *int32) string {
+	if v == nil {
+		return ""
+	}
+
+	return strconv.Itoa(int(*v))
+}
+
+type csvGameWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvGameWriter) Write(game *GameRecord) error {
+	flat := FlattenGameRecord(game)
+	return c.w.Write(flat.csvRow())
+}
+
+func (c *csvGameWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlGameWriter writes one GameRecord per line in the same JSON form as the player export
+type jsonlGameWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (j *jsonlGameWriter) Write(game *GameRecord) error {
+	return j.enc.Encode(game)
+}
+
+func (j *jsonlGameWriter) Close() error {
+	return j.w.Flush()
+}
+
+type parquetGameWriter struct {
+	w *parquet.GenericWriter[FlatGameRecord]
+}
+
+func (p *parquetGameWriter) Write(game *GameRecord) error {
+	_, err := p.w.Write([]FlatGameRecord{FlattenGameRecord(game)})
+	return err
+}
+
+func (p *parquetGameWriter) Close() error {
+	return p.w.Close()
+}
@@ -0,0 +1,274 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/parquet-go/parquet-go"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	playerA = "00000000-0000-0000-0000-00000000000a"
+	playerB = "00000000-0000-0000-0000-00000000000b"
+)
+
+func testRecords() (*GameRecord, *GameRecord) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+
+	towerDefence := &GameRecord{
+		Id:             "000000000000000000000001",
+		GameModeId:     "towerdefence",
+		MapId:          "castle",
+		ServerId:       "server-1",
+		StartTime:      &start,
+		EndTime:        end,
+		DurationMillis: (10 * time.Minute).Milliseconds(),
+		Players:        []*PlayerRecord{{Id: playerA, Username: "a"}, {Id: playerB, Username: "b"}},
+		Participation: []*ParticipationRecord{
+			{PlayerId: playerA, Username: "a"},
+			{PlayerId: playerB, Username: "b", LeftEarly: true},
+		},
+		WinnerIds:     []string{playerA},
+		LoserIds:      []string{playerB},
+		WinningTeamId: "red",
+		TowerDefence:  &TowerDefenceRecord{MaxHealth: 100, RedHealth: 40, BlueHealth: 0},
+	}
+
+	blockSumo := &GameRecord{
+		Id:         "000000000000000000000002",
+		GameModeId: "blocksumo",
+		ServerId:   "server-2",
+		EndTime:    end,
+		Players:    []*PlayerRecord{{Id: playerB, Username: "b"}, {Id: playerA, Username: "a"}},
+		BlockSumo: &BlockSumoRecord{Scoreboard: map[string]*BlockSumoEntryRecord{
+			playerB: {RemainingLives: 0, Kills: 1, FinalKills: 0},
+			playerA: {RemainingLives: 2, Kills: 3, FinalKills: 1},
+		}},
+	}
+
+	return towerDefence, blockSumo
+}
+
+func TestFlattenGameRecord(t *testing.T) {
+	towerDefence, blockSumo := testRecords()
+	health := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name   string
+		record *GameRecord
+		want   FlatGameRecord
+	}{
+		{
+			name:   "tower defence",
+			record: towerDefence,
+			want: FlatGameRecord{
+				Id:                     towerDefence.Id,
+				GameModeId:             "towerdefence",
+				MapId:                  "castle",
+				ServerId:               "server-1",
+				StartTime:              towerDefence.StartTime,
+				EndTime:                towerDefence.EndTime,
+				DurationMillis:         towerDefence.DurationMillis,
+				PlayerCount:            2,
+				PlayerIds:              []string{playerA, playerB},
+				Usernames:              []string{"a", "b"},
+				LeftEarlyIds:           []string{playerB},
+				WinnerIds:              []string{playerA},
+				LoserIds:               []string{playerB},
+				WinningTeamId:          "red",
+				TowerDefenceMaxHealth:  health(100),
+				TowerDefenceRedHealth:  health(40),
+				TowerDefenceBlueHealth: health(0),
+			},
+		},
+		{
+			name:   "block sumo scoreboard is sorted by player",
+			record: blockSumo,
+			want: FlatGameRecord{
+				Id:          blockSumo.Id,
+				GameModeId:  "blocksumo",
+				ServerId:    "server-2",
+				EndTime:     blockSumo.EndTime,
+				PlayerCount: 2,
+				PlayerIds:   []string{playerB, playerA},
+				Usernames:   []string{"b", "a"},
+				BlockSumoScoreboard: []FlatBlockSumoEntry{
+					{PlayerId: playerA, RemainingLives: 2, Kills: 3, FinalKills: 1},
+					{PlayerId: playerB, RemainingLives: 0, Kills: 1, FinalKills: 0},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FlattenGameRecord(tt.record); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlattenGameRecord() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVGameWriter(t *testing.T) {
+	towerDefence, blockSumo := testRecords()
+
+	tests := []struct {
+		name   string
+		record *GameRecord
+		want   map[string]string
+	}{
+		{
+			name:   "tower defence",
+			record: towerDefence,
+			want: map[string]string{
+				"startTime":              "2026-01-01T12:00:00Z",
+				"endTime":                "2026-01-01T12:10:00Z",
+				"durationMillis":         "600000",
+				"playerCount":            "2",
+				"playerIds":              playerA + ";" + playerB,
+				"leftEarlyIds":           playerB,
+				"towerDefenceMaxHealth":  "100",
+				"towerDefenceBlueHealth": "0",
+				"blockSumoScoreboard":    "",
+			},
+		},
+		{
+			name:   "block sumo",
+			record: blockSumo,
+			want: map[string]string{
+				"startTime":             "",
+				"mapId":                 "",
+				"towerDefenceMaxHealth": "",
+				"blockSumoScoreboard":   playerA + ":2:3:1;" + playerB + ":0:1:0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewGameWriter(&buf, FormatCSV)
+			if err != nil {
+				t.Fatalf("NewGameWriter() error = %v", err)
+			}
+			if err := w.Write(tt.record); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			rows, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("failed to read csv: %v", err)
+			}
+			if len(rows) != 2 {
+				t.Fatalf("got %d rows, want a header and a game", len(rows))
+			}
+			if !reflect.DeepEqual(rows[0], flatGameColumns) {
+				t.Errorf("header = %v, want %v", rows[0], flatGameColumns)
+			}
+
+			row := make(map[string]string, len(rows[0]))
+			for i, column := range rows[0] {
+				row[column] = rows[1][i]
+			}
+			for column, want := range tt.want {
+				if row[column] != want {
+					t.Errorf("%s = %q, want %q", column, row[column], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParquetGameWriter(t *testing.T) {
+	towerDefence, blockSumo := testRecords()
+
+	var buf bytes.Buffer
+	w, err := NewGameWriter(&buf, FormatParquet)
+	if err != nil {
+		t.Fatalf("NewGameWriter() error = %v", err)
+	}
+	for _, r := range []*GameRecord{towerDefence, blockSumo} {
+		if err := w.Write(r); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	rows, err := parquet.Read[FlatGameRecord](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read parquet: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		record *GameRecord
+	}{
+		{name: "tower defence", record: towerDefence},
+		{name: "block sumo", record: blockSumo},
+	}
+
+	if len(rows) != len(tests) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := FlattenGameRecord(tt.record)
+			got := rows[i]
+
+			if got.Id != want.Id || got.GameModeId != want.GameModeId || got.PlayerCount != want.PlayerCount {
+				t.Errorf("row = %+v, want %+v", got, want)
+			}
+			if (got.StartTime == nil) != (want.StartTime == nil) ||
+				(got.StartTime != nil && !got.StartTime.Equal(*want.StartTime)) {
+				t.Errorf("StartTime = %v, want %v", got.StartTime, want.StartTime)
+			}
+			if !got.EndTime.Equal(want.EndTime) {
+				t.Errorf("EndTime = %v, want %v", got.EndTime, want.EndTime)
+			}
+			if !reflect.DeepEqual(got.PlayerIds, want.PlayerIds) {
+				t.Errorf("PlayerIds = %v, want %v", got.PlayerIds, want.PlayerIds)
+			}
+			if !reflect.DeepEqual(got.TowerDefenceRedHealth, want.TowerDefenceRedHealth) {
+				t.Errorf("TowerDefenceRedHealth = %v, want %v", got.TowerDefenceRedHealth, want.TowerDefenceRedHealth)
+			}
+			if len(got.BlockSumoScoreboard) != len(want.BlockSumoScoreboard) ||
+				(len(want.BlockSumoScoreboard) > 0 && !reflect.DeepEqual(got.BlockSumoScoreboard, want.BlockSumoScoreboard)) {
+				t.Errorf("BlockSumoScoreboard = %v, want %v", got.BlockSumoScoreboard, want.BlockSumoScoreboard)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{in: "csv", want: FormatCSV},
+		{in: "jsonl", want: FormatJSONL},
+		{in: "parquet", want: FormatParquet},
+		{in: "CSV", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFormat(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
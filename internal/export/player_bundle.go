@@ -0,0 +1,196 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+const bundlePageSize = 100
+
+// PlayerBundle is everything stored about a player, exported on their request.
+// The other players in their games and pairs are replaced by pseudonyms, see redactor.
+type PlayerBundle struct {
+	PlayerId   string    `json:"playerId"`
+	ExportedAt time.Time `json:"exportedAt"`
+
+	// Player is absent if the player isn't in the player directory
+	Player       *PlayerDirectoryRecord `json:"player,omitempty"`
+	Stats        []*PlayerStatsRecord   `json:"stats"`
+	Achievements []*AchievementRecord   `json:"achievements"`
+	Ratings      []*PlayerRatingRecord  `json:"ratings"`
+	// Pairs are the player's head-to-head and teammate stats with every player they have played with.
+	// Stats and Pairs hold the all-time totals followed by the totals of every season the player played in.
+	Pairs []*PlayerPairRecord `json:"pairs"`
+	Games []*GameRecord       `json:"games"`
+}
+
+type PlayerDirectoryRecord struct {
+	Username          string            `json:"username"`
+	UsernameFirstSeen time.Time         `json:"usernameFirstSeen"`
+	PreviousUsernames []*UsernameRecord `json:"previousUsernames"`
+	FirstSeen         time.Time         `json:"firstSeen"`
+	LastSeen          time.Time         `json:"lastSeen"`
+}
+
+type UsernameRecord struct {
+	Username  string    `json:"username"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// BuildPlayerBundle gathers the player's directory entry, stats, achievements and every historic game they took part in.
+func BuildPlayerBundle(ctx context.Context, repo repository.Repository, playerId uuid.UUID) (*PlayerBundle, error) {
+	bundle := &PlayerBundle{
+		PlayerId:   playerId.String(),
+		ExportedAt: time.Now(),
+		Games:      make([]*GameRecord, 0),
+	}
+
+	player, err := repo.GetPlayer(ctx, playerId)
+	switch {
+	case err == nil:
+		bundle.Player = playerDirectoryRecordFromModel(player)
+	case !errors.Is(err, repository.ErrNotFound):
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	stats, err := repo.GetPlayerStats(ctx, playerId, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player stats: %w", err)
+	}
+	seasonStats, err := repo.GetPlayerSeasonStats(ctx, playerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player season stats: %w", err)
+	}
+	stats = append(stats, seasonStats...)
+	bundle.Stats = make([]*PlayerStatsRecord, len(stats))
+	for i, s := range stats {
+		bundle.Stats[i] = PlayerStatsRecordFromModel(s)
+	}
+
+	achievements, err := repo.GetPlayerAchievements(ctx, playerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player achievements: %w", err)
+	}
+	bundle.Achievements = make([]*AchievementRecord, len(achievements))
+	for i, a := range achievements {
+		bundle.Achievements[i] = AchievementRecordFromModel(a)
+	}
+
+	ratings, err := repo.GetPlayerRatings(ctx, playerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player ratings: %w", err)
+	}
+	bundle.Ratings = make([]*PlayerRatingRecord, len(ratings))
+	for i, r := range ratings {
+		bundle.Ratings[i] = PlayerRatingRecordFromModel(r)
+	}
+
+	redact := newRedactor(playerId)
+
+	pairs, err := repo.GetPlayerPairs(ctx, playerId, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player pairs: %w", err)
+	}
+	for _, seasonId := range seasonIds(seasonStats) {
+		seasonPairs, err := repo.GetPlayerPairs(ctx, playerId, seasonId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get player pairs of season %s: %w", seasonId, err)
+		}
+		pairs = append(pairs, seasonPairs...)
+	}
+	bundle.Pairs = make([]*PlayerPairRecord, len(pairs))
+	for i, p := range pairs {
+		bundle.Pairs[i] = redact.pair(PlayerPairRecordFromModel(p))
+	}
+
+	filter := repository.HistoricGameFilter{PlayerId: playerId}
+	for page := int64(0); ; page++ {
+		games, err := repo.ListHistoricGames(ctx, filter, page, bundlePageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list games: %w", err)
+		}
+
+		for _, g := range games {
+			bundle.Games = append(bundle.Games, redact.game(GameRecordFromModel(g)))
+		}
+
+		if len(games) < bundlePageSize {
+			break
+		}
+	}
+
+	return bundle, nil
+}
+
+// seasonIds returns the distinct seasons of the stats in the order they first appear
+func seasonIds(stats []*model.PlayerStats) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, s := range stats {
+		if !seen[s.SeasonId] {
+			seen[s.SeasonId] = true
+			ids = append(ids, s.SeasonId)
+		}
+	}
+
+	return ids
+}
+
+func playerDirectoryRecordFromModel(p *model.Player) *PlayerDirectoryRecord {
+	r := &PlayerDirectoryRecord{
+		Username:          p.Username,
+		UsernameFirstSeen: p.UsernameFirstSeen,
+		PreviousUsernames: make([]*UsernameRecord, len(p.PreviousUsernames)),
+		FirstSeen:         p.FirstSeen,
+		LastSeen:          p.LastSeen,
+	}
+
+	for i, u := range p.PreviousUsernames {
+		r.PreviousUsernames[i] = &UsernameRecord{Username: u.Username, FirstSeen: u.FirstSeen, LastSeen: u.LastSeen}
+	}
+
+	return r
+}
+
+// WriteBundle writes the bundle as an indented JSON document, optionally inside a zip archive.
+func WriteBundle(w io.Writer, bundle *PlayerBundle, zipped bool) error {
+	if !zipped {
+		return writeBundleJSON(w, bundle)
+	}
+
+	zw := zip.NewWriter(w)
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     bundle.PlayerId + ".json",
+		Method:   zip.Deflate,
+		Modified: bundle.ExportedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create zip entry: %w", err)
+	}
+
+	if err := writeBundleJSON(fw, bundle); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeBundleJSON(w io.Writer, bundle *PlayerBundle) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(bundle); err != nil {
+		return fmt.Errorf("failed to encode bundle: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"game-tracker/internal/repository/model"
+	"time"
+)
+
+type PlayerStatsRecord struct {
+	GameModeId string `json:"gameModeId"`
+	// SeasonId is absent for all-time stats
+	SeasonId         string    `json:"seasonId,omitempty"`
+	GamesPlayed      int64     `json:"gamesPlayed"`
+	Wins             int64     `json:"wins"`
+	Losses           int64     `json:"losses"`
+	Draws            int64     `json:"draws"`
+	LeftEarly        int64     `json:"leftEarly"`
+	WinStreak        int64     `json:"winStreak"`
+	BestWinStreak    int64     `json:"bestWinStreak"`
+	LossStreak       int64     `json:"lossStreak"`
+	BestLossStreak   int64     `json:"bestLossStreak"`
+	TimePlayedMillis int64     `json:"timePlayedMillis"`
+	Kills            int64     `json:"kills"`
+	FinalKills       int64     `json:"finalKills"`
+	LastPlayed       time.Time `json:"lastPlayed"`
+}
+
+type AchievementRecord struct {
+	AchievementId string    `json:"achievementId"`
+	GameId        string    `json:"gameId"`
+	GameModeId    string    `json:"gameModeId"`
+	UnlockedAt    time.Time `json:"unlockedAt"`
+}
+
+func PlayerStatsRecordFromModel(s *model.PlayerStats) *PlayerStatsRecord {
+	return &PlayerStatsRecord{
+		GameModeId:       s.GameModeId,
+		SeasonId:         s.SeasonId,
+		GamesPlayed:      s.GamesPlayed,
+		Wins:             s.Wins,
+		Losses:           s.Losses,
+		Draws:            s.Draws,
+		LeftEarly:        s.LeftEarly,
+		WinStreak:        s.WinStreak,
+		BestWinStreak:    s.BestWinStreak,
+		LossStreak:       s.LossStreak,
+		BestLossStreak:   s.BestLossStreak,
+		TimePlayedMillis: s.TimePlayed.Milliseconds(),
+		Kills:            s.Kills,
+		FinalKills:       s.FinalKills,
+		LastPlayed:       s.LastPlayed,
+	}
+}
+
+func AchievementRecordFromModel(a *model.PlayerAchievement) *AchievementRecord {
+	return &AchievementRecord{
+		AchievementId: a.AchievementId,
+		GameId:        a.GameId.Hex(),
+		GameModeId:    a.GameModeId,
+		UnlockedAt:    a.UnlockedAt,
+	}
+}
+
+type PlayerRatingRecord struct {
+	GameModeId string `json:"gameModeId"`
+	// SeasonId is absent for the all-time rating
+	SeasonId   string    `json:"seasonId,omitempty"`
+	Rating     float64   `json:"rating"`
+	PeakRating float64   `json:"peakRating"`
+	Games      int64     `json:"games"`
+	LastPlayed time.Time `json:"lastPlayed"`
+}
+
+func PlayerRatingRecordFromModel(r *model.PlayerRating) *PlayerRatingRecord {
+	return &PlayerRatingRecord{
+		GameModeId: r.GameModeId,
+		SeasonId:   r.SeasonId,
+		Rating:     r.Rating,
+		PeakRating: r.PeakRating,
+		Games:      r.Games,
+		LastPlayed: r.LastPlayed,
+	}
+}
+
+type PlayerPairRecord struct {
+	PlayerId   string `json:"playerId"`
+	OtherId    string `json:"otherId"`
+	GameModeId string `json:"gameModeId,omitempty"`
+	// SeasonId is absent for all-time stats
+	SeasonId string `json:"seasonId,omitempty"`
+
+	GamesAgainst  int64 `json:"gamesAgainst"`
+	WinsAgainst   int64 `json:"winsAgainst"`
+	LossesAgainst int64 `json:"lossesAgainst"`
+
+	GamesWith  int64 `json:"gamesWith"`
+	WinsWith   int64 `json:"winsWith"`
+	LossesWith int64 `json:"lossesWith"`
+	// WinRateWith is the fraction of games played together that were won, absent if they never played together
+	WinRateWith *float64 `json:"winRateWith,omitempty"`
+
+	LastPlayed time.Time `json:"lastPlayed"`
+}
+
+func PlayerPairRecordFromModel(p *model.PlayerPairStats) *PlayerPairRecord {
+	r := &PlayerPairRecord{
+		PlayerId:      p.PlayerId.String(),
+		OtherId:       p.OtherId.String(),
+		GameModeId:    p.GameModeId,
+		SeasonId:      p.SeasonId,
+		GamesAgainst:  p.GamesAgainst,
+		WinsAgainst:   p.WinsAgainst,
+		LossesAgainst: p.LossesAgainst,
+		GamesWith:     p.GamesWith,
+		WinsWith:      p.WinsWith,
+		LossesWith:    p.LossesWith,
+		LastPlayed:    p.LastPlayed,
+	}
+
+	if p.GamesWith > 0 {
+		winRate := float64(p.WinsWith) / float64(p.GamesWith)
+		r.WinRateWith = &winRate
+	}
+
+	return r
+}
@@ -0,0 +1,207 @@
+package export
+
+import (
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"time"
+)
+
+// GameRecord is the JSON representation of a historic game used by every export format.
+type GameRecord struct {
+	Id             string     `json:"id"`
+	GameModeId     string     `json:"gameModeId"`
+	MapId          string     `json:"mapId,omitempty"`
+	ServerId       string     `json:"serverId"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	EndTime        time.Time  `json:"endTime"`
+	DurationMillis int64      `json:"durationMillis,omitempty"`
+
+	Players       []*PlayerRecord        `json:"players"`
+	Participation []*ParticipationRecord `json:"participation,omitempty"`
+	Teams         []*TeamRecord          `json:"teams,omitempty"`
+
+	WinnerIds     []string `json:"winnerIds,omitempty"`
+	LoserIds      []string `json:"loserIds,omitempty"`
+	WinningTeamId string   `json:"winningTeamId,omitempty"`
+
+	TowerDefence *TowerDefenceRecord `json:"towerDefence,omitempty"`
+	BlockSumo    *BlockSumoRecord    `json:"blockSumo,omitempty"`
+}
+
+// PlayerRecord matches the JSON form of the BasicGamePlayer proto
+type PlayerRecord struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type ParticipationRecord struct {
+	PlayerId         string    `json:"playerId"`
+	Username         string    `json:"username"`
+	FirstJoinTime    time.Time `json:"firstJoinTime"`
+	LastLeaveTime    time.Time `json:"lastLeaveTime"`
+	TimeInGameMillis int64     `json:"timeInGameMillis"`
+	LeftEarly        bool      `json:"leftEarly"`
+}
+
+// TeamRecord matches the JSON form of the Team proto
+type TeamRecord struct {
+	Id           string   `json:"id"`
+	FriendlyName string   `json:"friendlyName"`
+	Color        int32    `json:"color"`
+	PlayerIds    []string `json:"playerIds"`
+}
+
+type TowerDefenceRecord struct {
+	MaxHealth  int32 `json:"maxHealth"`
+	RedHealth  int32 `json:"redHealth"`
+	BlueHealth int32 `json:"blueHealth"`
+}
+
+type BlockSumoRecord struct {
+	Scoreboard map[string]*BlockSumoEntryRecord `json:"scoreboard"`
+}
+
+type BlockSumoEntryRecord struct {
+	RemainingLives int32 `json:"remainingLives"`
+	Kills          int32 `json:"kills"`
+	FinalKills     int32 `json:"finalKills"`
+}
+
+func GameRecordFromModel(g *model.HistoricGame) *GameRecord {
+	r := &GameRecord{
+		Id:             g.Id.Hex(),
+		GameModeId:     g.GameModeId,
+		MapId:          g.MapId,
+		ServerId:       g.ServerId,
+		StartTime:      g.StartTime,
+		EndTime:        g.EndTime,
+		DurationMillis: g.Duration.Milliseconds(),
+		WinningTeamId:  g.WinningTeamId,
+	}
+
+	r.Players = make([]*PlayerRecord, len(g.Players))
+	for i, p := range g.Players {
+		r.Players[i] = &PlayerRecord{Id: p.Id.String(), Username: p.Username}
+	}
+
+	for _, p := range g.Participation {
+		r.Participation = append(r.Participation, &ParticipationRecord{
+			PlayerId:         p.PlayerId.String(),
+			Username:         p.Username,
+			FirstJoinTime:    p.FirstJoinTime,
+			LastLeaveTime:    p.LastLeaveTime,
+			TimeInGameMillis: p.TimeInGame.Milliseconds(),
+			LeftEarly:        p.LeftEarly,
+		})
+	}
+
+	r.Teams = teamRecords(g.TeamData)
+
+	if g.WinnerData != nil {
+		r.WinnerIds = uuidStrings(g.WinnerData.WinnerIds)
+		r.LoserIds = uuidStrings(g.WinnerData.LoserIds)
+	}
+
+	switch data := g.GameData.(type) {
+	case *model.HistoricTowerDefenceData:
+		r.TowerDefence = &TowerDefenceRecord{
+			MaxHealth:  data.MaxHealth,
+			RedHealth:  data.RedHealth,
+			BlueHealth: data.BlueHealth,
+		}
+	case *model.HistoricBlockSumoData:
+		r.BlockSumo = blockSumoRecord(data.Scoreboard)
+	}
+
+	return r
+}
+
+// LiveGameRecord is the JSON representation of a live game, using the same fields as GameRecord
+type LiveGameRecord struct {
+	Id          string     `json:"id"`
+	GameModeId  string     `json:"gameModeId"`
+	MapId       string     `json:"mapId,omitempty"`
+	ServerId    string     `json:"serverId"`
+	StartTime   *time.Time `json:"startTime,omitempty"`
+	LastUpdated time.Time  `json:"lastUpdated"`
+
+	Players []*PlayerRecord `json:"players"`
+	Teams   []*TeamRecord   `json:"teams,omitempty"`
+
+	TowerDefence *TowerDefenceRecord `json:"towerDefence,omitempty"`
+	BlockSumo    *BlockSumoRecord    `json:"blockSumo,omitempty"`
+}
+
+func LiveGameRecordFromModel(g *model.LiveGame) *LiveGameRecord {
+	r := &LiveGameRecord{
+		Id:          g.Id.Hex(),
+		GameModeId:  g.GameModeId,
+		MapId:       g.MapId,
+		ServerId:    g.ServerId,
+		StartTime:   g.StartTime,
+		LastUpdated: g.LastUpdated,
+		Teams:       teamRecords(g.TeamData),
+	}
+
+	r.Players = make([]*PlayerRecord, len(g.Players))
+	for i, p := range g.Players {
+		r.Players[i] = &PlayerRecord{Id: p.Id.String(), Username: p.Username}
+	}
+
+	switch data := g.GameData.(type) {
+	case *model.LiveTowerDefenceData:
+		r.TowerDefence = &TowerDefenceRecord{
+			MaxHealth:  data.MaxHealth,
+			RedHealth:  data.RedHealth,
+			BlueHealth: data.BlueHealth,
+		}
+	case *model.LiveBlockSumoData:
+		r.BlockSumo = blockSumoRecord(data.Scoreboard)
+	}
+
+	return r
+}
+
+func teamRecords(teams *[]*model.Team) []*TeamRecord {
+	if teams == nil {
+		return nil
+	}
+
+	records := make([]*TeamRecord, len(*teams))
+	for i, t := range *teams {
+		records[i] = &TeamRecord{
+			Id:           t.Id,
+			FriendlyName: t.FriendlyName,
+			Color:        t.Color,
+			PlayerIds:    uuidStrings(t.PlayerIds),
+		}
+	}
+
+	return records
+}
+
+func blockSumoRecord(scoreboard *model.BlockSumoScoreboard) *BlockSumoRecord {
+	r := &BlockSumoRecord{Scoreboard: make(map[string]*BlockSumoEntryRecord)}
+	if scoreboard == nil {
+		return r
+	}
+
+	for id, e := range scoreboard.Entries {
+		r.Scoreboard[id.String()] = &BlockSumoEntryRecord{
+			RemainingLives: e.RemainingLives,
+			Kills:          e.Kills,
+			FinalKills:     e.FinalKills,
+		}
+	}
+
+	return r
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+
+	return strs
+}
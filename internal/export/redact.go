@@ -0,0 +1,87 @@
+package export
+
+import (
+	"github.com/google/uuid"
+)
+
+// RedactedUsername replaces the usernames of other players in a player's export
+const RedactedUsername = "[redacted]"
+
+// redactor replaces the other players in a player's export with pseudonyms. A player keeps the same pseudonym
+// throughout the export, so it still shows who the player played with repeatedly, but gets a new one in every export.
+type redactor struct {
+	playerId   string
+	pseudonyms map[string]string
+}
+
+func newRedactor(playerId uuid.UUID) *redactor {
+	return &redactor{
+		playerId:   playerId.String(),
+		pseudonyms: make(map[string]string),
+	}
+}
+
+// id returns the pseudonym of another player, or the id itself if it is the exported player
+func (r *redactor) id(id string) string {
+	if id == r.playerId {
+		return id
+	}
+
+	pseudonym, ok := r.pseudonyms[id]
+	if !ok {
+		pseudonym = uuid.NewString()
+		r.pseudonyms[id] = pseudonym
+	}
+
+	return pseudonym
+}
+
+func (r *redactor) ids(ids []string) []string {
+	for i, id := range ids {
+		ids[i] = r.id(id)
+	}
+
+	return ids
+}
+
+func (r *redactor) username(id string, username string) string {
+	if id == r.playerId {
+		return username
+	}
+
+	return RedactedUsername
+}
+
+func (r *redactor) game(g *GameRecord) *GameRecord {
+	for _, p := range g.Players {
+		p.Username = r.username(p.Id, p.Username)
+		p.Id = r.id(p.Id)
+	}
+
+	for _, p := range g.Participation {
+		p.Username = r.username(p.PlayerId, p.Username)
+		p.PlayerId = r.id(p.PlayerId)
+	}
+
+	for _, t := range g.Teams {
+		t.PlayerIds = r.ids(t.PlayerIds)
+	}
+
+	g.WinnerIds = r.ids(g.WinnerIds)
+	g.LoserIds = r.ids(g.LoserIds)
+
+	if g.BlockSumo != nil {
+		scoreboard := make(map[string]*BlockSumoEntryRecord, len(g.BlockSumo.Scoreboard))
+		for id, e := range g.BlockSumo.Scoreboard {
+			scoreboard[r.id(id)] = e
+		}
+		g.BlockSumo.Scoreboard = scoreboard
+	}
+
+	return g
+}
+
+func (r *redactor) pair(p *PlayerPairRecord) *PlayerPairRecord {
+	p.OtherId = r.id(p.OtherId)
+	return p
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	player := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	other := "00000000-0000-0000-0000-000000000002"
+
+	redact := newRedactor(player)
+	game := redact.game(&GameRecord{
+		Players: []*PlayerRecord{
+			{Id: player.String(), Username: "player"},
+			{Id: other, Username: "other"},
+		},
+		Participation: []*ParticipationRecord{{PlayerId: other, Username: "other"}},
+		Teams:         []*TeamRecord{{Id: "red", PlayerIds: []string{player.String(), other}}},
+		WinnerIds:     []string{other},
+		LoserIds:      []string{player.String()},
+		BlockSumo:     &BlockSumoRecord{Scoreboard: map[string]*BlockSumoEntryRecord{other: {Kills: 2}}},
+	})
+	pair := redact.pair(&PlayerPairRecord{PlayerId: player.String(), OtherId: other})
+
+	pseudonym := game.Players[1].Id
+	if pseudonym == other {
+		t.Fatal("other player wasn't replaced")
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"player id", game.Players[0].Id, player.String()},
+		{"player username", game.Players[0].Username, "player"},
+		{"other username", game.Players[1].Username, RedactedUsername},
+		{"participation id", game.Participation[0].PlayerId, pseudonym},
+		{"participation username", game.Participation[0].Username, RedactedUsername},
+		{"team member", game.Teams[0].PlayerIds[1], pseudonym},
+		{"winner", game.WinnerIds[0], pseudonym},
+		{"loser", game.LoserIds[0], player.String()},
+		{"pair other id", pair.OtherId, pseudonym},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if _, ok := game.BlockSumo.Scoreboard[pseudonym]; !ok || len(game.BlockSumo.Scoreboard) != 1 {
+		t.Errorf("scoreboard = %v, want only the pseudonym", game.BlockSumo.Scoreboard)
+	}
+
+	if newRedactor(player).id(other) == pseudonym {
+		t.Error("pseudonyms should differ between exports")
+	}
+}
@@ -0,0 +1,211 @@
+package gateway
+
+import (
+	"fmt"
+	"game-tracker/internal/export"
+	"game-tracker/internal/repository"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type listLiveGamesResponse struct {
+	Games []*export.LiveGameRecord `json:"games"`
+}
+
+type listHistoricGamesResponse struct {
+	Games    []*export.GameRecord `json:"games"`
+	Page     int64                `json:"page"`
+	PageSize int64                `json:"pageSize"`
+}
+
+// handleListLiveGames handles GET /v1/live-games?gameModeId=&serverId=&playerId=
+func (s *server) handleListLiveGames(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseLiveGameFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	games, err := s.repo.ListLiveGames(r.Context(), filter)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to list live games")
+		return
+	}
+
+	res := listLiveGamesResponse{Games: make([]*export.LiveGameRecord, len(games))}
+	for i, g := range games {
+		res.Games[i] = export.LiveGameRecordFromModel(g)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleGetLiveGame handles GET /v1/live-games/{id}
+func (s *server) handleGetLiveGame(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathId(w, r.URL.Path, "/v1/live-games/")
+	if !ok {
+		return
+	}
+
+	game, err := s.repo.GetLiveGame(r.Context(), id)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get live game")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, export.LiveGameRecordFromModel(game))
+}
+
+// handleListHistoricGames handles GET /v1/historic-games?gameModeId=&mapId=&playerId=&from=&to=&page=&pageSize=,
+// most recently finished first
+func (s *server) handleListHistoricGames(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseHistoricGameFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := queryInt(r, "page", 0, 0, 10_000)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pageSize, err := queryInt(r, "pageSize", defaultPageSize, 1, maxPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	games, err := s.repo.ListHistoricGames(r.Context(), filter, page, pageSize)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to list historic games")
+		return
+	}
+
+	res := listHistoricGamesResponse{
+		Games:    make([]*export.GameRecord, len(games)),
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for i, g := range games {
+		res.Games[i] = export.GameRecordFromModel(g)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleGetHistoricGame handles GET /v1/historic-games/{id} and GET /v1/historic-games/{id}/summary
+func (s *server) handleGetHistoricGame(w http.ResponseWriter, r *http.Request) {
+	if path, ok := strings.CutSuffix(r.URL.Path, "/summary"); ok {
+		s.handleGetGameSummary(w, r, path)
+		return
+	}
+
+	id, ok := pathId(w, r.URL.Path, "/v1/historic-games/")
+	if !ok {
+		return
+	}
+
+	game, err := s.repo.GetHistoricGame(r.Context(), id)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get historic game")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, export.GameRecordFromModel(game))
+}
+
+// pathId parses the game id following the prefix, writing an error response if it is invalid
+func pathId(w http.ResponseWriter, path string, prefix string) (primitive.ObjectID, bool) {
+	idStr := strings.TrimPrefix(path, prefix)
+	if idStr == "" || strings.Contains(idStr, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return primitive.NilObjectID, false
+	}
+
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid game id")
+		return primitive.NilObjectID, false
+	}
+
+	return id, true
+}
+
+func parseLiveGameFilter(r *http.Request) (repository.LiveGameFilter, error) {
+	query := r.URL.Query()
+
+	playerId, err := queryUUID(r, "playerId")
+	if err != nil {
+		return repository.LiveGameFilter{}, err
+	}
+
+	return repository.LiveGameFilter{
+		GameModeId: query.Get("gameModeId"),
+		ServerId:   query.Get("serverId"),
+		PlayerId:   playerId,
+	}, nil
+}
+
+func parseHistoricGameFilter(r *http.Request) (repository.HistoricGameFilter, error) {
+	query := r.URL.Query()
+
+	playerId, err := queryUUID(r, "playerId")
+	if err != nil {
+		return repository.HistoricGameFilter{}, err
+	}
+
+	from, err := queryTime(r, "from")
+	if err != nil {
+		return repository.HistoricGameFilter{}, err
+	}
+	to, err := queryTime(r, "to")
+	if err != nil {
+		return repository.HistoricGameFilter{}, err
+	}
+
+	return repository.HistoricGameFilter{
+		GameModeId: query.Get("gameModeId"),
+		MapId:      query.Get("mapId"),
+		PlayerId:   playerId,
+		From:       from,
+		To:         to,
+	}, nil
+}
+
+func queryUUID(r *http.Request, name string) (uuid.UUID, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s must be a UUID", name)
+	}
+
+	return id, nil
+}
+
+// queryTime parses an optional RFC 3339 time query parameter
+func queryTime(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time", name)
+	}
+
+	return &t, nil
+}
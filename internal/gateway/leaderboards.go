@@ -0,0 +1,304 @@
+package gateway
+
+import (
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultLeaderboardSize = 10
+
+type winStreakEntry struct {
+	Rank          int       `json:"rank"`
+	PlayerId      string    `json:"playerId"`
+	Username      string    `json:"username,omitempty"`
+	WinStreak     int64     `json:"winStreak"`
+	BestWinStreak int64     `json:"bestWinStreak"`
+	LastPlayed    time.Time `json:"lastPlayed"`
+}
+
+type winStreakLeaderboardResponse struct {
+	GameModeId string            `json:"gameModeId"`
+	SeasonId   string            `json:"seasonId,omitempty"`
+	Entries    []*winStreakEntry `json:"entries"`
+}
+
+// handleWinStreakLeaderboard handles GET /v1/leaderboards/win-streaks?gameModeId=&season=&activeSince=&limit=.
+// Players with equal streaks are ranked by who played most recently.
+func (s *server) handleWinStreakLeaderboard(w http.ResponseWriter, r *http.Request) {
+	gameModeId := r.URL.Query().Get("gameModeId")
+	if gameModeId == "" {
+		writeError(w, http.StatusBadRequest, "gameModeId is required")
+		return
+	}
+
+	seasonId, err := s.querySeason(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	activeSince, err := queryTime(r, "activeSince")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, err := queryInt(r, "limit", defaultLeaderboardSize, 1, maxPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stats, err := s.repo.GetWinStreakLeaderboard(r.Context(), seasonId, gameModeId, activeSince, limit)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get win streak leaderboard")
+		return
+	}
+
+	playerIds := make([]uuid.UUID, len(stats))
+	for i, st := range stats {
+		playerIds[i] = st.PlayerId
+	}
+	usernames, err := s.usernames(r.Context(), playerIds)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get leaderboard usernames")
+		return
+	}
+
+	res := winStreakLeaderboardResponse{GameModeId: gameModeId, SeasonId: seasonId, Entries: make([]*winStreakEntry, len(stats))}
+	for i, st := range stats {
+		res.Entries[i] = &winStreakEntry{
+			Rank:          i + 1,
+			PlayerId:      st.PlayerId.String(),
+			Username:      usernames[st.PlayerId],
+			WinStreak:     st.WinStreak,
+			BestWinStreak: st.BestWinStreak,
+			LastPlayed:    st.LastPlayed,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// defaultRatingMinGames hides players from the rating leaderboard until their rating has settled
+const defaultRatingMinGames = 10
+
+type ratingEntry struct {
+	Rank       int       `json:"rank"`
+	PlayerId   string    `json:"playerId"`
+	Username   string    `json:"username,omitempty"`
+	Rating     float64   `json:"rating"`
+	PeakRating float64   `json:"peakRating"`
+	Games      int64     `json:"games"`
+	LastPlayed time.Time `json:"lastPlayed"`
+}
+
+type ratingLeaderboardResponse struct {
+	GameModeId string         `json:"gameModeId"`
+	SeasonId   string         `json:"seasonId,omitempty"`
+	Entries    []*ratingEntry `json:"entries"`
+}
+
+// handleRatingLeaderboard handles GET /v1/leaderboards/ratings?gameModeId=&season=&minGames=&limit=.
+// Only players with at least minGames rated games are ranked.
+func (s *server) handleRatingLeaderboard(w http.ResponseWriter, r *http.Request) {
+	gameModeId := r.URL.Query().Get("gameModeId")
+	if gameModeId == "" {
+		writeError(w, http.StatusBadRequest, "gameModeId is required")
+		return
+	}
+
+	seasonId, err := s.querySeason(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	minGames, err := queryInt(r, "minGames", defaultRatingMinGames, 1, 10_000)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, err := queryInt(r, "limit", defaultLeaderboardSize, 1, maxPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ratings, err := s.repo.GetRatingLeaderboard(r.Context(), seasonId, gameModeId, minGames, limit)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get rating leaderboard")
+		return
+	}
+
+	playerIds := make([]uuid.UUID, len(ratings))
+	for i, rating := range ratings {
+		playerIds[i] = rating.PlayerId
+	}
+	usernames, err := s.usernames(r.Context(), playerIds)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get leaderboard usernames")
+		return
+	}
+
+	res := ratingLeaderboardResponse{GameModeId: gameModeId, SeasonId: seasonId, Entries: make([]*ratingEntry, len(ratings))}
+	for i, rating := range ratings {
+		res.Entries[i] = &ratingEntry{
+			Rank:       i + 1,
+			PlayerId:   rating.PlayerId.String(),
+			Username:   usernames[rating.PlayerId],
+			Rating:     rating.Rating,
+			PeakRating: rating.PeakRating,
+			Games:      rating.Games,
+			LastPlayed: rating.LastPlayed,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+type leaderboardEntry struct {
+	Rank      int64     `json:"rank"`
+	PlayerId  string    `json:"playerId"`
+	Username  string    `json:"username,omitempty"`
+	Value     int64     `json:"value"`
+	ReachedAt time.Time `json:"reachedAt"`
+}
+
+type leaderboardResponse struct {
+	Window     model.LeaderboardWindow `json:"window"`
+	Period     string                  `json:"period,omitempty"`
+	GameModeId string                  `json:"gameModeId"`
+	Metric     model.LeaderboardMetric `json:"metric"`
+	// Total is the number of players on the leaderboard
+	Total   int64               `json:"total"`
+	Entries []*leaderboardEntry `json:"entries"`
+}
+
+// handleLeaderboard handles GET /v1/leaderboards/{metric}?gameModeId=&window=&at=&season=&offset=&limit=&around=.
+// Players are ranked by value, then by who reached it first. If around is a player id, the page is centred on that player.
+func (s *server) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	metric := model.LeaderboardMetric(strings.TrimPrefix(r.URL.Path, "/v1/leaderboards/"))
+	if !metric.Valid() {
+		writeError(w, http.StatusNotFound, "unknown leaderboard")
+		return
+	}
+
+	key, err := s.queryLeaderboardKey(r, metric)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	offset, err := queryInt(r, "offset", 0, 0, 1_000_000)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, err := queryInt(r, "limit", defaultLeaderboardSize, 1, maxPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	around, err := queryUUID(r, "around")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if around != uuid.Nil {
+		_, rank, err := s.repo.GetLeaderboardRank(r.Context(), key, around)
+		if err != nil {
+			s.writeRepoError(w, err, "failed to get leaderboard rank")
+			return
+		}
+		offset = max(rank-1-limit/2, 0)
+	}
+
+	total, err := s.repo.CountLeaderboard(r.Context(), key)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to count leaderboard")
+		return
+	}
+	scores, err := s.repo.GetLeaderboard(r.Context(), key, offset, limit)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get leaderboard")
+		return
+	}
+
+	playerIds := make([]uuid.UUID, len(scores))
+	for i, sc := range scores {
+		playerIds[i] = sc.PlayerId
+	}
+	usernames, err := s.usernames(r.Context(), playerIds)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get leaderboard usernames")
+		return
+	}
+
+	res := leaderboardResponse{
+		Window:     key.Window,
+		Period:     key.Period,
+		GameModeId: key.GameModeId,
+		Metric:     key.Metric,
+		Total:      total,
+		Entries:    make([]*leaderboardEntry, len(scores)),
+	}
+	for i, sc := range scores {
+		res.Entries[i] = &leaderboardEntry{
+			Rank:      offset + int64(i) + 1,
+			PlayerId:  sc.PlayerId.String(),
+			Username:  usernames[sc.PlayerId],
+			Value:     sc.Value,
+			ReachedAt: sc.ReachedAt,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// queryLeaderboardKey reads the leaderboard of the metric from ?gameModeId=&window=&at=&season=.
+// The window defaults to all-time, at defaults to now and season to the current season.
+func (s *server) queryLeaderboardKey(r *http.Request, metric model.LeaderboardMetric) (model.LeaderboardKey, error) {
+	key := model.LeaderboardKey{
+		Window:     model.LeaderboardAllTime,
+		GameModeId: r.URL.Query().Get("gameModeId"),
+		Metric:     metric,
+	}
+	if key.GameModeId == "" {
+		return key, fmt.Errorf("gameModeId is required")
+	}
+
+	if window := r.URL.Query().Get("window"); window != "" {
+		key.Window = model.LeaderboardWindow(window)
+		if !key.Window.Valid() {
+			return key, fmt.Errorf("unknown window %q", window)
+		}
+	}
+
+	if key.Window == model.LeaderboardSeason {
+		seasonId, err := s.querySeason(r)
+		if err != nil {
+			return key, err
+		}
+		if seasonId == "" {
+			if seasonId, err = s.currentSeason(); err != nil {
+				return key, err
+			}
+		}
+		key.Period = seasonId
+		return key, nil
+	}
+
+	at, err := queryTime(r, "at")
+	if err != nil {
+		return key, err
+	}
+	if at != nil {
+		key.Period = key.Window.Period(*at)
+	} else {
+		key.Period = key.Window.Period(time.Now())
+	}
+
+	return key, nil
+}
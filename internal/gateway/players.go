@@ -0,0 +1,247 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"game-tracker/internal/export"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+type playerStatsResponse struct {
+	PlayerId string                      `json:"playerId"`
+	SeasonId string                      `json:"seasonId,omitempty"`
+	Stats    []*export.PlayerStatsRecord `json:"stats"`
+}
+
+type playerRatingsResponse struct {
+	PlayerId string                       `json:"playerId"`
+	SeasonId string                       `json:"seasonId,omitempty"`
+	Ratings  []*export.PlayerRatingRecord `json:"ratings"`
+}
+
+type headToHeadResponse struct {
+	PlayerId string                     `json:"playerId"`
+	OtherId  string                     `json:"otherId"`
+	Pairs    []*export.PlayerPairRecord `json:"pairs"`
+}
+
+type playerPairEntry struct {
+	*export.PlayerPairRecord
+	OtherUsername string `json:"otherUsername,omitempty"`
+}
+
+type topPlayerPairsResponse struct {
+	PlayerId string               `json:"playerId"`
+	Kind     model.PlayerPairKind `json:"kind"`
+	Pairs    []*playerPairEntry   `json:"pairs"`
+}
+
+type playerRank struct {
+	Metric model.LeaderboardMetric `json:"metric"`
+	Rank   int64                   `json:"rank"`
+	// Total is the number of players on the leaderboard
+	Total int64 `json:"total"`
+	Value int64 `json:"value"`
+}
+
+type playerRanksResponse struct {
+	PlayerId   string                  `json:"playerId"`
+	Window     model.LeaderboardWindow `json:"window"`
+	Period     string                  `json:"period,omitempty"`
+	GameModeId string                  `json:"gameModeId"`
+	Ranks      []*playerRank           `json:"ranks"`
+}
+
+// handlePlayer handles GET /v1/players/{id}/stats, GET /v1/players/{id}/ratings, GET /v1/players/{id}/head-to-head/{otherId},
+// GET /v1/players/{id}/rivals, GET /v1/players/{id}/partners and GET /v1/players/{id}/ranks.
+// Each accepts ?season= to limit them to a season.
+func (s *server) handlePlayer(w http.ResponseWriter, r *http.Request) {
+	idStr, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v1/players/"), "/")
+
+	playerId, err := uuid.Parse(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid player id")
+		return
+	}
+
+	seasonId, err := s.querySeason(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch {
+	case rest == "stats":
+		s.handleGetPlayerStats(w, r, playerId, seasonId)
+	case rest == "ratings":
+		s.handleGetPlayerRatings(w, r, playerId, seasonId)
+	case strings.HasPrefix(rest, "head-to-head/"):
+		otherId, err := uuid.Parse(strings.TrimPrefix(rest, "head-to-head/"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid other player id")
+			return
+		}
+		s.handleGetHeadToHead(w, r, playerId, otherId, seasonId)
+	case rest == string(model.PlayerPairRivals) || rest == string(model.PlayerPairPartners):
+		s.handleGetTopPlayerPairs(w, r, playerId, seasonId, model.PlayerPairKind(rest))
+	case rest == "ranks":
+		s.handleGetPlayerRanks(w, r, playerId)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *server) handleGetPlayerStats(w http.ResponseWriter, r *http.Request, playerId uuid.UUID, seasonId string) {
+	stats, err := s.repo.GetPlayerStats(r.Context(), playerId, seasonId)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get player stats")
+		return
+	}
+
+	res := playerStatsResponse{
+		PlayerId: playerId.String(),
+		SeasonId: seasonId,
+		Stats:    make([]*export.PlayerStatsRecord, len(stats)),
+	}
+	for i, st := range stats {
+		res.Stats[i] = export.PlayerStatsRecordFromModel(st)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *server) handleGetPlayerRatings(w http.ResponseWriter, r *http.Request, playerId uuid.UUID, seasonId string) {
+	ratings, err := s.repo.GetPlayerRatings(r.Context(), playerId)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get player ratings")
+		return
+	}
+
+	res := playerRatingsResponse{PlayerId: playerId.String(), SeasonId: seasonId, Ratings: make([]*export.PlayerRatingRecord, 0)}
+	for _, rating := range ratings {
+		if rating.SeasonId == seasonId {
+			res.Ratings = append(res.Ratings, export.PlayerRatingRecordFromModel(rating))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleGetHeadToHead returns the player's record against and alongside the other player in each game mode
+func (s *server) handleGetHeadToHead(w http.ResponseWriter, r *http.Request, playerId uuid.UUID, otherId uuid.UUID,
+	seasonId string) {
+
+	pairs, err := s.repo.GetHeadToHead(r.Context(), playerId, otherId, seasonId)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get head to head")
+		return
+	}
+
+	res := headToHeadResponse{
+		PlayerId: playerId.String(),
+		OtherId:  otherId.String(),
+		Pairs:    make([]*export.PlayerPairRecord, len(pairs)),
+	}
+	for i, p := range pairs {
+		res.Pairs[i] = export.PlayerPairRecordFromModel(p)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleGetTopPlayerPairs handles ?gameModeId=&minGames=&limit=. Rivals are the players most played against,
+// partners the teammates with the highest win rate together.
+func (s *server) handleGetTopPlayerPairs(w http.ResponseWriter, r *http.Request, playerId uuid.UUID, seasonId string,
+	kind model.PlayerPairKind) {
+
+	minGames, err := queryInt(r, "minGames", kind.DefaultMinGames(), 1, 10_000)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, err := queryInt(r, "limit", defaultLeaderboardSize, 1, maxPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pairs, err := s.repo.GetTopPlayerPairs(r.Context(), playerId, seasonId, r.URL.Query().Get("gameModeId"), kind,
+		minGames, limit)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get top player pairs")
+		return
+	}
+
+	otherIds := make([]uuid.UUID, len(pairs))
+	for i, p := range pairs {
+		otherIds[i] = p.OtherId
+	}
+	usernames, err := s.usernames(r.Context(), otherIds)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get player pair usernames")
+		return
+	}
+
+	res := topPlayerPairsResponse{PlayerId: playerId.String(), Kind: kind, Pairs: make([]*playerPairEntry, len(pairs))}
+	for i, p := range pairs {
+		res.Pairs[i] = &playerPairEntry{
+			PlayerPairRecord: export.PlayerPairRecordFromModel(p),
+			OtherUsername:    usernames[p.OtherId],
+		}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleGetPlayerRanks handles ?gameModeId=&window=&at=&season=, returning the player's rank on every leaderboard
+// of the window they are on
+func (s *server) handleGetPlayerRanks(w http.ResponseWriter, r *http.Request, playerId uuid.UUID) {
+	res := playerRanksResponse{PlayerId: playerId.String(), Ranks: make([]*playerRank, 0, len(model.LeaderboardMetrics))}
+
+	for _, metric := range model.LeaderboardMetrics {
+		key, err := s.queryLeaderboardKey(r, metric)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		res.Window, res.Period, res.GameModeId = key.Window, key.Period, key.GameModeId
+
+		score, rank, err := s.repo.GetLeaderboardRank(r.Context(), key, playerId)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			s.writeRepoError(w, err, "failed to get leaderboard rank")
+			return
+		}
+
+		total, err := s.repo.CountLeaderboard(r.Context(), key)
+		if err != nil {
+			s.writeRepoError(w, err, "failed to count leaderboard")
+			return
+		}
+
+		res.Ranks = append(res.Ranks, &playerRank{Metric: metric, Rank: rank, Total: total, Value: score.Value})
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// usernames returns the current username of every player in the player directory
+func (s *server) usernames(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	players, err := s.repo.GetPlayers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make(map[uuid.UUID]string, len(players))
+	for _, p := range players {
+		usernames[p.Id] = p.Username
+	}
+
+	return usernames, nil
+}
@@ -0,0 +1,167 @@
+package gateway
+
+import (
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type seasonResponse struct {
+	Id      string    `json:"id"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Current bool      `json:"current"`
+}
+
+type listSeasonsResponse struct {
+	Seasons []*seasonResponse `json:"seasons"`
+}
+
+type hallOfFameEntry struct {
+	Rank     int    `json:"rank"`
+	PlayerId string `json:"playerId"`
+	Username string `json:"username,omitempty"`
+	Value    int64  `json:"value"`
+}
+
+type hallOfFameLeaderboard struct {
+	Metric  model.StatsMetric  `json:"metric"`
+	Entries []*hallOfFameEntry `json:"entries"`
+}
+
+type hallOfFameRating struct {
+	Rank       int     `json:"rank"`
+	PlayerId   string  `json:"playerId"`
+	Username   string  `json:"username,omitempty"`
+	Rating     float64 `json:"rating"`
+	PeakRating float64 `json:"peakRating"`
+	Games      int64   `json:"games"`
+}
+
+type hallOfFameGameMode struct {
+	GameModeId   string                   `json:"gameModeId"`
+	Leaderboards []*hallOfFameLeaderboard `json:"leaderboards"`
+	Ratings      []*hallOfFameRating      `json:"ratings"`
+}
+
+type hallOfFameResponse struct {
+	SeasonId  string                `json:"seasonId"`
+	Start     time.Time             `json:"start"`
+	End       time.Time             `json:"end"`
+	CreatedAt time.Time             `json:"createdAt"`
+	GameModes []*hallOfFameGameMode `json:"gameModes"`
+}
+
+// handleListSeasons handles GET /v1/seasons, listing the configured seasons in order
+func (s *server) handleListSeasons(w http.ResponseWriter, _ *http.Request) {
+	now := time.Now()
+	current := s.seasons.At(now)
+
+	res := listSeasonsResponse{Seasons: make([]*seasonResponse, len(s.seasons))}
+	for i, season := range s.seasons {
+		res.Seasons[i] = &seasonResponse{
+			Id:      season.Id,
+			Start:   season.Start,
+			End:     season.End,
+			Current: season == current,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleSeason handles GET /v1/seasons/{id}/hall-of-fame, the snapshot saved when the season ended
+func (s *server) handleSeason(w http.ResponseWriter, r *http.Request) {
+	seasonId, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v1/seasons/"), "/")
+	if rest != "hall-of-fame" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if s.seasons.Get(seasonId) == nil {
+		writeError(w, http.StatusNotFound, "unknown season")
+		return
+	}
+
+	snapshot, err := s.repo.GetSeasonSnapshot(r.Context(), seasonId)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get season snapshot")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, hallOfFameFromModel(snapshot))
+}
+
+func hallOfFameFromModel(snapshot *model.SeasonSnapshot) *hallOfFameResponse {
+	res := &hallOfFameResponse{
+		SeasonId:  snapshot.SeasonId,
+		Start:     snapshot.Start,
+		End:       snapshot.End,
+		CreatedAt: snapshot.CreatedAt,
+		GameModes: make([]*hallOfFameGameMode, len(snapshot.GameModes)),
+	}
+
+	for i, g := range snapshot.GameModes {
+		gameMode := &hallOfFameGameMode{
+			GameModeId:   g.GameModeId,
+			Leaderboards: make([]*hallOfFameLeaderboard, len(g.Leaderboards)),
+			Ratings:      make([]*hallOfFameRating, len(g.Ratings)),
+		}
+
+		for j, l := range g.Leaderboards {
+			leaderboard := &hallOfFameLeaderboard{Metric: l.Metric, Entries: make([]*hallOfFameEntry, len(l.Entries))}
+			for k, e := range l.Entries {
+				leaderboard.Entries[k] = &hallOfFameEntry{
+					Rank:     e.Rank,
+					PlayerId: e.PlayerId.String(),
+					Username: e.Username,
+					Value:    e.Value,
+				}
+			}
+			gameMode.Leaderboards[j] = leaderboard
+		}
+
+		for j, e := range g.Ratings {
+			gameMode.Ratings[j] = &hallOfFameRating{
+				Rank:       e.Rank,
+				PlayerId:   e.PlayerId.String(),
+				Username:   e.Username,
+				Rating:     e.Rating,
+				PeakRating: e.PeakRating,
+				Games:      e.Games,
+			}
+		}
+
+		res.GameModes[i] = gameMode
+	}
+
+	return res
+}
+
+// querySeason returns the season id of the season query parameter, which may also be "current".
+// An empty id means all-time.
+func (s *server) querySeason(r *http.Request) (string, error) {
+	value := r.URL.Query().Get("season")
+	switch value {
+	case "":
+		return "", nil
+	case "current":
+		return s.currentSeason()
+	}
+
+	if s.seasons.Get(value) == nil {
+		return "", fmt.Errorf("unknown season %q", value)
+	}
+
+	return value, nil
+}
+
+func (s *server) currentSeason() (string, error) {
+	season := s.seasons.At(time.Now())
+	if season == nil {
+		return "", fmt.Errorf("no season is currently running")
+	}
+
+	return season.Id, nil
+}
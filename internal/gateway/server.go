@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/live"
+	"game-tracker/internal/repository"
+	"go.uber.org/zap"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// server is the HTTP gateway for clients that can't use gRPC, such as the website.
+// Every response is JSON apart from the live game stream, which uses Server-Sent Events.
+type server struct {
+	logger *zap.SugaredLogger
+	repo   repository.Repository
+	hub    *live.Hub
+	// seasons are the configured seasons stats queries can be limited to
+	seasons config.Seasons
+
+	allowedOrigins map[string]struct{}
+}
+
+// NewServer starts the gateway, shutting it down when the context is cancelled
+func NewServer(ctx context.Context, wg *sync.WaitGroup, cfg config.HTTPConfig, seasons config.Seasons,
+	logger *zap.SugaredLogger, repo repository.Repository, hub *live.Hub) {
+
+	s := &server{
+		logger:  logger,
+		repo:    repo,
+		hub:     hub,
+		seasons: seasons,
+
+		allowedOrigins: make(map[string]struct{}, len(cfg.AllowedOrigins)),
+	}
+	for _, origin := range cfg.AllowedOrigins {
+		s.allowedOrigins[origin] = struct{}{}
+	}
+
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Streams stay open until the client leaves, so they are ended by cancelling the base context on shutdown
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		logger.Infow("started http gateway", "port", cfg.Port)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorw("http gateway stopped", "error", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Errorw("failed to shut down http gateway", "error", err)
+		}
+	}()
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/live-games", s.handleListLiveGames)
+	mux.HandleFunc("/v1/live-games/stream", s.handleStreamLiveGames)
+	mux.HandleFunc("/v1/live-games/", s.handleGetLiveGame)
+	mux.HandleFunc("/v1/historic-games", s.handleListHistoricGames)
+	mux.HandleFunc("/v1/historic-games/", s.handleGetHistoricGame)
+	mux.HandleFunc("/v1/players/", s.handlePlayer)
+	mux.HandleFunc("/v1/leaderboards/win-streaks", s.handleWinStreakLeaderboard)
+	mux.HandleFunc("/v1/leaderboards/ratings", s.handleRatingLeaderboard)
+	mux.HandleFunc("/v1/leaderboards/", s.handleLeaderboard)
+	mux.HandleFunc("/v1/seasons", s.handleListSeasons)
+	mux.HandleFunc("/v1/seasons/", s.handleSeason)
+
+	return s.withCORS(mux)
+}
+
+// withCORS allows browsers on the allowed origins to call the gateway. Every endpoint is read only.
+func (s *server) withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && s.originAllowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Add("Vary", "Origin")
+		}
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *server) originAllowed(origin string) bool {
+	if _, ok := s.allowedOrigins["*"]; ok {
+		return true
+	}
+
+	_, ok := s.allowedOrigins[origin]
+	return ok
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// writeRepoError writes a not found response for ErrNotFound, logging any other error as an internal error
+func (s *server) writeRepoError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	s.logger.Errorw(message, "error", err)
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+// queryInt parses an optional integer query parameter, returning def if it isn't present
+func queryInt(r *http.Request, name string, def int64, minValue int64, maxValue int64) (int64, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || i < minValue || i > maxValue {
+		return 0, fmt.Errorf("%s must be a number between %d and %d", name, minValue, maxValue)
+	}
+
+	return i, nil
+}
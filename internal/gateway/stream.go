@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"game-tracker/internal/export"
+	"game-tracker/internal/live"
+	"net/http"
+	"time"
+)
+
+// keepAliveInterval is how often a comment is sent on idle streams so proxies don't close them
+const keepAliveInterval = 15 * time.Second
+
+// Stream event names. A snapshot of the matching live games is sent first, followed by every change to them.
+// A reset is sent before the stream is closed because the client fell behind; browsers reconnect automatically
+// and receive a new snapshot.
+const (
+	streamEventSnapshot = "snapshot"
+	streamEventReset    = "reset"
+)
+
+// handleStreamLiveGames handles GET /v1/live-games/stream?gameModeId=&serverId=&playerId=
+// using Server-Sent Events. Created and updated events contain the live game, finished events the historic game.
+func (s *server) handleStreamLiveGames(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	filter, err := parseLiveGameFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Subscribe before loading the snapshot so no change between the two is missed
+	events := s.hub.Subscribe(r.Context(), live.Filter{
+		GameModeId: filter.GameModeId,
+		ServerId:   filter.ServerId,
+		PlayerId:   filter.PlayerId,
+	})
+
+	games, err := s.repo.ListLiveGames(r.Context(), filter)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to list live games")
+		return
+	}
+
+	snapshot := make([]*export.LiveGameRecord, len(games))
+	for i, g := range games {
+		snapshot[i] = export.LiveGameRecordFromModel(g)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeEvent(w, streamEventSnapshot, snapshot); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-events:
+			if !ok {
+				_ = writeEvent(w, streamEventReset, struct{}{})
+				flusher.Flush()
+				return
+			}
+
+			if err := writeEvent(w, string(e.Type), eventRecord(e)); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+func eventRecord(e *live.Event) any {
+	if e.HistoricGame != nil {
+		return export.GameRecordFromModel(e.HistoricGame)
+	}
+
+	return export.LiveGameRecordFromModel(e.LiveGame)
+}
+
+func writeEvent(w http.ResponseWriter, name string, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", name, err)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, encoded)
+	return err
+}
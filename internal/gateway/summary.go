@@ -0,0 +1,39 @@
+package gateway
+
+import (
+	"game-tracker/internal/summary"
+	"net/http"
+)
+
+// handleGetGameSummary handles GET /v1/historic-games/{id}/summary?format=json|markdown|discord.
+// The discord format is a webhook payload that can be posted to a Discord channel as is.
+func (s *server) handleGetGameSummary(w http.ResponseWriter, r *http.Request, path string) {
+	id, ok := pathId(w, path, "/v1/historic-games/")
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "markdown" && format != "discord" {
+		writeError(w, http.StatusBadRequest, "format must be json, markdown or discord")
+		return
+	}
+
+	game, err := s.repo.GetHistoricGame(r.Context(), id)
+	if err != nil {
+		s.writeRepoError(w, err, "failed to get historic game")
+		return
+	}
+
+	sum := summary.FromGame(game)
+
+	switch format {
+	case "markdown":
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		_, _ = w.Write([]byte(sum.Markdown()))
+	case "discord":
+		writeJSON(w, http.StatusOK, sum.DiscordPayload())
+	default:
+		writeJSON(w, http.StatusOK, sum)
+	}
+}
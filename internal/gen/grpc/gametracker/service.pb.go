@@ -0,0 +1,2715 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: game_tracker/service.proto
+
+package gametracker
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LiveGameEventType int32
+
+const (
+	LiveGameEventType_LIVE_GAME_EVENT_TYPE_UNSPECIFIED LiveGameEventType = 0
+	// LIVE_GAME_EVENT_TYPE_SNAPSHOT is sent for each game that was live when the stream opened
+	LiveGameEventType_LIVE_GAME_EVENT_TYPE_SNAPSHOT LiveGameEventType = 1
+	LiveGameEventType_LIVE_GAME_EVENT_TYPE_CREATED  LiveGameEventType = 2
+	LiveGameEventType_LIVE_GAME_EVENT_TYPE_UPDATED  LiveGameEventType = 3
+	LiveGameEventType_LIVE_GAME_EVENT_TYPE_FINISHED LiveGameEventType = 4
+)
+
+// Enum value maps for LiveGameEventType.
+var (
+	LiveGameEventType_name = map[int32]string{
+		0: "LIVE_GAME_EVENT_TYPE_UNSPECIFIED",
+		1: "LIVE_GAME_EVENT_TYPE_SNAPSHOT",
+		2: "LIVE_GAME_EVENT_TYPE_CREATED",
+		3: "LIVE_GAME_EVENT_TYPE_UPDATED",
+		4: "LIVE_GAME_EVENT_TYPE_FINISHED",
+	}
+	LiveGameEventType_value = map[string]int32{
+		"LIVE_GAME_EVENT_TYPE_UNSPECIFIED": 0,
+		"LIVE_GAME_EVENT_TYPE_SNAPSHOT":    1,
+		"LIVE_GAME_EVENT_TYPE_CREATED":     2,
+		"LIVE_GAME_EVENT_TYPE_UPDATED":     3,
+		"LIVE_GAME_EVENT_TYPE_FINISHED":    4,
+	}
+)
+
+func (x LiveGameEventType) Enum() *LiveGameEventType {
+	p := new(LiveGameEventType)
+	*p = x
+	return p
+}
+
+func (x LiveGameEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LiveGameEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_tracker_service_proto_enumTypes[0].Descriptor()
+}
+
+func (LiveGameEventType) Type() protoreflect.EnumType {
+	return &file_game_tracker_service_proto_enumTypes[0]
+}
+
+func (x LiveGameEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LiveGameEventType.Descriptor instead.
+func (LiveGameEventType) EnumDescriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{0}
+}
+
+type PlayerPairKind int32
+
+const (
+	PlayerPairKind_PLAYER_PAIR_KIND_UNSPECIFIED PlayerPairKind = 0
+	PlayerPairKind_PLAYER_PAIR_KIND_RIVALS      PlayerPairKind = 1
+	PlayerPairKind_PLAYER_PAIR_KIND_PARTNERS    PlayerPairKind = 2
+)
+
+// Enum value maps for PlayerPairKind.
+var (
+	PlayerPairKind_name = map[int32]string{
+		0: "PLAYER_PAIR_KIND_UNSPECIFIED",
+		1: "PLAYER_PAIR_KIND_RIVALS",
+		2: "PLAYER_PAIR_KIND_PARTNERS",
+	}
+	PlayerPairKind_value = map[string]int32{
+		"PLAYER_PAIR_KIND_UNSPECIFIED": 0,
+		"PLAYER_PAIR_KIND_RIVALS":      1,
+		"PLAYER_PAIR_KIND_PARTNERS":    2,
+	}
+)
+
+func (x PlayerPairKind) Enum() *PlayerPairKind {
+	p := new(PlayerPairKind)
+	*p = x
+	return p
+}
+
+func (x PlayerPairKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlayerPairKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_tracker_service_proto_enumTypes[1].Descriptor()
+}
+
+func (PlayerPairKind) Type() protoreflect.EnumType {
+	return &file_game_tracker_service_proto_enumTypes[1]
+}
+
+func (x PlayerPairKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlayerPairKind.Descriptor instead.
+func (PlayerPairKind) EnumDescriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{1}
+}
+
+type ErasePlayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// requested_by is the staff member or ticket the erasure was requested by, kept in the audit
+	RequestedBy string `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+}
+
+func (x *ErasePlayerRequest) Reset() {
+	*x = ErasePlayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErasePlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErasePlayerRequest) ProtoMessage() {}
+
+func (x *ErasePlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErasePlayerRequest.ProtoReflect.Descriptor instead.
+func (*ErasePlayerRequest) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ErasePlayerRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ErasePlayerRequest) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+type ErasePlayerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuditId              string `protobuf:"bytes,1,opt,name=audit_id,json=auditId,proto3" json:"audit_id,omitempty"`
+	LiveGames            int32  `protobuf:"varint,2,opt,name=live_games,json=liveGames,proto3" json:"live_games,omitempty"`
+	HistoricGames        int32  `protobuf:"varint,3,opt,name=historic_games,json=historicGames,proto3" json:"historic_games,omitempty"`
+	Archives             int32  `protobuf:"varint,5,opt,name=archives,proto3" json:"archives,omitempty"`
+	DeletedPlayerRecords int64  `protobuf:"varint,4,opt,name=deleted_player_records,json=deletedPlayerRecords,proto3" json:"deleted_player_records,omitempty"`
+	OutboxEvents         int64  `protobuf:"varint,6,opt,name=outbox_events,json=outboxEvents,proto3" json:"outbox_events,omitempty"`
+	// season_ids are the seasons whose hall of fame referenced the player
+	SeasonIds []string `protobuf:"bytes,7,rep,name=season_ids,json=seasonIds,proto3" json:"season_ids,omitempty"`
+}
+
+func (x *ErasePlayerResponse) Reset() {
+	*x = ErasePlayerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErasePlayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErasePlayerResponse) ProtoMessage() {}
+
+func (x *ErasePlayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErasePlayerResponse.ProtoReflect.Descriptor instead.
+func (*ErasePlayerResponse) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ErasePlayerResponse) GetAuditId() string {
+	if x != nil {
+		return x.AuditId
+	}
+	return ""
+}
+
+func (x *ErasePlayerResponse) GetLiveGames() int32 {
+	if x != nil {
+		return x.LiveGames
+	}
+	return 0
+}
+
+func (x *ErasePlayerResponse) GetHistoricGames() int32 {
+	if x != nil {
+		return x.HistoricGames
+	}
+	return 0
+}
+
+func (x *ErasePlayerResponse) GetArchives() int32 {
+	if x != nil {
+		return x.Archives
+	}
+	return 0
+}
+
+func (x *ErasePlayerResponse) GetDeletedPlayerRecords() int64 {
+	if x != nil {
+		return x.DeletedPlayerRecords
+	}
+	return 0
+}
+
+func (x *ErasePlayerResponse) GetOutboxEvents() int64 {
+	if x != nil {
+		return x.OutboxEvents
+	}
+	return 0
+}
+
+func (x *ErasePlayerResponse) GetSeasonIds() []string {
+	if x != nil {
+		return x.SeasonIds
+	}
+	return nil
+}
+
+type ExportPlayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// zip wraps the document in a zip archive
+	Zip bool `protobuf:"varint,2,opt,name=zip,proto3" json:"zip,omitempty"`
+}
+
+func (x *ExportPlayerRequest) Reset() {
+	*x = ExportPlayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportPlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPlayerRequest) ProtoMessage() {}
+
+func (x *ExportPlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPlayerRequest.ProtoReflect.Descriptor instead.
+func (*ExportPlayerRequest) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportPlayerRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ExportPlayerRequest) GetZip() bool {
+	if x != nil {
+		return x.Zip
+	}
+	return false
+}
+
+type ExportPlayerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// chunk is the next part of the document, to be concatenated in the order received
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (x *ExportPlayerResponse) Reset() {
+	*x = ExportPlayerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportPlayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPlayerResponse) ProtoMessage() {}
+
+func (x *ExportPlayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPlayerResponse.ProtoReflect.Descriptor instead.
+func (*ExportPlayerResponse) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExportPlayerResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// Filters are optional, and every filter set must match
+type WatchLiveGamesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameModeId *string `protobuf:"bytes,1,opt,name=game_mode_id,json=gameModeId,proto3,oneof" json:"game_mode_id,omitempty"`
+	ServerId   *string `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3,oneof" json:"server_id,omitempty"`
+	PlayerId   *string `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3,oneof" json:"player_id,omitempty"`
+}
+
+func (x *WatchLiveGamesRequest) Reset() {
+	*x = WatchLiveGamesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchLiveGamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLiveGamesRequest) ProtoMessage() {}
+
+func (x *WatchLiveGamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLiveGamesRequest.ProtoReflect.Descriptor instead.
+func (*WatchLiveGamesRequest) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WatchLiveGamesRequest) GetGameModeId() string {
+	if x != nil && x.GameModeId != nil {
+		return *x.GameModeId
+	}
+	return ""
+}
+
+func (x *WatchLiveGamesRequest) GetServerId() string {
+	if x != nil && x.ServerId != nil {
+		return *x.ServerId
+	}
+	return ""
+}
+
+func (x *WatchLiveGamesRequest) GetPlayerId() string {
+	if x != nil && x.PlayerId != nil {
+		return *x.PlayerId
+	}
+	return ""
+}
+
+type LiveGameEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type LiveGameEventType `protobuf:"varint,1,opt,name=type,proto3,enum=emortal.grpc.game_tracker.LiveGameEventType" json:"type,omitempty"`
+	// live_game is set for snapshot, created and updated events, historic_game for finished events
+	//
+	// Types that are assignable to Game:
+	//	*LiveGameEvent_LiveGame
+	//	*LiveGameEvent_HistoricGame
+	Game isLiveGameEvent_Game `protobuf_oneof:"game"`
+}
+
+func (x *LiveGameEvent) Reset() {
+	*x = LiveGameEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiveGameEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveGameEvent) ProtoMessage() {}
+
+func (x *LiveGameEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveGameEvent.ProtoReflect.Descriptor instead.
+func (*LiveGameEvent) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LiveGameEvent) GetType() LiveGameEventType {
+	if x != nil {
+		return x.Type
+	}
+	return LiveGameEventType_LIVE_GAME_EVENT_TYPE_UNSPECIFIED
+}
+
+func (m *LiveGameEvent) GetGame() isLiveGameEvent_Game {
+	if m != nil {
+		return m.Game
+	}
+	return nil
+}
+
+func (x *LiveGameEvent) GetLiveGame() *LiveGame {
+	if x, ok := x.GetGame().(*LiveGameEvent_LiveGame); ok {
+		return x.LiveGame
+	}
+	return nil
+}
+
+func (x *LiveGameEvent) GetHistoricGame() *HistoricGame {
+	if x, ok := x.GetGame().(*LiveGameEvent_HistoricGame); ok {
+		return x.HistoricGame
+	}
+	return nil
+}
+
+type isLiveGameEvent_Game interface {
+	isLiveGameEvent_Game()
+}
+
+type LiveGameEvent_LiveGame struct {
+	LiveGame *LiveGame `protobuf:"bytes,2,opt,name=live_game,json=liveGame,proto3,oneof"`
+}
+
+type LiveGameEvent_HistoricGame struct {
+	HistoricGame *HistoricGame `protobuf:"bytes,3,opt,name=historic_game,json=historicGame,proto3,oneof"`
+}
+
+func (*LiveGameEvent_LiveGame) isLiveGameEvent_Game() {}
+
+func (*LiveGameEvent_HistoricGame) isLiveGameEvent_Game() {}
+
+type LiveGame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GameModeId   string                 `protobuf:"bytes,2,opt,name=game_mode_id,json=gameModeId,proto3" json:"game_mode_id,omitempty"`
+	MapId        *string                `protobuf:"bytes,3,opt,name=map_id,json=mapId,proto3,oneof" json:"map_id,omitempty"`
+	ServerId     string                 `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	StartTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3,oneof" json:"start_time,omitempty"`
+	LastUpdated  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Players      []*Player              `protobuf:"bytes,7,rep,name=players,proto3" json:"players,omitempty"`
+	Teams        []*Team                `protobuf:"bytes,8,rep,name=teams,proto3" json:"teams,omitempty"`
+	TowerDefence *TowerDefence          `protobuf:"bytes,9,opt,name=tower_defence,json=towerDefence,proto3,oneof" json:"tower_defence,omitempty"`
+	BlockSumo    *BlockSumo             `protobuf:"bytes,10,opt,name=block_sumo,json=blockSumo,proto3,oneof" json:"block_sumo,omitempty"`
+}
+
+func (x *LiveGame) Reset() {
+	*x = LiveGame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiveGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveGame) ProtoMessage() {}
+
+func (x *LiveGame) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveGame.ProtoReflect.Descriptor instead.
+func (*LiveGame) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LiveGame) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LiveGame) GetGameModeId() string {
+	if x != nil {
+		return x.GameModeId
+	}
+	return ""
+}
+
+func (x *LiveGame) GetMapId() string {
+	if x != nil && x.MapId != nil {
+		return *x.MapId
+	}
+	return ""
+}
+
+func (x *LiveGame) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *LiveGame) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *LiveGame) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *LiveGame) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *LiveGame) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *LiveGame) GetTowerDefence() *TowerDefence {
+	if x != nil {
+		return x.TowerDefence
+	}
+	return nil
+}
+
+func (x *LiveGame) GetBlockSumo() *BlockSumo {
+	if x != nil {
+		return x.BlockSumo
+	}
+	return nil
+}
+
+type HistoricGame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GameModeId     string                 `protobuf:"bytes,2,opt,name=game_mode_id,json=gameModeId,proto3" json:"game_mode_id,omitempty"`
+	MapId          *string                `protobuf:"bytes,3,opt,name=map_id,json=mapId,proto3,oneof" json:"map_id,omitempty"`
+	ServerId       string                 `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	StartTime      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3,oneof" json:"start_time,omitempty"`
+	EndTime        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	DurationMillis int64                  `protobuf:"varint,7,opt,name=duration_millis,json=durationMillis,proto3" json:"duration_millis,omitempty"`
+	Players        []*Player              `protobuf:"bytes,8,rep,name=players,proto3" json:"players,omitempty"`
+	Participation  []*Participation       `protobuf:"bytes,9,rep,name=participation,proto3" json:"participation,omitempty"`
+	Teams          []*Team                `protobuf:"bytes,10,rep,name=teams,proto3" json:"teams,omitempty"`
+	WinnerIds      []string               `protobuf:"bytes,11,rep,name=winner_ids,json=winnerIds,proto3" json:"winner_ids,omitempty"`
+	LoserIds       []string               `protobuf:"bytes,12,rep,name=loser_ids,json=loserIds,proto3" json:"loser_ids,omitempty"`
+	WinningTeamId  *string                `protobuf:"bytes,13,opt,name=winning_team_id,json=winningTeamId,proto3,oneof" json:"winning_team_id,omitempty"`
+	TowerDefence   *TowerDefence          `protobuf:"bytes,14,opt,name=tower_defence,json=towerDefence,proto3,oneof" json:"tower_defence,omitempty"`
+	BlockSumo      *BlockSumo             `protobuf:"bytes,15,opt,name=block_sumo,json=blockSumo,proto3,oneof" json:"block_sumo,omitempty"`
+}
+
+func (x *HistoricGame) Reset() {
+	*x = HistoricGame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoricGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoricGame) ProtoMessage() {}
+
+func (x *HistoricGame) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoricGame.ProtoReflect.Descriptor instead.
+func (*HistoricGame) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HistoricGame) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HistoricGame) GetGameModeId() string {
+	if x != nil {
+		return x.GameModeId
+	}
+	return ""
+}
+
+func (x *HistoricGame) GetMapId() string {
+	if x != nil && x.MapId != nil {
+		return *x.MapId
+	}
+	return ""
+}
+
+func (x *HistoricGame) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *HistoricGame) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *HistoricGame) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *HistoricGame) GetDurationMillis() int64 {
+	if x != nil {
+		return x.DurationMillis
+	}
+	return 0
+}
+
+func (x *HistoricGame) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *HistoricGame) GetParticipation() []*Participation {
+	if x != nil {
+		return x.Participation
+	}
+	return nil
+}
+
+func (x *HistoricGame) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *HistoricGame) GetWinnerIds() []string {
+	if x != nil {
+		return x.WinnerIds
+	}
+	return nil
+}
+
+func (x *HistoricGame) GetLoserIds() []string {
+	if x != nil {
+		return x.LoserIds
+	}
+	return nil
+}
+
+func (x *HistoricGame) GetWinningTeamId() string {
+	if x != nil && x.WinningTeamId != nil {
+		return *x.WinningTeamId
+	}
+	return ""
+}
+
+func (x *HistoricGame) GetTowerDefence() *TowerDefence {
+	if x != nil {
+		return x.TowerDefence
+	}
+	return nil
+}
+
+func (x *HistoricGame) GetBlockSumo() *BlockSumo {
+	if x != nil {
+		return x.BlockSumo
+	}
+	return nil
+}
+
+type Player struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Player) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Player) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type Participation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId         string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Username         string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	FirstJoinTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=first_join_time,json=firstJoinTime,proto3" json:"first_join_time,omitempty"`
+	LastLeaveTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_leave_time,json=lastLeaveTime,proto3" json:"last_leave_time,omitempty"`
+	TimeInGameMillis int64                  `protobuf:"varint,5,opt,name=time_in_game_millis,json=timeInGameMillis,proto3" json:"time_in_game_millis,omitempty"`
+	LeftEarly        bool                   `protobuf:"varint,6,opt,name=left_early,json=leftEarly,proto3" json:"left_early,omitempty"`
+}
+
+func (x *Participation) Reset() {
+	*x = Participation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Participation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Participation) ProtoMessage() {}
+
+func (x *Participation) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Participation.ProtoReflect.Descriptor instead.
+func (*Participation) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Participation) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Participation) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Participation) GetFirstJoinTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstJoinTime
+	}
+	return nil
+}
+
+func (x *Participation) GetLastLeaveTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLeaveTime
+	}
+	return nil
+}
+
+func (x *Participation) GetTimeInGameMillis() int64 {
+	if x != nil {
+		return x.TimeInGameMillis
+	}
+	return 0
+}
+
+func (x *Participation) GetLeftEarly() bool {
+	if x != nil {
+		return x.LeftEarly
+	}
+	return false
+}
+
+type Team struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FriendlyName string   `protobuf:"bytes,2,opt,name=friendly_name,json=friendlyName,proto3" json:"friendly_name,omitempty"`
+	Color        int32    `protobuf:"varint,3,opt,name=color,proto3" json:"color,omitempty"`
+	PlayerIds    []string `protobuf:"bytes,4,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Team) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Team) GetFriendlyName() string {
+	if x != nil {
+		return x.FriendlyName
+	}
+	return ""
+}
+
+func (x *Team) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *Team) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+type TowerDefence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxHealth  int32 `protobuf:"varint,1,opt,name=max_health,json=maxHealth,proto3" json:"max_health,omitempty"`
+	RedHealth  int32 `protobuf:"varint,2,opt,name=red_health,json=redHealth,proto3" json:"red_health,omitempty"`
+	BlueHealth int32 `protobuf:"varint,3,opt,name=blue_health,json=blueHealth,proto3" json:"blue_health,omitempty"`
+}
+
+func (x *TowerDefence) Reset() {
+	*x = TowerDefence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TowerDefence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TowerDefence) ProtoMessage() {}
+
+func (x *TowerDefence) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TowerDefence.ProtoReflect.Descriptor instead.
+func (*TowerDefence) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TowerDefence) GetMaxHealth() int32 {
+	if x != nil {
+		return x.MaxHealth
+	}
+	return 0
+}
+
+func (x *TowerDefence) GetRedHealth() int32 {
+	if x != nil {
+		return x.RedHealth
+	}
+	return 0
+}
+
+func (x *TowerDefence) GetBlueHealth() int32 {
+	if x != nil {
+		return x.BlueHealth
+	}
+	return 0
+}
+
+type BlockSumo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// scoreboard is keyed by player id
+	Scoreboard map[string]*BlockSumoEntry `protobuf:"bytes,1,rep,name=scoreboard,proto3" json:"scoreboard,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *BlockSumo) Reset() {
+	*x = BlockSumo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockSumo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSumo) ProtoMessage() {}
+
+func (x *BlockSumo) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSumo.ProtoReflect.Descriptor instead.
+func (*BlockSumo) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BlockSumo) GetScoreboard() map[string]*BlockSumoEntry {
+	if x != nil {
+		return x.Scoreboard
+	}
+	return nil
+}
+
+type BlockSumoEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RemainingLives int32 `protobuf:"varint,1,opt,name=remaining_lives,json=remainingLives,proto3" json:"remaining_lives,omitempty"`
+	Kills          int32 `protobuf:"varint,2,opt,name=kills,proto3" json:"kills,omitempty"`
+	FinalKills     int32 `protobuf:"varint,3,opt,name=final_kills,json=finalKills,proto3" json:"final_kills,omitempty"`
+}
+
+func (x *BlockSumoEntry) Reset() {
+	*x = BlockSumoEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockSumoEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSumoEntry) ProtoMessage() {}
+
+func (x *BlockSumoEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSumoEntry.ProtoReflect.Descriptor instead.
+func (*BlockSumoEntry) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BlockSumoEntry) GetRemainingLives() int32 {
+	if x != nil {
+		return x.RemainingLives
+	}
+	return 0
+}
+
+func (x *BlockSumoEntry) GetKills() int32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *BlockSumoEntry) GetFinalKills() int32 {
+	if x != nil {
+		return x.FinalKills
+	}
+	return 0
+}
+
+type GetHeadToHeadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	OtherId  string `protobuf:"bytes,2,opt,name=other_id,json=otherId,proto3" json:"other_id,omitempty"`
+	// season_id limits the record to a season, otherwise it is all-time
+	SeasonId *string `protobuf:"bytes,3,opt,name=season_id,json=seasonId,proto3,oneof" json:"season_id,omitempty"`
+}
+
+func (x *GetHeadToHeadRequest) Reset() {
+	*x = GetHeadToHeadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHeadToHeadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeadToHeadRequest) ProtoMessage() {}
+
+func (x *GetHeadToHeadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeadToHeadRequest.ProtoReflect.Descriptor instead.
+func (*GetHeadToHeadRequest) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetHeadToHeadRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetHeadToHeadRequest) GetOtherId() string {
+	if x != nil {
+		return x.OtherId
+	}
+	return ""
+}
+
+func (x *GetHeadToHeadRequest) GetSeasonId() string {
+	if x != nil && x.SeasonId != nil {
+		return *x.SeasonId
+	}
+	return ""
+}
+
+type GetHeadToHeadResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pairs []*PlayerPair `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+}
+
+func (x *GetHeadToHeadResponse) Reset() {
+	*x = GetHeadToHeadResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHeadToHeadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeadToHeadResponse) ProtoMessage() {}
+
+func (x *GetHeadToHeadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeadToHeadResponse.ProtoReflect.Descriptor instead.
+func (*GetHeadToHeadResponse) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetHeadToHeadResponse) GetPairs() []*PlayerPair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+type GetTopPlayerPairsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string         `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Kind     PlayerPairKind `protobuf:"varint,2,opt,name=kind,proto3,enum=emortal.grpc.game_tracker.PlayerPairKind" json:"kind,omitempty"`
+	// game_mode_id limits the pairs to a game mode, otherwise they are summed across every game mode
+	GameModeId *string `protobuf:"bytes,3,opt,name=game_mode_id,json=gameModeId,proto3,oneof" json:"game_mode_id,omitempty"`
+	SeasonId   *string `protobuf:"bytes,6,opt,name=season_id,json=seasonId,proto3,oneof" json:"season_id,omitempty"`
+	// min_games is the games of the kind a pair needs, 1 for rivals and 5 for partners by default
+	MinGames *int32 `protobuf:"varint,4,opt,name=min_games,json=minGames,proto3,oneof" json:"min_games,omitempty"`
+	// limit defaults to 10, up to 100
+	Limit *int32 `protobuf:"varint,5,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+}
+
+func (x *GetTopPlayerPairsRequest) Reset() {
+	*x = GetTopPlayerPairsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTopPlayerPairsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopPlayerPairsRequest) ProtoMessage() {}
+
+func (x *GetTopPlayerPairsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopPlayerPairsRequest.ProtoReflect.Descriptor instead.
+func (*GetTopPlayerPairsRequest) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTopPlayerPairsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetTopPlayerPairsRequest) GetKind() PlayerPairKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PlayerPairKind_PLAYER_PAIR_KIND_UNSPECIFIED
+}
+
+func (x *GetTopPlayerPairsRequest) GetGameModeId() string {
+	if x != nil && x.GameModeId != nil {
+		return *x.GameModeId
+	}
+	return ""
+}
+
+func (x *GetTopPlayerPairsRequest) GetSeasonId() string {
+	if x != nil && x.SeasonId != nil {
+		return *x.SeasonId
+	}
+	return ""
+}
+
+func (x *GetTopPlayerPairsRequest) GetMinGames() int32 {
+	if x != nil && x.MinGames != nil {
+		return *x.MinGames
+	}
+	return 0
+}
+
+func (x *GetTopPlayerPairsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type GetTopPlayerPairsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pairs []*PlayerPair `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+}
+
+func (x *GetTopPlayerPairsResponse) Reset() {
+	*x = GetTopPlayerPairsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTopPlayerPairsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopPlayerPairsResponse) ProtoMessage() {}
+
+func (x *GetTopPlayerPairsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopPlayerPairsResponse.ProtoReflect.Descriptor instead.
+func (*GetTopPlayerPairsResponse) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTopPlayerPairsResponse) GetPairs() []*PlayerPair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+type PlayerPair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	OtherId  string `protobuf:"bytes,2,opt,name=other_id,json=otherId,proto3" json:"other_id,omitempty"`
+	// game_mode_id is absent when the pair is summed across every game mode
+	GameModeId    *string `protobuf:"bytes,3,opt,name=game_mode_id,json=gameModeId,proto3,oneof" json:"game_mode_id,omitempty"`
+	SeasonId      *string `protobuf:"bytes,12,opt,name=season_id,json=seasonId,proto3,oneof" json:"season_id,omitempty"`
+	GamesAgainst  int64   `protobuf:"varint,4,opt,name=games_against,json=gamesAgainst,proto3" json:"games_against,omitempty"`
+	WinsAgainst   int64   `protobuf:"varint,5,opt,name=wins_against,json=winsAgainst,proto3" json:"wins_against,omitempty"`
+	LossesAgainst int64   `protobuf:"varint,6,opt,name=losses_against,json=lossesAgainst,proto3" json:"losses_against,omitempty"`
+	GamesWith     int64   `protobuf:"varint,7,opt,name=games_with,json=gamesWith,proto3" json:"games_with,omitempty"`
+	WinsWith      int64   `protobuf:"varint,8,opt,name=wins_with,json=winsWith,proto3" json:"wins_with,omitempty"`
+	LossesWith    int64   `protobuf:"varint,9,opt,name=losses_with,json=lossesWith,proto3" json:"losses_with,omitempty"`
+	// win_rate_with is absent if the players never played together
+	WinRateWith *float64               `protobuf:"fixed64,10,opt,name=win_rate_with,json=winRateWith,proto3,oneof" json:"win_rate_with,omitempty"`
+	LastPlayed  *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_played,json=lastPlayed,proto3" json:"last_played,omitempty"`
+}
+
+func (x *PlayerPair) Reset() {
+	*x = PlayerPair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerPair) ProtoMessage() {}
+
+func (x *PlayerPair) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerPair.ProtoReflect.Descriptor instead.
+func (*PlayerPair) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PlayerPair) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerPair) GetOtherId() string {
+	if x != nil {
+		return x.OtherId
+	}
+	return ""
+}
+
+func (x *PlayerPair) GetGameModeId() string {
+	if x != nil && x.GameModeId != nil {
+		return *x.GameModeId
+	}
+	return ""
+}
+
+func (x *PlayerPair) GetSeasonId() string {
+	if x != nil && x.SeasonId != nil {
+		return *x.SeasonId
+	}
+	return ""
+}
+
+func (x *PlayerPair) GetGamesAgainst() int64 {
+	if x != nil {
+		return x.GamesAgainst
+	}
+	return 0
+}
+
+func (x *PlayerPair) GetWinsAgainst() int64 {
+	if x != nil {
+		return x.WinsAgainst
+	}
+	return 0
+}
+
+func (x *PlayerPair) GetLossesAgainst() int64 {
+	if x != nil {
+		return x.LossesAgainst
+	}
+	return 0
+}
+
+func (x *PlayerPair) GetGamesWith() int64 {
+	if x != nil {
+		return x.GamesWith
+	}
+	return 0
+}
+
+func (x *PlayerPair) GetWinsWith() int64 {
+	if x != nil {
+		return x.WinsWith
+	}
+	return 0
+}
+
+func (x *PlayerPair) GetLossesWith() int64 {
+	if x != nil {
+		return x.LossesWith
+	}
+	return 0
+}
+
+func (x *PlayerPair) GetWinRateWith() float64 {
+	if x != nil && x.WinRateWith != nil {
+		return *x.WinRateWith
+	}
+	return 0
+}
+
+func (x *PlayerPair) GetLastPlayed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastPlayed
+	}
+	return nil
+}
+
+type GetGameSummaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+}
+
+func (x *GetGameSummaryRequest) Reset() {
+	*x = GetGameSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGameSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameSummaryRequest) ProtoMessage() {}
+
+func (x *GetGameSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetGameSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetGameSummaryRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type GetGameSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Summary *GameSummary `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	// markdown is the summary as Discord flavoured Markdown
+	Markdown string `protobuf:"bytes,2,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	// discord_payload is the JSON body of a Discord webhook execution, which can be posted as is
+	DiscordPayload string `protobuf:"bytes,3,opt,name=discord_payload,json=discordPayload,proto3" json:"discord_payload,omitempty"`
+}
+
+func (x *GetGameSummaryResponse) Reset() {
+	*x = GetGameSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGameSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameSummaryResponse) ProtoMessage() {}
+
+func (x *GetGameSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetGameSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetGameSummaryResponse) GetSummary() *GameSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *GetGameSummaryResponse) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+func (x *GetGameSummaryResponse) GetDiscordPayload() string {
+	if x != nil {
+		return x.DiscordPayload
+	}
+	return ""
+}
+
+type GameSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId     string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	GameModeId string                 `protobuf:"bytes,2,opt,name=game_mode_id,json=gameModeId,proto3" json:"game_mode_id,omitempty"`
+	MapId      *string                `protobuf:"bytes,3,opt,name=map_id,json=mapId,proto3,oneof" json:"map_id,omitempty"`
+	ServerId   string                 `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	EndTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// duration_millis is absent if the start time of the game is unknown
+	DurationMillis *int64 `protobuf:"varint,6,opt,name=duration_millis,json=durationMillis,proto3,oneof" json:"duration_millis,omitempty"`
+	PlayerCount    int32  `protobuf:"varint,7,opt,name=player_count,json=playerCount,proto3" json:"player_count,omitempty"`
+	// winners are the usernames of the winning players
+	Winners []string `protobuf:"bytes,8,rep,name=winners,proto3" json:"winners,omitempty"`
+	// winning_team is the friendly name of the winning team, absent if there isn't a single winning team
+	WinningTeam      *string `protobuf:"bytes,9,opt,name=winning_team,json=winningTeam,proto3,oneof" json:"winning_team,omitempty"`
+	WinningTeamColor *int32  `protobuf:"varint,10,opt,name=winning_team_color,json=winningTeamColor,proto3,oneof" json:"winning_team_color,omitempty"`
+	// top_killers are the Block Sumo players with the most kills, most first
+	TopKillers   []*GameSummaryKiller `protobuf:"bytes,11,rep,name=top_killers,json=topKillers,proto3" json:"top_killers,omitempty"`
+	TowerDefence *TowerDefence        `protobuf:"bytes,12,opt,name=tower_defence,json=towerDefence,proto3,oneof" json:"tower_defence,omitempty"`
+}
+
+func (x *GameSummary) Reset() {
+	*x = GameSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameSummary) ProtoMessage() {}
+
+func (x *GameSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameSummary.ProtoReflect.Descriptor instead.
+func (*GameSummary) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GameSummary) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *GameSummary) GetGameModeId() string {
+	if x != nil {
+		return x.GameModeId
+	}
+	return ""
+}
+
+func (x *GameSummary) GetMapId() string {
+	if x != nil && x.MapId != nil {
+		return *x.MapId
+	}
+	return ""
+}
+
+func (x *GameSummary) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *GameSummary) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GameSummary) GetDurationMillis() int64 {
+	if x != nil && x.DurationMillis != nil {
+		return *x.DurationMillis
+	}
+	return 0
+}
+
+func (x *GameSummary) GetPlayerCount() int32 {
+	if x != nil {
+		return x.PlayerCount
+	}
+	return 0
+}
+
+func (x *GameSummary) GetWinners() []string {
+	if x != nil {
+		return x.Winners
+	}
+	return nil
+}
+
+func (x *GameSummary) GetWinningTeam() string {
+	if x != nil && x.WinningTeam != nil {
+		return *x.WinningTeam
+	}
+	return ""
+}
+
+func (x *GameSummary) GetWinningTeamColor() int32 {
+	if x != nil && x.WinningTeamColor != nil {
+		return *x.WinningTeamColor
+	}
+	return 0
+}
+
+func (x *GameSummary) GetTopKillers() []*GameSummaryKiller {
+	if x != nil {
+		return x.TopKillers
+	}
+	return nil
+}
+
+func (x *GameSummary) GetTowerDefence() *TowerDefence {
+	if x != nil {
+		return x.TowerDefence
+	}
+	return nil
+}
+
+type GameSummaryKiller struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username   string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Kills      int32  `protobuf:"varint,2,opt,name=kills,proto3" json:"kills,omitempty"`
+	FinalKills int32  `protobuf:"varint,3,opt,name=final_kills,json=finalKills,proto3" json:"final_kills,omitempty"`
+}
+
+func (x *GameSummaryKiller) Reset() {
+	*x = GameSummaryKiller{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_tracker_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameSummaryKiller) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameSummaryKiller) ProtoMessage() {}
+
+func (x *GameSummaryKiller) ProtoReflect() protoreflect.Message {
+	mi := &file_game_tracker_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameSummaryKiller.ProtoReflect.Descriptor instead.
+func (*GameSummaryKiller) Descriptor() ([]byte, []int) {
+	return file_game_tracker_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GameSummaryKiller) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GameSummaryKiller) GetKills() int32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *GameSummaryKiller) GetFinalKills() int32 {
+	if x != nil {
+		return x.FinalKills
+	}
+	return 0
+}
+
+var File_game_tracker_service_proto protoreflect.FileDescriptor
+
+var file_game_tracker_service_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x65, 0x6d,
+	0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x54, 0x0a, 0x12, 0x45, 0x72, 0x61, 0x73,
+	0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x79, 0x22, 0x8c,
+	0x02, 0x0a, 0x13, 0x45, 0x72, 0x61, 0x73, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x75, 0x64, 0x69, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x75, 0x64, 0x69, 0x74, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x5f, 0x67, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x69, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x14, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74,
+	0x62, 0x6f, 0x78, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x78, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x22, 0x44, 0x0a,
+	0x13, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x7a, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x7a, 0x69, 0x70, 0x22, 0x2c, 0x0a, 0x14, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x22, 0xaf, 0x01, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x69, 0x76, 0x65, 0x47,
+	0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0c, 0x67,
+	0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x22, 0xed, 0x01, 0x0a, 0x0d, 0x4c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e,
+	0x4c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x6c, 0x69, 0x76, 0x65, 0x5f,
+	0x67, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x6d, 0x6f,
+	0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x48,
+	0x00, 0x52, 0x08, 0x6c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x67,
+	0x61, 0x6d, 0x65, 0x22, 0xc0, 0x04, 0x0a, 0x08, 0x4c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x20, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x06, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x6d, 0x61, 0x70, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3e, 0x0a, 0x0a, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x01, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x3d, 0x0a, 0x0c, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c,
+	0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x07, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x6d,
+	0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x51,
+	0x0a, 0x0d, 0x74, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x2e, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x02,
+	0x52, 0x0c, 0x74, 0x6f, 0x77, 0x65, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x48, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x75, 0x6d, 0x6f, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x6f, 0x48, 0x03, 0x52, 0x09, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x6f, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f,
+	0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x74, 0x6f, 0x77, 0x65, 0x72, 0x5f,
+	0x64, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x73, 0x75, 0x6d, 0x6f, 0x22, 0xb2, 0x06, 0x0a, 0x0c, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x69, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67,
+	0x61, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x06, 0x6d, 0x61, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x6d, 0x61, 0x70,
+	0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x3e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x48, 0x01, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x12, 0x3b, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12,
+	0x4e, 0x0a, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x35, 0x0a, 0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x54, 0x65, 0x61, 0x6d, 0x52,
+	0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x6e,
+	0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x73, 0x12, 0x2b, 0x0a, 0x0f, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x65,
+	0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0d, 0x77,
+	0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x54, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x51, 0x0a, 0x0d, 0x74, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x2e, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x48,
+	0x03, 0x52, 0x0c, 0x74, 0x6f, 0x77, 0x65, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x48, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x75, 0x6d, 0x6f,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x6f, 0x48, 0x04, 0x52, 0x09,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x6f, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x5f, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x74,
+	0x6f, 0x77, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x0d, 0x0a, 0x0b,
+	0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x75, 0x6d, 0x6f, 0x22, 0x34, 0x0a, 0x06, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x9e, 0x02, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x0f,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x42, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x65, 0x61, 0x76, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x13, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x69, 0x6e, 0x5f,
+	0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x65, 0x61, 0x72, 0x6c,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6c, 0x65, 0x66, 0x74, 0x45, 0x61, 0x72,
+	0x6c, 0x79, 0x22, 0x70, 0x0a, 0x04, 0x54, 0x65, 0x61, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72,
+	0x69, 0x65, 0x6e, 0x64, 0x6c, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x6c, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x64, 0x73, 0x22, 0x6d, 0x0a, 0x0c, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x44, 0x65, 0x66,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x65, 0x64, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x6c, 0x75, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x62, 0x6c, 0x75, 0x65, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x22, 0xcb, 0x01, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x6d,
+	0x6f, 0x12, 0x54, 0x0a, 0x0a, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x1a, 0x68, 0x0a, 0x0f, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3f, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65, 0x6d,
+	0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x6d,
+	0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x70, 0x0a, 0x0e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x6d, 0x6f, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x5f, 0x6c, 0x69, 0x76, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65,
+	0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x76, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6b, 0x69, 0x6c,
+	0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x6b, 0x69, 0x6c, 0x6c,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x4b, 0x69,
+	0x6c, 0x6c, 0x73, 0x22, 0x7e, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x54, 0x6f,
+	0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x74, 0x68, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x74, 0x68, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x09, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x22, 0x54, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x54, 0x6f,
+	0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x05,
+	0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x6d,
+	0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x61,
+	0x69, 0x72, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0xb3, 0x02, 0x0a, 0x18, 0x47, 0x65,
+	0x74, 0x54, 0x6f, 0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x3d, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x29, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x25, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x67, 0x61, 0x6d, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x73, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08,
+	0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x6d,
+	0x69, 0x6e, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02,
+	0x52, 0x08, 0x6d, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x73, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6d, 0x69, 0x6e, 0x5f,
+	0x67, 0x61, 0x6d, 0x65, 0x73, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
+	0x58, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50,
+	0x61, 0x69, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x05,
+	0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x6d,
+	0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x61,
+	0x69, 0x72, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0xf0, 0x03, 0x0a, 0x0a, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x25, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x4d, 0x6f,
+	0x64, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x73, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x73, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0d, 0x67, 0x61, 0x6d,
+	0x65, 0x73, 0x5f, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x73, 0x5f, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x77, 0x69, 0x6e, 0x73, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x5f, 0x61, 0x67, 0x61, 0x69,
+	0x6e, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6c, 0x6f, 0x73, 0x73, 0x65,
+	0x73, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x61, 0x6d, 0x65,
+	0x73, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x67, 0x61,
+	0x6d, 0x65, 0x73, 0x57, 0x69, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69, 0x6e, 0x73, 0x5f,
+	0x77, 0x69, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x77, 0x69, 0x6e, 0x73,
+	0x57, 0x69, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x5f, 0x77,
+	0x69, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x6f, 0x73, 0x73, 0x65,
+	0x73, 0x57, 0x69, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x0d, 0x77, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74,
+	0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x0b,
+	0x77, 0x69, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x57, 0x69, 0x74, 0x68, 0x88, 0x01, 0x01, 0x12, 0x3b,
+	0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x42, 0x0f, 0x0a, 0x0d, 0x5f,
+	0x67, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x77,
+	0x69, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x22, 0x30, 0x0a, 0x15,
+	0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x9f,
+	0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x07, 0x73, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x65, 0x6d, 0x6f,
+	0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d,
+	0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x63, 0x6f,
+	0x72, 0x64, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x22, 0xf9, 0x04, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x67, 0x61, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x06, 0x6d,
+	0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x6d,
+	0x61, 0x70, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x0f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x0e, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x77,
+	0x69, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x5f, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0b,
+	0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x54, 0x65, 0x61, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x31,
+	0x0a, 0x12, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x10, 0x77, 0x69,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x54, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x88, 0x01,
+	0x01, 0x12, 0x4d, 0x0a, 0x0b, 0x74, 0x6f, 0x70, 0x5f, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x69,
+	0x6c, 0x6c, 0x65, 0x72, 0x52, 0x0a, 0x74, 0x6f, 0x70, 0x4b, 0x69, 0x6c, 0x6c, 0x65, 0x72, 0x73,
+	0x12, 0x51, 0x0a, 0x0d, 0x74, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x66, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61,
+	0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x77, 0x65, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65,
+	0x48, 0x04, 0x52, 0x0c, 0x74, 0x6f, 0x77, 0x65, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65,
+	0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x42, 0x12,
+	0x0a, 0x10, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x74,
+	0x65, 0x61, 0x6d, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
+	0x74, 0x65, 0x61, 0x6d, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x74,
+	0x6f, 0x77, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x66, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x66, 0x0a, 0x11,
+	0x47, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x69, 0x6c, 0x6c, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6b, 0x69,
+	0x6c, 0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x6b, 0x69, 0x6c,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x4b,
+	0x69, 0x6c, 0x6c, 0x73, 0x2a, 0xc3, 0x01, 0x0a, 0x11, 0x4c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x4c, 0x49,
+	0x56, 0x45, 0x5f, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x49, 0x56, 0x45, 0x5f, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f,
+	0x54, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x4c, 0x49, 0x56, 0x45, 0x5f, 0x47, 0x41, 0x4d, 0x45,
+	0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x4c, 0x49, 0x56, 0x45, 0x5f, 0x47, 0x41,
+	0x4d, 0x45, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50,
+	0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x49, 0x56, 0x45, 0x5f,
+	0x47, 0x41, 0x4d, 0x45, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x6e, 0x0a, 0x0e, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x1c,
+	0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x50, 0x41, 0x49, 0x52, 0x5f, 0x4b, 0x49, 0x4e, 0x44,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b,
+	0x0a, 0x17, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x50, 0x41, 0x49, 0x52, 0x5f, 0x4b, 0x49,
+	0x4e, 0x44, 0x5f, 0x52, 0x49, 0x56, 0x41, 0x4c, 0x53, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x50,
+	0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x50, 0x41, 0x49, 0x52, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f,
+	0x50, 0x41, 0x52, 0x54, 0x4e, 0x45, 0x52, 0x53, 0x10, 0x02, 0x32, 0xf3, 0x01, 0x0a, 0x10, 0x47,
+	0x61, 0x6d, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12,
+	0x6c, 0x0a, 0x0b, 0x45, 0x72, 0x61, 0x73, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x2d,
+	0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x65,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e,
+	0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a,
+	0x0c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x2e, 0x2e,
+	0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e,
+	0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x32, 0xed, 0x03, 0x0a, 0x10, 0x47, 0x61, 0x6d, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x6e, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x69,
+	0x76, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x30, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61,
+	0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x65, 0x6d, 0x6f, 0x72,
+	0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72,
+	0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x72, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64,
+	0x54, 0x6f, 0x48, 0x65, 0x61, 0x64, 0x12, 0x2f, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x54, 0x6f, 0x48, 0x65, 0x61, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61,
+	0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x54, 0x6f, 0x48, 0x65, 0x61,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7e, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x54, 0x6f, 0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x33,
+	0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f,
+	0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x47, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x30, 0x2e, 0x65, 0x6d,
+	0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e,
+	0x65, 0x6d, 0x6f, 0x72, 0x74, 0x61, 0x6c, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d,
+	0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x61, 0x6d, 0x65, 0x2d, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x2f, 0x67, 0x61, 0x6d, 0x65, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_game_tracker_service_proto_rawDescOnce sync.Once
+	file_game_tracker_service_proto_rawDescData = file_game_tracker_service_proto_rawDesc
+)
+
+func file_game_tracker_service_proto_rawDescGZIP() []byte {
+	file_game_tracker_service_proto_rawDescOnce.Do(func() {
+		file_game_tracker_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_game_tracker_service_proto_rawDescData)
+	})
+	return file_game_tracker_service_proto_rawDescData
+}
+
+var file_game_tracker_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_game_tracker_service_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_game_tracker_service_proto_goTypes = []any{
+	(LiveGameEventType)(0),            // 0: emortal.grpc.game_tracker.LiveGameEventType
+	(PlayerPairKind)(0),               // 1: emortal.grpc.game_tracker.PlayerPairKind
+	(*ErasePlayerRequest)(nil),        // 2: emortal.grpc.game_tracker.ErasePlayerRequest
+	(*ErasePlayerResponse)(nil),       // 3: emortal.grpc.game_tracker.ErasePlayerResponse
+	(*ExportPlayerRequest)(nil),       // 4: emortal.grpc.game_tracker.ExportPlayerRequest
+	(*ExportPlayerResponse)(nil),      // 5: emortal.grpc.game_tracker.ExportPlayerResponse
+	(*WatchLiveGamesRequest)(nil),     // 6: emortal.grpc.game_tracker.WatchLiveGamesRequest
+	(*LiveGameEvent)(nil),             // 7: emortal.grpc.game_tracker.LiveGameEvent
+	(*LiveGame)(nil),                  // 8: emortal.grpc.game_tracker.LiveGame
+	(*HistoricGame)(nil),              // 9: emortal.grpc.game_tracker.HistoricGame
+	(*Player)(nil),                    // 10: emortal.grpc.game_tracker.Player
+	(*Participation)(nil),             // 11: emortal.grpc.game_tracker.Participation
+	(*Team)(nil),                      // 12: emortal.grpc.game_tracker.Team
+	(*TowerDefence)(nil),              // 13: emortal.grpc.game_tracker.TowerDefence
+	(*BlockSumo)(nil),                 // 14: emortal.grpc.game_tracker.BlockSumo
+	(*BlockSumoEntry)(nil),            // 15: emortal.grpc.game_tracker.BlockSumoEntry
+	(*GetHeadToHeadRequest)(nil),      // 16: emortal.grpc.game_tracker.GetHeadToHeadRequest
+	(*GetHeadToHeadResponse)(nil),     // 17: emortal.grpc.game_tracker.GetHeadToHeadResponse
+	(*GetTopPlayerPairsRequest)(nil),  // 18: emortal.grpc.game_tracker.GetTopPlayerPairsRequest
+	(*GetTopPlayerPairsResponse)(nil), // 19: emortal.grpc.game_tracker.GetTopPlayerPairsResponse
+	(*PlayerPair)(nil),                // 20: emortal.grpc.game_tracker.PlayerPair
+	(*GetGameSummaryRequest)(nil),     // 21: emortal.grpc.game_tracker.GetGameSummaryRequest
+	(*GetGameSummaryResponse)(nil),    // 22: emortal.grpc.game_tracker.GetGameSummaryResponse
+	(*GameSummary)(nil),               // 23: emortal.grpc.game_tracker.GameSummary
+	(*GameSummaryKiller)(nil),         // 24: emortal.grpc.game_tracker.GameSummaryKiller
+	nil,                               // 25: emortal.grpc.game_tracker.BlockSumo.ScoreboardEntry
+	(*timestamppb.Timestamp)(nil),     // 26: google.protobuf.Timestamp
+}
+var file_game_tracker_service_proto_depIdxs = []int32{
+	0,  // 0: emortal.grpc.game_tracker.LiveGameEvent.type:type_name -> emortal.grpc.game_tracker.LiveGameEventType
+	8,  // 1: emortal.grpc.game_tracker.LiveGameEvent.live_game:type_name -> emortal.grpc.game_tracker.LiveGame
+	9,  // 2: emortal.grpc.game_tracker.LiveGameEvent.historic_game:type_name -> emortal.grpc.game_tracker.HistoricGame
+	26, // 3: emortal.grpc.game_tracker.LiveGame.start_time:type_name -> google.protobuf.Timestamp
+	26, // 4: emortal.grpc.game_tracker.LiveGame.last_updated:type_name -> google.protobuf.Timestamp
+	10, // 5: emortal.grpc.game_tracker.LiveGame.players:type_name -> emortal.grpc.game_tracker.Player
+	12, // 6: emortal.grpc.game_tracker.LiveGame.teams:type_name -> emortal.grpc.game_tracker.Team
+	13, // 7: emortal.grpc.game_tracker.LiveGame.tower_defence:type_name -> emortal.grpc.game_tracker.TowerDefence
+	14, // 8: emortal.grpc.game_tracker.LiveGame.block_sumo:type_name -> emortal.grpc.game_tracker.BlockSumo
+	26, // 9: emortal.grpc.game_tracker.HistoricGame.start_time:type_name -> google.protobuf.Timestamp
+	26, // 10: emortal.grpc.game_tracker.HistoricGame.end_time:type_name -> google.protobuf.Timestamp
+	10, // 11: emortal.grpc.game_tracker.HistoricGame.players:type_name -> emortal.grpc.game_tracker.Player
+	11, // 12: emortal.grpc.game_tracker.HistoricGame.participation:type_name -> emortal.grpc.game_tracker.Participation
+	12, // 13: emortal.grpc.game_tracker.HistoricGame.teams:type_name -> emortal.grpc.game_tracker.Team
+	13, // 14: emortal.grpc.game_tracker.HistoricGame.tower_defence:type_name -> emortal.grpc.game_tracker.TowerDefence
+	14, // 15: emortal.grpc.game_tracker.HistoricGame.block_sumo:type_name -> emortal.grpc.game_tracker.BlockSumo
+	26, // 16: emortal.grpc.game_tracker.Participation.first_join_time:type_name -> google.protobuf.Timestamp
+	26, // 17: emortal.grpc.game_tracker.Participation.last_leave_time:type_name -> google.protobuf.Timestamp
+	25, // 18: emortal.grpc.game_tracker.BlockSumo.scoreboard:type_name -> emortal.grpc.game_tracker.BlockSumo.ScoreboardEntry
+	20, // 19: emortal.grpc.game_tracker.GetHeadToHeadResponse.pairs:type_name -> emortal.grpc.game_tracker.PlayerPair
+	1,  // 20: emortal.grpc.game_tracker.GetTopPlayerPairsRequest.kind:type_name -> emortal.grpc.game_tracker.PlayerPairKind
+	20, // 21: emortal.grpc.game_tracker.GetTopPlayerPairsResponse.pairs:type_name -> emortal.grpc.game_tracker.PlayerPair
+	26, // 22: emortal.grpc.game_tracker.PlayerPair.last_played:type_name -> google.protobuf.Timestamp
+	23, // 23: emortal.grpc.game_tracker.GetGameSummaryResponse.summary:type_name -> emortal.grpc.game_tracker.GameSummary
+	26, // 24: emortal.grpc.game_tracker.GameSummary.end_time:type_name -> google.protobuf.Timestamp
+	24, // 25: emortal.grpc.game_tracker.GameSummary.top_killers:type_name -> emortal.grpc.game_tracker.GameSummaryKiller
+	13, // 26: emortal.grpc.game_tracker.GameSummary.tower_defence:type_name -> emortal.grpc.game_tracker.TowerDefence
+	15, // 27: emortal.grpc.game_tracker.BlockSumo.ScoreboardEntry.value:type_name -> emortal.grpc.game_tracker.BlockSumoEntry
+	2,  // 28: emortal.grpc.game_tracker.GameTrackerAdmin.ErasePlayer:input_type -> emortal.grpc.game_tracker.ErasePlayerRequest
+	4,  // 29: emortal.grpc.game_tracker.GameTrackerAdmin.ExportPlayer:input_type -> emortal.grpc.game_tracker.ExportPlayerRequest
+	6,  // 30: emortal.grpc.game_tracker.GameTrackerQuery.WatchLiveGames:input_type -> emortal.grpc.game_tracker.WatchLiveGamesRequest
+	16, // 31: emortal.grpc.game_tracker.GameTrackerQuery.GetHeadToHead:input_type -> emortal.grpc.game_tracker.GetHeadToHeadRequest
+	18, // 32: emortal.grpc.game_tracker.GameTrackerQuery.GetTopPlayerPairs:input_type -> emortal.grpc.game_tracker.GetTopPlayerPairsRequest
+	21, // 33: emortal.grpc.game_tracker.GameTrackerQuery.GetGameSummary:input_type -> emortal.grpc.game_tracker.GetGameSummaryRequest
+	3,  // 34: emortal.grpc.game_tracker.GameTrackerAdmin.ErasePlayer:output_type -> emortal.grpc.game_tracker.ErasePlayerResponse
+	5,  // 35: emortal.grpc.game_tracker.GameTrackerAdmin.ExportPlayer:output_type -> emortal.grpc.game_tracker.ExportPlayerResponse
+	7,  // 36: emortal.grpc.game_tracker.GameTrackerQuery.WatchLiveGames:output_type -> emortal.grpc.game_tracker.LiveGameEvent
+	17, // 37: emortal.grpc.game_tracker.GameTrackerQuery.GetHeadToHead:output_type -> emortal.grpc.game_tracker.GetHeadToHeadResponse
+	19, // 38: emortal.grpc.game_tracker.GameTrackerQuery.GetTopPlayerPairs:output_type -> emortal.grpc.game_tracker.GetTopPlayerPairsResponse
+	22, // 39: emortal.grpc.game_tracker.GameTrackerQuery.GetGameSummary:output_type -> emortal.grpc.game_tracker.GetGameSummaryResponse
+	34, // [34:40] is the sub-list for method output_type
+	28, // [28:34] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_game_tracker_service_proto_init() }
+func file_game_tracker_service_proto_init() {
+	if File_game_tracker_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_game_tracker_service_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ErasePlayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ErasePlayerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ExportPlayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ExportPlayerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchLiveGamesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*LiveGameEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*LiveGame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*HistoricGame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Player); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Participation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*Team); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*TowerDefence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockSumo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockSumoEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*GetHeadToHeadRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*GetHeadToHeadResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GetTopPlayerPairsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*GetTopPlayerPairsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*PlayerPair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*GetGameSummaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*GetGameSummaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*GameSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_tracker_service_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*GameSummaryKiller); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_game_tracker_service_proto_msgTypes[4].OneofWrappers = []any{}
+	file_game_tracker_service_proto_msgTypes[5].OneofWrappers = []any{
+		(*LiveGameEvent_LiveGame)(nil),
+		(*LiveGameEvent_HistoricGame)(nil),
+	}
+	file_game_tracker_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_game_tracker_service_proto_msgTypes[7].OneofWrappers = []any{}
+	file_game_tracker_service_proto_msgTypes[14].OneofWrappers = []any{}
+	file_game_tracker_service_proto_msgTypes[16].OneofWrappers = []any{}
+	file_game_tracker_service_proto_msgTypes[18].OneofWrappers = []any{}
+	file_game_tracker_service_proto_msgTypes[21].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_game_tracker_service_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_game_tracker_service_proto_goTypes,
+		DependencyIndexes: file_game_tracker_service_proto_depIdxs,
+		EnumInfos:         file_game_tracker_service_proto_enumTypes,
+		MessageInfos:      file_game_tracker_service_proto_msgTypes,
+	}.Build()
+	File_game_tracker_service_proto = out.File
+	file_game_tracker_service_proto_rawDesc = nil
+	file_game_tracker_service_proto_goTypes = nil
+	file_game_tracker_service_proto_depIdxs = nil
+}
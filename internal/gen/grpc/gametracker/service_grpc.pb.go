@@ -0,0 +1,421 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: game_tracker/service.proto
+
+package gametracker
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	GameTrackerAdmin_ErasePlayer_FullMethodName  = "/emortal.grpc.game_tracker.GameTrackerAdmin/ErasePlayer"
+	GameTrackerAdmin_ExportPlayer_FullMethodName = "/emortal.grpc.game_tracker.GameTrackerAdmin/ExportPlayer"
+)
+
+// GameTrackerAdminClient is the client API for GameTrackerAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GameTrackerAdminClient interface {
+	// ErasePlayer pseudonymises the player across every stored game and deletes the data derived from them (GDPR erasure)
+	ErasePlayer(ctx context.Context, in *ErasePlayerRequest, opts ...grpc.CallOption) (*ErasePlayerResponse, error)
+	// ExportPlayer streams everything stored about the player (GDPR access request) in chunks of the same JSON document
+	// the export-player command writes. Other players are replaced by pseudonyms.
+	ExportPlayer(ctx context.Context, in *ExportPlayerRequest, opts ...grpc.CallOption) (GameTrackerAdmin_ExportPlayerClient, error)
+}
+
+type gameTrackerAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGameTrackerAdminClient(cc grpc.ClientConnInterface) GameTrackerAdminClient {
+	return &gameTrackerAdminClient{cc}
+}
+
+func (c *gameTrackerAdminClient) ErasePlayer(ctx context.Context, in *ErasePlayerRequest, opts ...grpc.CallOption) (*ErasePlayerResponse, error) {
+	out := new(ErasePlayerResponse)
+	err := c.cc.Invoke(ctx, GameTrackerAdmin_ErasePlayer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameTrackerAdminClient) ExportPlayer(ctx context.Context, in *ExportPlayerRequest, opts ...grpc.CallOption) (GameTrackerAdmin_ExportPlayerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GameTrackerAdmin_ServiceDesc.Streams[0], GameTrackerAdmin_ExportPlayer_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gameTrackerAdminExportPlayerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GameTrackerAdmin_ExportPlayerClient interface {
+	Recv() (*ExportPlayerResponse, error)
+	grpc.ClientStream
+}
+
+type gameTrackerAdminExportPlayerClient struct {
+	grpc.ClientStream
+}
+
+func (x *gameTrackerAdminExportPlayerClient) Recv() (*ExportPlayerResponse, error) {
+	m := new(ExportPlayerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GameTrackerAdminServer is the server API for GameTrackerAdmin service.
+// All implementations must embed UnimplementedGameTrackerAdminServer
+// for forward compatibility
+type GameTrackerAdminServer interface {
+	// ErasePlayer pseudonymises the player across every stored game and deletes the data derived from them (GDPR erasure)
+	ErasePlayer(context.Context, *ErasePlayerRequest) (*ErasePlayerResponse, error)
+	// ExportPlayer streams everything stored about the player (GDPR access request) in chunks of the same JSON document
+	// the export-player command writes. Other players are replaced by pseudonyms.
+	ExportPlayer(*ExportPlayerRequest, GameTrackerAdmin_ExportPlayerServer) error
+	mustEmbedUnimplementedGameTrackerAdminServer()
+}
+
+// UnimplementedGameTrackerAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedGameTrackerAdminServer struct {
+}
+
+func (UnimplementedGameTrackerAdminServer) ErasePlayer(context.Context, *ErasePlayerRequest) (*ErasePlayerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ErasePlayer not implemented")
+}
+func (UnimplementedGameTrackerAdminServer) ExportPlayer(*ExportPlayerRequest, GameTrackerAdmin_ExportPlayerServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportPlayer not implemented")
+}
+func (UnimplementedGameTrackerAdminServer) mustEmbedUnimplementedGameTrackerAdminServer() {}
+
+// UnsafeGameTrackerAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GameTrackerAdminServer will
+// result in compilation errors.
+type UnsafeGameTrackerAdminServer interface {
+	mustEmbedUnimplementedGameTrackerAdminServer()
+}
+
+func RegisterGameTrackerAdminServer(s grpc.ServiceRegistrar, srv GameTrackerAdminServer) {
+	s.RegisterService(&GameTrackerAdmin_ServiceDesc, srv)
+}
+
+func _GameTrackerAdmin_ErasePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ErasePlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameTrackerAdminServer).ErasePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameTrackerAdmin_ErasePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameTrackerAdminServer).ErasePlayer(ctx, req.(*ErasePlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameTrackerAdmin_ExportPlayer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportPlayerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameTrackerAdminServer).ExportPlayer(m, &gameTrackerAdminExportPlayerServer{stream})
+}
+
+type GameTrackerAdmin_ExportPlayerServer interface {
+	Send(*ExportPlayerResponse) error
+	grpc.ServerStream
+}
+
+type gameTrackerAdminExportPlayerServer struct {
+	grpc.ServerStream
+}
+
+func (x *gameTrackerAdminExportPlayerServer) Send(m *ExportPlayerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// GameTrackerAdmin_ServiceDesc is the grpc.ServiceDesc for GameTrackerAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GameTrackerAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "emortal.grpc.game_tracker.GameTrackerAdmin",
+	HandlerType: (*GameTrackerAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ErasePlayer",
+			Handler:    _GameTrackerAdmin_ErasePlayer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportPlayer",
+			Handler:       _GameTrackerAdmin_ExportPlayer_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "game_tracker/service.proto",
+}
+
+const (
+	GameTrackerQuery_WatchLiveGames_FullMethodName    = "/emortal.grpc.game_tracker.GameTrackerQuery/WatchLiveGames"
+	GameTrackerQuery_GetHeadToHead_FullMethodName     = "/emortal.grpc.game_tracker.GameTrackerQuery/GetHeadToHead"
+	GameTrackerQuery_GetTopPlayerPairs_FullMethodName = "/emortal.grpc.game_tracker.GameTrackerQuery/GetTopPlayerPairs"
+	GameTrackerQuery_GetGameSummary_FullMethodName    = "/emortal.grpc.game_tracker.GameTrackerQuery/GetGameSummary"
+)
+
+// GameTrackerQueryClient is the client API for GameTrackerQuery service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GameTrackerQueryClient interface {
+	// WatchLiveGames sends the matching live games, then every time one of them starts, updates or finishes.
+	// The stream is aborted if the client falls too far behind, and should be reopened for a new snapshot.
+	WatchLiveGames(ctx context.Context, in *WatchLiveGamesRequest, opts ...grpc.CallOption) (GameTrackerQuery_WatchLiveGamesClient, error)
+	// GetHeadToHead returns a player's record against and alongside another player in each game mode they played together
+	GetHeadToHead(ctx context.Context, in *GetHeadToHeadRequest, opts ...grpc.CallOption) (*GetHeadToHeadResponse, error)
+	// GetTopPlayerPairs returns a player's rivals, the players most played against,
+	// or partners, the teammates with the highest win rate together
+	GetTopPlayerPairs(ctx context.Context, in *GetTopPlayerPairsRequest, opts ...grpc.CallOption) (*GetTopPlayerPairsResponse, error)
+	// GetGameSummary returns the result of a finished game in the forms posted to community channels
+	GetGameSummary(ctx context.Context, in *GetGameSummaryRequest, opts ...grpc.CallOption) (*GetGameSummaryResponse, error)
+}
+
+type gameTrackerQueryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGameTrackerQueryClient(cc grpc.ClientConnInterface) GameTrackerQueryClient {
+	return &gameTrackerQueryClient{cc}
+}
+
+func (c *gameTrackerQueryClient) WatchLiveGames(ctx context.Context, in *WatchLiveGamesRequest, opts ...grpc.CallOption) (GameTrackerQuery_WatchLiveGamesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GameTrackerQuery_ServiceDesc.Streams[0], GameTrackerQuery_WatchLiveGames_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gameTrackerQueryWatchLiveGamesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GameTrackerQuery_WatchLiveGamesClient interface {
+	Recv() (*LiveGameEvent, error)
+	grpc.ClientStream
+}
+
+type gameTrackerQueryWatchLiveGamesClient struct {
+	grpc.ClientStream
+}
+
+func (x *gameTrackerQueryWatchLiveGamesClient) Recv() (*LiveGameEvent, error) {
+	m := new(LiveGameEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gameTrackerQueryClient) GetHeadToHead(ctx context.Context, in *GetHeadToHeadRequest, opts ...grpc.CallOption) (*GetHeadToHeadResponse, error) {
+	out := new(GetHeadToHeadResponse)
+	err := c.cc.Invoke(ctx, GameTrackerQuery_GetHeadToHead_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameTrackerQueryClient) GetTopPlayerPairs(ctx context.Context, in *GetTopPlayerPairsRequest, opts ...grpc.CallOption) (*GetTopPlayerPairsResponse, error) {
+	out := new(GetTopPlayerPairsResponse)
+	err := c.cc.Invoke(ctx, GameTrackerQuery_GetTopPlayerPairs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameTrackerQueryClient) GetGameSummary(ctx context.Context, in *GetGameSummaryRequest, opts ...grpc.CallOption) (*GetGameSummaryResponse, error) {
+	out := new(GetGameSummaryResponse)
+	err := c.cc.Invoke(ctx, GameTrackerQuery_GetGameSummary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GameTrackerQueryServer is the server API for GameTrackerQuery service.
+// All implementations must embed UnimplementedGameTrackerQueryServer
+// for forward compatibility
+type GameTrackerQueryServer interface {
+	// WatchLiveGames sends the matching live games, then every time one of them starts, updates or finishes.
+	// The stream is aborted if the client falls too far behind, and should be reopened for a new snapshot.
+	WatchLiveGames(*WatchLiveGamesRequest, GameTrackerQuery_WatchLiveGamesServer) error
+	// GetHeadToHead returns a player's record against and alongside another player in each game mode they played together
+	GetHeadToHead(context.Context, *GetHeadToHeadRequest) (*GetHeadToHeadResponse, error)
+	// GetTopPlayerPairs returns a player's rivals, the players most played against,
+	// or partners, the teammates with the highest win rate together
+	GetTopPlayerPairs(context.Context, *GetTopPlayerPairsRequest) (*GetTopPlayerPairsResponse, error)
+	// GetGameSummary returns the result of a finished game in the forms posted to community channels
+	GetGameSummary(context.Context, *GetGameSummaryRequest) (*GetGameSummaryResponse, error)
+	mustEmbedUnimplementedGameTrackerQueryServer()
+}
+
+// UnimplementedGameTrackerQueryServer must be embedded to have forward compatible implementations.
+type UnimplementedGameTrackerQueryServer struct {
+}
+
+func (UnimplementedGameTrackerQueryServer) WatchLiveGames(*WatchLiveGamesRequest, GameTrackerQuery_WatchLiveGamesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLiveGames not implemented")
+}
+func (UnimplementedGameTrackerQueryServer) GetHeadToHead(context.Context, *GetHeadToHeadRequest) (*GetHeadToHeadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeadToHead not implemented")
+}
+func (UnimplementedGameTrackerQueryServer) GetTopPlayerPairs(context.Context, *GetTopPlayerPairsRequest) (*GetTopPlayerPairsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopPlayerPairs not implemented")
+}
+func (UnimplementedGameTrackerQueryServer) GetGameSummary(context.Context, *GetGameSummaryRequest) (*GetGameSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGameSummary not implemented")
+}
+func (UnimplementedGameTrackerQueryServer) mustEmbedUnimplementedGameTrackerQueryServer() {}
+
+// UnsafeGameTrackerQueryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GameTrackerQueryServer will
+// result in compilation errors.
+type UnsafeGameTrackerQueryServer interface {
+	mustEmbedUnimplementedGameTrackerQueryServer()
+}
+
+func RegisterGameTrackerQueryServer(s grpc.ServiceRegistrar, srv GameTrackerQueryServer) {
+	s.RegisterService(&GameTrackerQuery_ServiceDesc, srv)
+}
+
+func _GameTrackerQuery_WatchLiveGames_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLiveGamesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameTrackerQueryServer).WatchLiveGames(m, &gameTrackerQueryWatchLiveGamesServer{stream})
+}
+
+type GameTrackerQuery_WatchLiveGamesServer interface {
+	Send(*LiveGameEvent) error
+	grpc.ServerStream
+}
+
+type gameTrackerQueryWatchLiveGamesServer struct {
+	grpc.ServerStream
+}
+
+func (x *gameTrackerQueryWatchLiveGamesServer) Send(m *LiveGameEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GameTrackerQuery_GetHeadToHead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeadToHeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameTrackerQueryServer).GetHeadToHead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameTrackerQuery_GetHeadToHead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameTrackerQueryServer).GetHeadToHead(ctx, req.(*GetHeadToHeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameTrackerQuery_GetTopPlayerPairs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopPlayerPairsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameTrackerQueryServer).GetTopPlayerPairs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameTrackerQuery_GetTopPlayerPairs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameTrackerQueryServer).GetTopPlayerPairs(ctx, req.(*GetTopPlayerPairsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameTrackerQuery_GetGameSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameTrackerQueryServer).GetGameSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameTrackerQuery_GetGameSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameTrackerQueryServer).GetGameSummary(ctx, req.(*GetGameSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GameTrackerQuery_ServiceDesc is the grpc.ServiceDesc for GameTrackerQuery service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GameTrackerQuery_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "emortal.grpc.game_tracker.GameTrackerQuery",
+	HandlerType: (*GameTrackerQueryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHeadToHead",
+			Handler:    _GameTrackerQuery_GetHeadToHead_Handler,
+		},
+		{
+			MethodName: "GetTopPlayerPairs",
+			Handler:    _GameTrackerQuery_GetTopPlayerPairs_Handler,
+		},
+		{
+			MethodName: "GetGameSummary",
+			Handler:    _GameTrackerQuery_GetGameSummary_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLiveGames",
+			Handler:       _GameTrackerQuery_WatchLiveGames_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "game_tracker/service.proto",
+}
@@ -0,0 +1,295 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"game-tracker/internal/export"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/emortalmc/proto-specs/gen/go/model/gametracker"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"io"
+	"time"
+)
+
+// Summary is the outcome of an import. Invalid lines are logged and counted but don't stop the import.
+type Summary struct {
+	Lines    int64
+	Invalid  int64
+	Inserted int64
+	Updated  int64
+	Skipped  int64
+}
+
+// Importer loads historic games from JSON Lines in the format written by the export-games command.
+type Importer struct {
+	logger    *zap.SugaredLogger
+	repo      repository.Repository
+	mode      repository.DuplicateMode
+	batchSize int
+
+	// DryRun validates every line without writing anything
+	DryRun bool
+}
+
+func NewImporter(logger *zap.SugaredLogger, repo repository.Repository, mode repository.DuplicateMode, batchSize int) *Importer {
+	return &Importer{
+		logger:    logger,
+		repo:      repo,
+		mode:      mode,
+		batchSize: batchSize,
+	}
+}
+
+// Import reads games from r until EOF, writing them in batches. The summary is returned even if the import fails.
+func (i *Importer) Import(ctx context.Context, r io.Reader) (*Summary, error) {
+	summary := &Summary{}
+	batch := make([]*model.HistoricGame, 0, i.batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 || i.DryRun {
+			batch = batch[:0]
+			return nil
+		}
+
+		result, err := i.repo.ImportHistoricGames(ctx, batch, i.mode)
+		if result != nil {
+			summary.Inserted += result.Inserted
+			summary.Updated += result.Updated
+			summary.Skipped += result.Skipped
+		}
+		if err != nil {
+			return err
+		}
+
+		i.logger.Infow("imported batch of games", "batchSize", len(batch), "lines", summary.Lines)
+		batch = batch[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		summary.Lines++
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		game, err := parseLine(line)
+		if err != nil {
+			summary.Invalid++
+			i.logger.Warnw("skipping invalid game", "line", summary.Lines, "error", err)
+			continue
+		}
+
+		batch = append(batch, game)
+		if len(batch) >= i.batchSize {
+			if err := flush(); err != nil {
+				return summary, err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return summary, fmt.Errorf("failed to read games: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return summary, err
+	}
+
+	return summary, nil
+}
+
+func parseLine(line []byte) (*model.HistoricGame, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.DisallowUnknownFields()
+
+	var record export.GameRecord
+	if err := dec.Decode(&record); err != nil {
+		return nil, fmt.Errorf("failed to parse game: %w", err)
+	}
+
+	return GameFromRecord(&record)
+}
+
+// GameFromRecord validates an exported game and converts it back into a historic game.
+// Player ids are parsed with the same functions used for producer messages.
+// Records without an id are given a new one, so importing them twice creates duplicate games.
+func GameFromRecord(r *export.GameRecord) (*model.HistoricGame, error) {
+	if r.GameModeId == "" {
+		return nil, errors.New("gameModeId is required")
+	}
+	if r.EndTime.IsZero() {
+		return nil, errors.New("endTime is required")
+	}
+	if r.StartTime != nil && r.StartTime.After(r.EndTime) {
+		return nil, errors.New("startTime is after endTime")
+	}
+	if len(r.Players) == 0 {
+		return nil, errors.New("game has no players")
+	}
+	if r.TowerDefence != nil && r.BlockSumo != nil {
+		return nil, errors.New("game has both Tower Defence and Block Sumo data")
+	}
+
+	id := primitive.NewObjectID()
+	if r.Id != "" {
+		var err error
+		if id, err = primitive.ObjectIDFromHex(r.Id); err != nil {
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+	}
+
+	protoPlayers := make([]*gametracker.BasicGamePlayer, len(r.Players))
+	for i, p := range r.Players {
+		protoPlayers[i] = &gametracker.BasicGamePlayer{Id: p.Id, Username: p.Username}
+	}
+
+	players, err := model.BasicPlayersFromProto(protoPlayers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse players: %w", err)
+	}
+
+	inGame := make(map[uuid.UUID]bool, len(players))
+	for _, p := range players {
+		inGame[p.Id] = true
+	}
+
+	game := &model.HistoricGame{
+		Game: &model.Game{
+			Id:         id,
+			GameModeId: r.GameModeId,
+			ServerId:   r.ServerId,
+			MapId:      r.MapId,
+			StartTime:  r.StartTime,
+			Players:    players,
+			CreatedAt:  time.Now(),
+		},
+		EndTime: r.EndTime,
+	}
+
+	if len(r.Teams) > 0 {
+		teams := make([]*model.Team, len(r.Teams))
+		for i, t := range r.Teams {
+			team, err := model.TeamFromProto(&gametracker.Team{
+				Id:           t.Id,
+				FriendlyName: t.FriendlyName,
+				Color:        t.Color,
+				PlayerIds:    t.PlayerIds,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse team %s: %w", t.Id, err)
+			}
+
+			if err := checkInGame(inGame, team.PlayerIds); err != nil {
+				return nil, fmt.Errorf("invalid team %s: %w", t.Id, err)
+			}
+			teams[i] = team
+		}
+		game.TeamData = &teams
+	}
+
+	if len(r.WinnerIds) > 0 || len(r.LoserIds) > 0 {
+		winnerData, err := model.HistoricWinnerDataFromProto(&gametracker.CommonGameFinishWinnerData{
+			WinnerIds: r.WinnerIds,
+			LoserIds:  r.LoserIds,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse winner data: %w", err)
+		}
+
+		if err := checkInGame(inGame, winnerData.WinnerIds); err != nil {
+			return nil, fmt.Errorf("invalid winners: %w", err)
+		}
+		if err := checkInGame(inGame, winnerData.LoserIds); err != nil {
+			return nil, fmt.Errorf("invalid losers: %w", err)
+		}
+		game.WinnerData = winnerData
+	}
+
+	for _, p := range r.Participation {
+		playerId, err := uuid.Parse(p.PlayerId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse participation player id: %w", err)
+		}
+
+		game.Participation = append(game.Participation, &model.PlayerParticipation{
+			PlayerId:      playerId,
+			Username:      p.Username,
+			FirstJoinTime: p.FirstJoinTime,
+			LastLeaveTime: p.LastLeaveTime,
+			TimeInGame:    time.Duration(p.TimeInGameMillis) * time.Millisecond,
+			LeftEarly:     p.LeftEarly,
+		})
+	}
+
+	switch {
+	case r.TowerDefence != nil:
+		td := r.TowerDefence
+		if td.RedHealth < 0 || td.BlueHealth < 0 || td.RedHealth > td.MaxHealth || td.BlueHealth > td.MaxHealth {
+			return nil, errors.New("tower health must be between 0 and maxHealth")
+		}
+
+		game.SetGameData(model.CreateHistoricTowerDefenceDataFromFinish(&gametracker.TowerDefenceFinishData{
+			HealthData: &gametracker.TowerDefenceHealthData{
+				MaxHealth:  td.MaxHealth,
+				RedHealth:  td.RedHealth,
+				BlueHealth: td.BlueHealth,
+			},
+		}))
+	case r.BlockSumo != nil:
+		entries := make(map[string]*gametracker.BlockSumoScoreboard_Entry, len(r.BlockSumo.Scoreboard))
+		for playerId, e := range r.BlockSumo.Scoreboard {
+			entries[playerId] = &gametracker.BlockSumoScoreboard_Entry{
+				RemainingLives: e.RemainingLives,
+				Kills:          e.Kills,
+				FinalKills:     e.FinalKills,
+			}
+		}
+
+		data, err := model.CreateHistoricBlockSumoDataFromFinish(&gametracker.BlockSumoFinishData{
+			Scoreboard: &gametracker.BlockSumoScoreboard{Entries: entries},
+		})
+		if err != nil {
+			return nil, err
+		}
+		game.SetGameData(data)
+	}
+
+	game.ComputeDuration()
+	game.ComputeTeamOutcome()
+
+	// The winning team of Tower Defence games can come from analytics, which aren't exported
+	switch {
+	case r.WinningTeamId == "" || game.WinningTeamId == r.WinningTeamId:
+	case game.WinningTeamId == "":
+		game.WinningTeamId = r.WinningTeamId
+		for _, stats := range game.TeamStats {
+			stats.Won = stats.TeamId == r.WinningTeamId
+		}
+	default:
+		return nil, fmt.Errorf("winningTeamId %s doesn't match the team of the winners", r.WinningTeamId)
+	}
+
+	return game, nil
+}
+
+func checkInGame(inGame map[uuid.UUID]bool, ids []uuid.UUID) error {
+	for _, id := range ids {
+		if !inGame[id] {
+			return fmt.Errorf("player %s is not in the game", id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package importer
+
+import (
+	"game-tracker/internal/export"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+const (
+	playerA = "00000000-0000-0000-0000-00000000000a"
+	playerB = "00000000-0000-0000-0000-00000000000b"
+	playerC = "00000000-0000-0000-0000-00000000000c"
+)
+
+// validRecord returns a Tower Defence game won by the red team
+func validRecord() *export.GameRecord {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	return &export.GameRecord{
+		Id:         "000000000000000000000001",
+		GameModeId: "towerdefence",
+		ServerId:   "server-1",
+		StartTime:  &start,
+		EndTime:    start.Add(10 * time.Minute),
+		Players:    []*export.PlayerRecord{{Id: playerA, Username: "a"}, {Id: playerB, Username: "b"}},
+		Teams: []*export.TeamRecord{
+			{Id: "red", PlayerIds: []string{playerA}},
+			{Id: "blue", PlayerIds: []string{playerB}},
+		},
+		WinnerIds:    []string{playerA},
+		LoserIds:     []string{playerB},
+		TowerDefence: &export.TowerDefenceRecord{MaxHealth: 100, RedHealth: 40, BlueHealth: 0},
+	}
+}
+
+func TestGameFromRecord(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(r *export.GameRecord)
+		wantErr     bool
+		wantWinning string
+	}{
+		{name: "valid", modify: func(r *export.GameRecord) {}, wantWinning: "red"},
+		{name: "matching winning team", modify: func(r *export.GameRecord) { r.WinningTeamId = "red" }, wantWinning: "red"},
+		{
+			name: "winning team without winners",
+			modify: func(r *export.GameRecord) {
+				r.WinnerIds, r.LoserIds = nil, nil
+				r.WinningTeamId = "blue"
+			},
+			wantWinning: "blue",
+		},
+		{name: "no id", modify: func(r *export.GameRecord) { r.Id = "" }, wantWinning: "red"},
+		{name: "no start time", modify: func(r *export.GameRecord) { r.StartTime = nil }, wantWinning: "red"},
+		{name: "winning team of the losers", modify: func(r *export.GameRecord) { r.WinningTeamId = "blue" }, wantErr: true},
+		{name: "no game mode", modify: func(r *export.GameRecord) { r.GameModeId = "" }, wantErr: true},
+		{name: "no end time", modify: func(r *export.GameRecord) { r.EndTime = time.Time{} }, wantErr: true},
+		{
+			name: "starts after it ends",
+			modify: func(r *export.GameRecord) {
+				start := r.EndTime.Add(time.Second)
+				r.StartTime = &start
+			},
+			wantErr: true,
+		},
+		{name: "no players", modify: func(r *export.GameRecord) { r.Players = nil }, wantErr: true},
+		{
+			name:    "both game modes",
+			modify:  func(r *export.GameRecord) { r.BlockSumo = &export.BlockSumoRecord{} },
+			wantErr: true,
+		},
+		{name: "invalid id", modify: func(r *export.GameRecord) { r.Id = "game" }, wantErr: true},
+		{name: "invalid player id", modify: func(r *export.GameRecord) { r.Players[0].Id = "a" }, wantErr: true},
+		{
+			name:    "team player not in the game",
+			modify:  func(r *export.GameRecord) { r.Teams[0].PlayerIds = []string{playerC} },
+			wantErr: true,
+		},
+		{name: "winner not in the game", modify: func(r *export.GameRecord) { r.WinnerIds = []string{playerC} }, wantErr: true},
+		{name: "loser not in the game", modify: func(r *export.GameRecord) { r.LoserIds = []string{playerC} }, wantErr: true},
+		{
+			name: "invalid participation player id",
+			modify: func(r *export.GameRecord) {
+				r.Participation = []*export.ParticipationRecord{{PlayerId: "a"}}
+			},
+			wantErr: true,
+		},
+		{name: "negative health", modify: func(r *export.GameRecord) { r.TowerDefence.RedHealth = -1 }, wantErr: true},
+		{name: "health above max", modify: func(r *export.GameRecord) { r.TowerDefence.BlueHealth = 101 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := validRecord()
+			tt.modify(record)
+
+			game, err := GameFromRecord(record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GameFromRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if record.Id != "" && game.Id.Hex() != record.Id {
+				t.Errorf("Id = %s, want %s", game.Id.Hex(), record.Id)
+			}
+			if game.Id.IsZero() {
+				t.Error("game has no id")
+			}
+			if game.WinningTeamId != tt.wantWinning {
+				t.Errorf("WinningTeamId = %q, want %q", game.WinningTeamId, tt.wantWinning)
+			}
+			for _, stats := range game.TeamStats {
+				if stats.Won != (stats.TeamId == tt.wantWinning) {
+					t.Errorf("team %s Won = %v", stats.TeamId, stats.Won)
+				}
+			}
+			if record.StartTime != nil && game.Duration != 10*time.Minute {
+				t.Errorf("Duration = %s, want 10m", game.Duration)
+			}
+			if _, ok := game.GameData.(*model.HistoricTowerDefenceData); !ok {
+				t.Errorf("GameData = %T, want tower defence data", game.GameData)
+			}
+		})
+	}
+}
+
+func TestGameFromRecordRoundTrip(t *testing.T) {
+	game, err := GameFromRecord(validRecord())
+	if err != nil {
+		t.Fatalf("GameFromRecord() error = %v", err)
+	}
+
+	record := export.GameRecordFromModel(game)
+	again, err := GameFromRecord(record)
+	if err != nil {
+		t.Fatalf("GameFromRecord() of the exported game error = %v", err)
+	}
+
+	if again.Id != game.Id || again.WinningTeamId != game.WinningTeamId || !again.EndTime.Equal(game.EndTime) ||
+		again.Duration != game.Duration || len(again.Players) != len(game.Players) {
+		t.Errorf("round trip = %+v, want %+v", again.Game, game.Game)
+	}
+	if again.Players[0].Id != uuid.MustParse(playerA) {
+		t.Errorf("first player = %s, want %s", again.Players[0].Id, playerA)
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			line: `{"gameModeId":"blocksumo","endTime":"2026-01-01T12:00:00Z","players":[{"id":"` + playerA + `","username":"a"}],` +
+				`"blockSumo":{"scoreboard":{"` + playerA + `":{"remainingLives":2,"kills":1,"finalKills":0}}}}`,
+		},
+		{
+			name:    "unknown field",
+			line:    `{"gameModeId":"blocksumo","endTime":"2026-01-01T12:00:00Z","players":[{"id":"` + playerA + `"}],"extra":1}`,
+			wantErr: true,
+		},
+		{name: "not json", line: `game`, wantErr: true},
+		{name: "invalid game", line: `{"gameModeId":"blocksumo"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseLine([]byte(tt.line))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
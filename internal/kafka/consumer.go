@@ -3,15 +3,16 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"game-tracker/internal/achievements"
 	"game-tracker/internal/config"
+	"game-tracker/internal/live"
 	"game-tracker/internal/parsers"
 	"game-tracker/internal/repository"
 	"game-tracker/internal/repository/model"
-	"game-tracker/internal/utils"
+	"game-tracker/internal/webhook"
 	"github.com/emortalmc/proto-specs/gen/go/message/gametracker"
 	"github.com/emortalmc/proto-specs/gen/go/nongenerated/kafkautils"
 	"github.com/segmentio/kafka-go"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -23,17 +24,14 @@ import (
 const gamesTopic = "game-tracker"
 
 type consumer struct {
-	logger *zap.SugaredLogger
-	repo   repository.Repository
+	*processor
 
 	reader *kafka.Reader
-
-	liveHandler     *parserHandler[model.LiveGame]
-	historicHandler *parserHandler[model.HistoricGame]
 }
 
 func NewConsumer(ctx context.Context, wg *sync.WaitGroup, cfg config.KafkaConfig, logger *zap.SugaredLogger,
-	repo repository.Repository) {
+	repo repository.Repository, relay *OutboxRelay, hub *live.Hub, webhooks *webhook.Dispatcher, achievements *achievements.Engine,
+	seasons config.Seasons, erasureCfg config.ErasureConfig) {
 
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:     []string{cfg.Host},
@@ -45,13 +43,9 @@ func NewConsumer(ctx context.Context, wg *sync.WaitGroup, cfg config.KafkaConfig
 	})
 
 	c := &consumer{
-		logger: logger,
-		repo:   repo,
+		processor: newProcessor(logger, repo, relay, hub, webhooks, achievements, seasons, erasureCfg),
 
 		reader: reader,
-
-		liveHandler:     &parserHandler[model.LiveGame]{logger: logger, parsers: parsers.LiveParsers},
-		historicHandler: &parserHandler[model.HistoricGame]{logger: logger, parsers: parsers.HistoricParsers},
 	}
 
 	handler := kafkautils.NewConsumerHandler(logger, reader)
@@ -71,129 +65,32 @@ func NewConsumer(ctx context.Context, wg *sync.WaitGroup, cfg config.KafkaConfig
 	}()
 }
 
-func (c *consumer) handleGameStartMessage(ctx context.Context, _ *kafka.Message, uncastMsg proto.Message) {
-	m := uncastMsg.(*gametracker.GameStartMessage)
-	commonData := m.CommonData
-
-	id, err := primitive.ObjectIDFromHex(commonData.GameId)
-	if err != nil {
-		c.logger.Errorw("failed to parse game id", "gameId", commonData.GameId)
-		return
-	}
-
-	players, err := model.BasicPlayersFromProto(commonData.Players)
-	if err != nil {
-		c.logger.Errorw("failed to parse players", "players", commonData.Players)
-		return
-	}
-
-	liveGame := &model.LiveGame{
-		Game: &model.Game{
-			Id:         id,
-			GameModeId: commonData.GameModeId,
-			ServerId:   commonData.ServerId,
-			StartTime:  utils.Pointer(m.StartTime.AsTime()),
-			Players:    players,
-		},
-		LastUpdated: time.Now(),
-	}
-
-	if err := c.liveHandler.handle(m.Content, liveGame); err != nil {
-		c.logger.Errorw("failed to handle game content", "game", commonData.GameId, "content", m.Content)
-		return
-	}
-
-	if err := c.repo.SaveLiveGame(ctx, liveGame); err != nil {
-		c.logger.Errorw("failed to save live game", "game", liveGame, "error", err)
-		return
+func (c *consumer) handleGameStartMessage(ctx context.Context, kafkaMsg *kafka.Message, uncastMsg proto.Message) {
+	if err := c.processGameStart(ctx, uncastMsg.(*gametracker.GameStartMessage), messageTime(kafkaMsg)); err != nil {
+		c.logger.Errorw("failed to process game start message", "error", err)
 	}
 }
 
-func (c *consumer) handleGameUpdateMessage(ctx context.Context, _ *kafka.Message, uncastMsg proto.Message) {
-	m := uncastMsg.(*gametracker.GameUpdateMessage)
-	commonData := m.CommonData
-
-	id, err := primitive.ObjectIDFromHex(commonData.GameId)
-	if err != nil {
-		c.logger.Errorw("failed to parse game id", "gameId", commonData.GameId)
-		return
-	}
-
-	liveGame, err := c.repo.GetLiveGame(ctx, id)
-	if err != nil {
-		c.logger.Errorw("failed to get live game", "gameId", id.Hex(), err)
-		return
-	}
-
-	// common data start
-
-	players, err := model.BasicPlayersFromProto(commonData.Players)
-	if err != nil {
-		c.logger.Errorw("failed to parse players", "players", commonData.Players)
-		return
-	}
-
-	liveGame.Players = players
-	liveGame.LastUpdated = time.Now()
-
-	// common data end
-
-	if err := c.liveHandler.handle(m.Content, liveGame); err != nil {
-		c.logger.Errorw("failed to handle game content", "game", commonData.GameId, "content", m.Content)
-		return
-	}
-
-	if err := c.repo.SaveLiveGame(ctx, liveGame); err != nil {
-		c.logger.Errorw("failed to save live game", "game", liveGame, "error", err)
+func (c *consumer) handleGameUpdateMessage(ctx context.Context, kafkaMsg *kafka.Message, uncastMsg proto.Message) {
+	if err := c.processGameUpdate(ctx, uncastMsg.(*gametracker.GameUpdateMessage), messageTime(kafkaMsg)); err != nil {
+		c.logger.Errorw("failed to process game update message", "error", err)
 	}
 }
 
-func (c *consumer) handleGameFinishMessage(ctx context.Context, _ *kafka.Message, uncastMsg proto.Message) {
-	m := uncastMsg.(*gametracker.GameFinishMessage)
-	commonData := m.CommonData
-
-	id, err := primitive.ObjectIDFromHex(commonData.GameId)
-	if err != nil {
-		c.logger.Errorw("failed to parse game id", "gameId", commonData.GameId)
-		return
-	}
-
-	liveGame, err := c.repo.GetLiveGame(ctx, id)
-	if err != nil {
-		c.logger.Errorw("failed to get live game", "game", id, "error", err)
-		return
-	}
-
-	if err := c.repo.DeleteLiveGame(ctx, id); err != nil {
-		c.logger.Errorw("failed to delete live game", "game", id, "error", err)
-		return
-	}
-
-	players, err := model.BasicPlayersFromProto(commonData.Players)
-	if err != nil {
-		c.logger.Errorw("failed to parse players", "players", commonData.Players)
-		return
-	}
-
-	game := &model.HistoricGame{
-		Game: &model.Game{
-			Id:         id,
-			GameModeId: commonData.GameModeId,
-			ServerId:   commonData.ServerId,
-			StartTime:  liveGame.StartTime,
-			Players:    players,
-		},
-		EndTime: m.EndTime.AsTime(),
+func (c *consumer) handleGameFinishMessage(ctx context.Context, kafkaMsg *kafka.Message, uncastMsg proto.Message) {
+	if err := c.processGameFinish(ctx, uncastMsg.(*gametracker.GameFinishMessage), messageTime(kafkaMsg)); err != nil {
+		c.logger.Errorw("failed to process game finish message", "error", err)
 	}
+}
 
-	if err := c.historicHandler.handle(m.Content, game); err != nil {
-		c.logger.Errorw("failed to handle game content", "error", err, "game", commonData.GameId, "content", m.Content)
-		return
+// messageTime is the time the message was produced, used instead of the current time so replayed messages
+// produce the same timestamps as when they were first consumed.
+func messageTime(m *kafka.Message) time.Time {
+	if m == nil || m.Time.IsZero() {
+		return time.Now()
 	}
 
-	if err := c.repo.SaveHistoricGame(ctx, game); err != nil {
-		c.logger.Errorw("failed to save historic game", "game", game, "error", err)
-	}
+	return m.Time
 }
 
 type parserHandler[T model.IGame] struct {
@@ -0,0 +1,125 @@
+package kafka
+
+import (
+	"context"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+const (
+	outboxBatchSize = 100
+
+	outboxPollInterval = 5 * time.Second
+	outboxMaxBackoff   = time.Minute
+)
+
+// OutboxRelay publishes queued outbox events and marks them sent. Events are marked after they are written,
+// so a crash in between causes them to be published again (at-least-once delivery).
+// Events are published in the order they were queued, stopping at the first failure to keep that order.
+type OutboxRelay struct {
+	logger   *zap.SugaredLogger
+	repo     repository.Repository
+	producer *Producer
+
+	// wake is signalled when events are queued so they are published without waiting for the next poll
+	wake chan struct{}
+}
+
+func NewOutboxRelay(ctx context.Context, wg *sync.WaitGroup, logger *zap.SugaredLogger, repo repository.Repository,
+	producer *Producer) *OutboxRelay {
+
+	r := &OutboxRelay{
+		logger:   logger,
+		repo:     repo,
+		producer: producer,
+		wake:     make(chan struct{}, 1),
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.run(ctx)
+	}()
+
+	return r
+}
+
+// Notify wakes the relay to publish newly queued events
+func (r *OutboxRelay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *OutboxRelay) run(ctx context.Context) {
+	backoff := time.Duration(0)
+
+	for {
+		wait := outboxPollInterval
+		if backoff > 0 {
+			wait = backoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-time.After(wait):
+		}
+
+		if err := r.relay(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			backoff = min(max(2*backoff, time.Second), outboxMaxBackoff)
+			r.logger.Errorw("failed to relay outbox events", "retryIn", backoff, "error", err)
+			continue
+		}
+		backoff = 0
+	}
+}
+
+// relay publishes batches of events until the outbox is empty
+func (r *OutboxRelay) relay(ctx context.Context) error {
+	for {
+		events, err := r.repo.GetUnsentOutboxEvents(ctx, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := outboxEventIds(events)
+
+		if err := r.producer.PublishOutboxEvents(ctx, events); err != nil {
+			if recordErr := r.repo.RecordOutboxFailure(ctx, ids, err.Error()); recordErr != nil {
+				r.logger.Errorw("failed to record outbox failure", "error", recordErr)
+			}
+			return err
+		}
+
+		if err := r.repo.MarkOutboxEventsSent(ctx, ids, time.Now()); err != nil {
+			return err
+		}
+
+		if len(events) < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+func outboxEventIds(events []*model.OutboxEvent) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, len(events))
+	for i, e := range events {
+		ids[i] = e.Id
+	}
+
+	return ids
+}
@@ -0,0 +1,462 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/achievements"
+	"game-tracker/internal/config"
+	"game-tracker/internal/erasure"
+	"game-tracker/internal/events"
+	"game-tracker/internal/live"
+	"game-tracker/internal/parsers"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"game-tracker/internal/utils"
+	"game-tracker/internal/webhook"
+	"github.com/emortalmc/proto-specs/gen/go/message/gametracker"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// processor applies game messages to a repository. It is shared by the consumer and replays
+// so both produce exactly the same stored games.
+type processor struct {
+	logger *zap.SugaredLogger
+	repo   repository.Repository
+	// relay publishes the events queued when games are saved. It is nil if publishing is disabled.
+	relay *OutboxRelay
+	// hub receives live game changes made by this replica. It is nil if changes are read from a change stream instead.
+	hub *live.Hub
+	// webhooks creates the webhook deliveries saved with finished games. It is nil if webhooks are disabled.
+	webhooks *webhook.Dispatcher
+	// achievements evaluates the achievements earned in finished games. It is nil if achievements are disabled.
+	achievements *achievements.Engine
+	// seasons are the configured seasons finished games are bucketed into
+	seasons config.Seasons
+	// erasure recognises erased players, who are replaced with their pseudonyms in every game saved
+	erasure config.ErasureConfig
+	// erasures is the repository erased players are looked up in. It is repo, except when replaying into
+	// a separate database, which has none of the live database's erasures.
+	erasures repository.Repository
+
+	liveHandler     *parserHandler[model.LiveGame]
+	historicHandler *parserHandler[model.HistoricGame]
+}
+
+func newProcessor(logger *zap.SugaredLogger, repo repository.Repository, relay *OutboxRelay, hub *live.Hub,
+	webhooks *webhook.Dispatcher, achievements *achievements.Engine, seasons config.Seasons,
+	erasureCfg config.ErasureConfig) *processor {
+
+	return &processor{
+		logger:       logger,
+		repo:         repo,
+		relay:        relay,
+		hub:          hub,
+		webhooks:     webhooks,
+		achievements: achievements,
+		seasons:      seasons,
+		erasure:      erasureCfg,
+		erasures:     repo,
+
+		liveHandler:     &parserHandler[model.LiveGame]{logger: logger, parsers: parsers.LiveParsers},
+		historicHandler: &parserHandler[model.HistoricGame]{logger: logger, parsers: parsers.HistoricParsers},
+	}
+}
+
+func (p *processor) processGameStart(ctx context.Context, m *gametracker.GameStartMessage, now time.Time) error {
+	commonData := m.CommonData
+
+	id, err := primitive.ObjectIDFromHex(commonData.GameId)
+	if err != nil {
+		return fmt.Errorf("failed to parse game id %s: %w", commonData.GameId, err)
+	}
+
+	players, err := model.BasicPlayersFromProto(commonData.Players)
+	if err != nil {
+		return fmt.Errorf("failed to parse players of game %s: %w", commonData.GameId, err)
+	}
+
+	liveGame := &model.LiveGame{
+		Game: &model.Game{
+			Id:         id,
+			GameModeId: commonData.GameModeId,
+			ServerId:   commonData.ServerId,
+			MapId:      m.MapId,
+			StartTime:  utils.Pointer(m.StartTime.AsTime()),
+			CreatedAt:  now,
+		},
+		LastUpdated: now,
+	}
+	liveGame.SyncPlayers(players, *liveGame.StartTime)
+
+	if err := p.liveHandler.handle(m.Content, liveGame); err != nil {
+		return fmt.Errorf("failed to handle content of game %s: %w", commonData.GameId, err)
+	}
+
+	if err := p.replaceErasedPlayers(ctx, liveGame, players, now); err != nil {
+		return fmt.Errorf("failed to replace erased players of game %s: %w", commonData.GameId, err)
+	}
+
+	if err := p.repo.SaveLiveGame(ctx, liveGame); err != nil {
+		return fmt.Errorf("failed to save live game %s: %w", commonData.GameId, err)
+	}
+	p.publishLive(&live.Event{Type: live.EventCreated, LiveGame: liveGame})
+
+	return nil
+}
+
+func (p *processor) processGameUpdate(ctx context.Context, m *gametracker.GameUpdateMessage, now time.Time) error {
+	commonData := m.CommonData
+
+	id, err := primitive.ObjectIDFromHex(commonData.GameId)
+	if err != nil {
+		return fmt.Errorf("failed to parse game id %s: %w", commonData.GameId, err)
+	}
+
+	liveGame, err := p.repo.GetLiveGame(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get live game %s: %w", commonData.GameId, err)
+	}
+
+	// common data start
+
+	players, err := model.BasicPlayersFromProto(commonData.Players)
+	if err != nil {
+		return fmt.Errorf("failed to parse players of game %s: %w", commonData.GameId, err)
+	}
+
+	liveGame.SyncPlayers(players, liveGame.GameTime(now))
+	liveGame.LastUpdated = now
+	liveGame.RecordUpdate(now)
+
+	// common data end
+
+	if err := p.liveHandler.handle(m.Content, liveGame); err != nil {
+		return fmt.Errorf("failed to handle content of game %s: %w", commonData.GameId, err)
+	}
+
+	if err := p.replaceErasedPlayers(ctx, liveGame, players, now); err != nil {
+		return fmt.Errorf("failed to replace erased players of game %s: %w", commonData.GameId, err)
+	}
+
+	if err := p.repo.SaveLiveGame(ctx, liveGame); err != nil {
+		return fmt.Errorf("failed to save live game %s: %w", commonData.GameId, err)
+	}
+	p.publishLive(&live.Event{Type: live.EventUpdated, LiveGame: liveGame})
+
+	return nil
+}
+
+func (p *processor) processGameFinish(ctx context.Context, m *gametracker.GameFinishMessage, now time.Time) error {
+	commonData := m.CommonData
+
+	id, err := primitive.ObjectIDFromHex(commonData.GameId)
+	if err != nil {
+		return fmt.Errorf("failed to parse game id %s: %w", commonData.GameId, err)
+	}
+
+	liveGame, err := p.repo.GetLiveGame(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get live game %s: %w", commonData.GameId, err)
+	}
+
+	players, err := model.BasicPlayersFromProto(commonData.Players)
+	if err != nil {
+		return fmt.Errorf("failed to parse players of game %s: %w", commonData.GameId, err)
+	}
+
+	game := &model.HistoricGame{
+		Game: &model.Game{
+			Id:         id,
+			GameModeId: commonData.GameModeId,
+			ServerId:   commonData.ServerId,
+			MapId:      liveGame.MapId,
+			StartTime:  liveGame.StartTime,
+			// Teams are usually only sent on start, the finish content may replace them
+			TeamData: liveGame.TeamData,
+
+			CreatedAt:       liveGame.CreatedAt,
+			UpdateCount:     liveGame.UpdateCount,
+			FirstUpdateTime: liveGame.FirstUpdateTime,
+			LastUpdateTime:  liveGame.LastUpdateTime,
+			PlayerSessions:  liveGame.PlayerSessions,
+		},
+		EndTime: m.EndTime.AsTime(),
+	}
+	game.ComputeDuration()
+	game.SyncFinishPlayers(players)
+	game.ComputeParticipation()
+
+	if err := p.historicHandler.handle(m.Content, game); err != nil {
+		return fmt.Errorf("failed to handle content of game %s: %w", commonData.GameId, err)
+	}
+
+	game.ComputeTeamOutcome()
+
+	switch data := game.GameData.(type) {
+	case *model.HistoricTowerDefenceData:
+		if liveData, ok := liveGame.GameData.(*model.LiveTowerDefenceData); ok {
+			data.ComputeAnalytics(liveData, game.EndTime, game.WinningTeamId)
+		}
+	case *model.HistoricBlockSumoData:
+		if liveData, ok := liveGame.GameData.(*model.LiveBlockSumoData); ok {
+			data.InitialLives = liveData.InitialLives
+		}
+	}
+
+	if err := p.replaceErasedPlayers(ctx, game, players, now); err != nil {
+		return fmt.Errorf("failed to replace erased players of game %s: %w", commonData.GameId, err)
+	}
+
+	outboxEvents, err := p.outboxEvents(commonData.GameId, now,
+		&outboxMessage{message: events.GameRecorded(game), playerIds: game.ReferencedPlayerIds()})
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*model.WebhookDelivery
+	if p.webhooks != nil {
+		if deliveries, err = p.webhooks.Deliveries(game, now); err != nil {
+			return fmt.Errorf("failed to create webhook deliveries of game %s: %w", commonData.GameId, err)
+		}
+	}
+
+	if err := p.finishGame(ctx, game, outboxEvents, deliveries, now); err != nil {
+		return err
+	}
+	p.notifyRelay()
+	if len(deliveries) > 0 {
+		p.webhooks.Notify()
+	}
+	p.publishLive(&live.Event{Type: live.EventFinished, HistoricGame: game})
+
+	return nil
+}
+
+// finishGame saves the game and records its players' stats in one transaction, so a failure fails the message
+// without losing the stats or counting them twice when it is retried.
+// While a recompute swaps in its collections, the game waits for the swap to complete.
+func (p *processor) finishGame(ctx context.Context, game *model.HistoricGame, outboxEvents []*model.OutboxEvent,
+	deliveries []*model.WebhookDelivery, now time.Time) error {
+
+	for paused := false; ; paused = true {
+		err := p.repo.WithTransaction(ctx, func(ctx context.Context) error {
+			if err := p.repo.FinishGame(ctx, game, outboxEvents, deliveries); err != nil {
+				return fmt.Errorf("failed to save historic game %s: %w", game.Id.Hex(), err)
+			}
+
+			if err := p.recordPlayerStats(ctx, game, now); err != nil {
+				return fmt.Errorf("failed to record player stats of game %s: %w", game.Id.Hex(), err)
+			}
+			return nil
+		})
+		if !errors.Is(err, errRecomputeSwapping) {
+			return err
+		}
+
+		if !paused {
+			p.logger.Infow("pausing finished game until the recompute has swapped in its collections",
+				"gameId", game.Id.Hex())
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(recomputePollInterval):
+		}
+	}
+}
+
+// errRecomputeSwapping is returned while a recompute swaps in its collections, which the stats must wait for
+var errRecomputeSwapping = errors.New("recompute is swapping in its collections")
+
+// recomputePollInterval is how often paused games check whether the recompute has swapped in its collections
+const recomputePollInterval = time.Second
+
+// recordPlayerStats updates the stats, pairwise stats, leaderboard scores and ratings of the game's players
+// and unlocks the achievements they earned, queueing the stats, rating and achievement events.
+// It is called in the transaction saving the game.
+// Games are bucketed into the season they ended in as well as the all-time totals.
+//
+// While a recompute is applying games, the game is recorded into its collections too.
+func (p *processor) recordPlayerStats(ctx context.Context, game *model.HistoricGame, now time.Time) error {
+	seasonId := p.seasons.IdAt(game.EndTime)
+
+	recompute, err := p.recomputeTarget(ctx, game)
+	if err != nil {
+		return err
+	}
+	if recompute != nil {
+		if _, _, err := recordDerived(ctx, recompute, game, seasonId); err != nil {
+			return fmt.Errorf("failed to record into recompute collections: %w", err)
+		}
+	}
+
+	stats, ratings, err := recordDerived(ctx, p.repo, game, seasonId)
+	if err != nil {
+		return err
+	}
+
+	messages := make([]*outboxMessage, 0, len(stats)+len(ratings))
+	for _, s := range stats {
+		messages = append(messages, &outboxMessage{
+			message:   events.PlayerStatsUpdated(s, game.Id.Hex(), seasonId),
+			playerIds: []uuid.UUID{s.PlayerId},
+		})
+	}
+	for _, r := range ratings {
+		messages = append(messages, &outboxMessage{
+			message:   events.PlayerRatingChanged(r, game.Id.Hex(), seasonId),
+			playerIds: []uuid.UUID{r.PlayerId},
+		})
+	}
+
+	if p.achievements != nil {
+		unlocked, err := p.repo.UnlockAchievements(ctx, p.achievements.Evaluate(game, stats, now))
+		if err != nil {
+			return err
+		}
+
+		for _, a := range unlocked {
+			messages = append(messages, &outboxMessage{
+				message:   events.AchievementUnlocked(a, p.achievements.Rule(a.AchievementId).Name),
+				playerIds: []uuid.UUID{a.PlayerId},
+			})
+		}
+	}
+
+	outboxEvents, err := p.outboxEvents(game.Id.Hex(), now, messages...)
+	if err != nil {
+		return err
+	}
+
+	return p.repo.SaveOutboxEvents(ctx, outboxEvents)
+}
+
+// recomputeTarget returns the repository to also record the game into if a recompute is applying games, claiming
+// the game for it. It returns errRecomputeSwapping if the recompute is swapping, so the transaction is retried
+// once the recompute's collections are the live ones.
+func (p *processor) recomputeTarget(ctx context.Context, game *model.HistoricGame) (repository.Repository, error) {
+	checkpoint, err := p.repo.GetRecomputeCheckpoint(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if checkpoint.Phase == model.RecomputeSwapping {
+		return nil, errRecomputeSwapping
+	}
+
+	claimed, err := p.repo.ClaimRecomputeGame(ctx, game.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		// The recompute already applied it
+		return nil, nil
+	}
+
+	return p.repo.RecomputeTarget(), nil
+}
+
+// recordDerived records the game into the repository's derived collections, returning the updated stats and ratings
+func recordDerived(ctx context.Context, repo repository.Repository, game *model.HistoricGame,
+	seasonId string) ([]*model.PlayerStats, []*model.PlayerRating, error) {
+
+	if err := repo.RecordPlayerPairs(ctx, game, seasonId); err != nil {
+		return nil, nil, err
+	}
+	if err := repo.RecordLeaderboardScores(ctx, game, seasonId); err != nil {
+		return nil, nil, err
+	}
+	ratings, err := repo.RecordPlayerRatings(ctx, game, seasonId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats, err := repo.RecordPlayerStats(ctx, game, seasonId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stats, ratings, nil
+}
+
+// outboxMessage is an event and the players it references, so it can be found when one of them is erased
+type outboxMessage struct {
+	message   proto.Message
+	playerIds []uuid.UUID
+}
+
+// outboxEvents encodes the events to be saved with a game. No events are returned if publishing is disabled.
+func (p *processor) outboxEvents(gameId string, now time.Time, messages ...*outboxMessage) ([]*model.OutboxEvent, error) {
+	if p.relay == nil {
+		return nil, nil
+	}
+
+	outboxEvents := make([]*model.OutboxEvent, len(messages))
+	for i, m := range messages {
+		e, err := newOutboxEvent(gameId, m.message, m.playerIds, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode event of game %s: %w", gameId, err)
+		}
+		outboxEvents[i] = e
+	}
+
+	return outboxEvents, nil
+}
+
+func (p *processor) publishLive(e *live.Event) {
+	if p.hub != nil {
+		p.hub.Publish(e)
+	}
+}
+
+func (p *processor) notifyRelay() {
+	if p.relay != nil {
+		p.relay.Notify()
+	}
+}
+
+// replaceErasedPlayers replaces the erased players in the game with their pseudonyms, then records the other players
+// in the player directory. Erased players are still sent by games that were live when they were erased.
+func (p *processor) replaceErasedPlayers(ctx context.Context, game erasure.Game, players []*model.BasicPlayer,
+	seenAt time.Time) error {
+
+	// The players are usually the game's own, so their ids are replaced too
+	ids := make([]uuid.UUID, len(players))
+	for i, player := range players {
+		ids[i] = player.Id
+	}
+
+	erased, err := erasure.ReplaceErasedPlayers(ctx, p.erasures, p.erasure, game)
+	if err != nil {
+		return err
+	}
+
+	recorded := make([]*model.BasicPlayer, 0, len(players))
+	for i, player := range players {
+		if !erased[ids[i]] {
+			recorded = append(recorded, player)
+		}
+	}
+	p.recordPlayers(ctx, recorded, seenAt)
+
+	return nil
+}
+
+// recordPlayers updates the player directory. Failures are logged rather than failing the message.
+func (p *processor) recordPlayers(ctx context.Context, players []*model.BasicPlayer, seenAt time.Time) {
+	if len(players) == 0 {
+		return
+	}
+
+	if err := p.repo.RecordPlayers(ctx, players, seenAt); err != nil {
+		p.logger.Errorw("failed to record players", "players", players, "error", err)
+	}
+}
@@ -0,0 +1,139 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// recomputeRepo is a recompute in the given phase, or no recompute if checkpoint is nil
+type recomputeRepo struct {
+	repository.Repository
+
+	checkpoint *model.RecomputeCheckpoint
+	claimed    bool
+	target     repository.Repository
+}
+
+func (r *recomputeRepo) GetRecomputeCheckpoint(context.Context) (*model.RecomputeCheckpoint, error) {
+	if r.checkpoint == nil {
+		return nil, repository.ErrNotFound
+	}
+	return r.checkpoint, nil
+}
+
+func (r *recomputeRepo) ClaimRecomputeGame(context.Context, primitive.ObjectID) (bool, error) {
+	if r.claimed {
+		return false, nil
+	}
+	r.claimed = true
+	return true, nil
+}
+
+func (r *recomputeRepo) RecomputeTarget() repository.Repository {
+	return r.target
+}
+
+func TestRecomputeTarget(t *testing.T) {
+	target := &recomputeRepo{}
+
+	tests := []struct {
+		name        string
+		phase       model.RecomputePhase
+		recompute   bool
+		claimed     bool
+		wantTarget  bool
+		wantClaimed bool
+		wantErr     error
+	}{
+		{name: "no recompute"},
+		{name: "applying", recompute: true, phase: model.RecomputeApplying, wantTarget: true, wantClaimed: true},
+		{name: "applying, already applied", recompute: true, phase: model.RecomputeApplying, claimed: true, wantClaimed: true},
+		// The finish transaction is retried once the swap is complete, and records into the swapped in collections
+		{name: "swapping", recompute: true, phase: model.RecomputeSwapping, wantErr: errRecomputeSwapping},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &recomputeRepo{claimed: tt.claimed, target: target}
+			if tt.recompute {
+				repo.checkpoint = &model.RecomputeCheckpoint{Phase: tt.phase}
+			}
+			p := &processor{logger: zap.NewNop().Sugar(), repo: repo}
+
+			game := &model.HistoricGame{Game: &model.Game{Id: primitive.ObjectID{11: 1}}}
+			got, err := p.recomputeTarget(context.Background(), game)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("recomputeTarget() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if repo.claimed != tt.wantClaimed {
+				t.Errorf("claimed = %v, want %v", repo.claimed, tt.wantClaimed)
+			}
+			if (got != nil) != tt.wantTarget {
+				t.Errorf("recomputeTarget() = %v, want target %v", got, tt.wantTarget)
+			}
+		})
+	}
+}
+
+// erasureRepo has the erased players keyed by id hash and records the players added to the player directory
+type erasureRepo struct {
+	repository.Repository
+
+	erased   map[string]uuid.UUID
+	recorded []*model.BasicPlayer
+}
+
+func (r *erasureRepo) GetErasedPlayers(_ context.Context, playerIdHashes []string) (map[string]uuid.UUID, error) {
+	pseudonyms := make(map[string]uuid.UUID)
+	for _, hash := range playerIdHashes {
+		if pseudonym, ok := r.erased[hash]; ok {
+			pseudonyms[hash] = pseudonym
+		}
+	}
+	return pseudonyms, nil
+}
+
+func (r *erasureRepo) RecordPlayers(_ context.Context, players []*model.BasicPlayer, _ time.Time) error {
+	r.recorded = append(r.recorded, players...)
+	return nil
+}
+
+func TestReplaceErasedPlayersFromLiveRepository(t *testing.T) {
+	cfg := config.ErasureConfig{Secret: "secret"}
+	erasedId := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	keptId := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	pseudonym := uuid.MustParse("00000000-0000-0000-0000-0000000000ff")
+
+	// A replay writes to a database without the live database's erasures
+	liveRepo := &erasureRepo{erased: map[string]uuid.UUID{repository.HashPlayerId([]byte(cfg.Secret), erasedId): pseudonym}}
+	target := &erasureRepo{}
+
+	p := newProcessor(zap.NewNop().Sugar(), target, nil, nil, nil, nil, nil, cfg)
+	p.erasures = liveRepo
+
+	players := []*model.BasicPlayer{{Id: erasedId, Username: "erased"}, {Id: keptId, Username: "kept"}}
+	game := &model.HistoricGame{Game: &model.Game{Players: players}}
+
+	if err := p.replaceErasedPlayers(context.Background(), game, players, time.Now()); err != nil {
+		t.Fatalf("replaceErasedPlayers() error = %v", err)
+	}
+
+	if id := game.Players[0].Id; id != pseudonym {
+		t.Errorf("erased player = %s, want pseudonym %s", id, pseudonym)
+	}
+	if id := game.Players[1].Id; id != keptId {
+		t.Errorf("kept player = %s, want %s", id, keptId)
+	}
+	if len(target.recorded) != 1 || target.recorded[0].Id != keptId {
+		t.Errorf("recorded players = %v, want only %s", target.recorded, keptId)
+	}
+}
@@ -0,0 +1,88 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository/model"
+	"github.com/emortalmc/proto-specs/gen/go/nongenerated/kafkautils"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"sync"
+	"time"
+)
+
+// Producer publishes the tracker's own events to the events topic. Events are queued in the outbox
+// when the data they describe is saved, and the outbox relay publishes them with the producer.
+type Producer struct {
+	writer *kafka.Writer
+}
+
+func NewProducer(ctx context.Context, wg *sync.WaitGroup, cfg config.KafkaConfig, logger *zap.SugaredLogger) *Producer {
+	writer := &kafka.Writer{
+		Addr:  kafka.TCP(cfg.Host),
+		Topic: cfg.EventsTopic,
+		// Events are keyed by game so the events of a game are kept in order
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: 100 * time.Millisecond,
+
+		Logger:      kafkautils.CreateLogger(logger),
+		ErrorLogger: kafkautils.CreateErrorLogger(logger),
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := writer.Close(); err != nil {
+			logger.Errorw("failed to close kafka writer", "error", err)
+		}
+	}()
+
+	return &Producer{writer: writer}
+}
+
+// PublishOutboxEvents writes the events in order with the X-Proto-Type header used by every producer
+func (p *Producer) PublishOutboxEvents(ctx context.Context, events []*model.OutboxEvent) error {
+	kMessages := make([]kafka.Message, len(events))
+
+	for i, e := range events {
+		kMessages[i] = kafka.Message{
+			Key: []byte(e.Key),
+			Headers: []kafka.Header{
+				{
+					Key:   "X-Proto-Type",
+					Value: []byte(e.ProtoType),
+				},
+			},
+			Value: e.Payload,
+		}
+	}
+
+	if err := p.writer.WriteMessages(ctx, kMessages...); err != nil {
+		return fmt.Errorf("failed to write messages: %w", err)
+	}
+
+	return nil
+}
+
+// newOutboxEvent encodes a message to be published by the outbox relay
+func newOutboxEvent(key string, m proto.Message, playerIds []uuid.UUID, now time.Time) (*model.OutboxEvent, error) {
+	bytes, err := proto.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return &model.OutboxEvent{
+		Id:        primitive.NewObjectID(),
+		Key:       key,
+		ProtoType: string(m.ProtoReflect().Descriptor().FullName()),
+		Payload:   bytes,
+		PlayerIds: playerIds,
+		CreatedAt: now,
+	}, nil
+}
@@ -0,0 +1,252 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"github.com/emortalmc/proto-specs/gen/go/message/gametracker"
+	"github.com/emortalmc/proto-specs/gen/go/nongenerated/kafkautils"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"time"
+)
+
+type ReplayOptions struct {
+	// From is the produce time to start replaying from. If zero, Offset is used for every partition instead.
+	From time.Time
+	// Offset is the offset to start replaying every partition from, clamped to the oldest retained message
+	Offset int64
+
+	// To stops the replay at messages produced at or after it.
+	// If zero, the replay stops at the last message present when it started.
+	To time.Time
+}
+
+type ReplayResult struct {
+	Messages int64
+	Started  int64
+	Updated  int64
+	// Finished is the number of historic games rebuilt
+	Finished int64
+	Failed   int64
+	// Ignored is the number of messages that weren't game messages
+	Ignored int64
+}
+
+// partitionCursor reads a single partition up to the end offset captured when the replay started
+type partitionCursor struct {
+	partition int
+	reader    *kafka.Reader
+	end       int64
+
+	next *kafka.Message
+	done bool
+}
+
+// Replay reads the game messages in the range and processes them into the repository exactly as the consumer would.
+// It reads partitions directly rather than through a consumer group, so the tracker's committed offsets are untouched.
+// Messages of a game may be spread across partitions, so they are processed in produce time order across all partitions.
+// Erased players are looked up in the live repository, as the messages still have the players that have since been erased.
+func Replay(ctx context.Context, cfg config.KafkaConfig, seasons config.Seasons, erasureCfg config.ErasureConfig, logger *zap.SugaredLogger,
+	liveRepo repository.Repository, repo repository.Repository, opts ReplayOptions) (*ReplayResult, error) {
+
+	cursors, err := openCursors(ctx, cfg, logger, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, c := range cursors {
+			if err := c.reader.Close(); err != nil {
+				logger.Errorw("failed to close kafka reader", "partition", c.partition, "error", err)
+			}
+		}
+	}()
+
+	// Events and webhooks were sent when the games were first consumed and replayed games aren't live
+	p := newProcessor(logger, repo, nil, nil, nil, nil, seasons, erasureCfg)
+	p.erasures = liveRepo
+	result := &ReplayResult{}
+
+	for {
+		var earliest *partitionCursor
+		for _, c := range cursors {
+			if err := c.fill(ctx, opts.To); err != nil {
+				return result, err
+			}
+
+			if c.done {
+				continue
+			}
+			if earliest == nil || c.next.Time.Before(earliest.next.Time) {
+				earliest = c
+			}
+		}
+
+		if earliest == nil {
+			return result, nil
+		}
+
+		msg := earliest.next
+		earliest.next = nil
+
+		result.Messages++
+		p.replayMessage(ctx, msg, result)
+
+		if result.Messages%1000 == 0 {
+			logger.Infow("replaying messages", "messages", result.Messages, "finishedGames", result.Finished,
+				"failed", result.Failed, "time", msg.Time)
+		}
+	}
+}
+
+func openCursors(ctx context.Context, cfg config.KafkaConfig, logger *zap.SugaredLogger, opts ReplayOptions) ([]*partitionCursor, error) {
+	conn, err := kafka.DialContext(ctx, "tcp", cfg.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka: %w", err)
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(gamesTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partitions: %w", err)
+	}
+
+	cursors := make([]*partitionCursor, 0, len(partitions))
+	for _, partition := range partitions {
+		start, end, err := partitionRange(ctx, cfg, partition.ID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read offsets of partition %d: %w", partition.ID, err)
+		}
+
+		if start < 0 || start >= end {
+			logger.Infow("nothing to replay in partition", "partition", partition.ID)
+			continue
+		}
+
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:   []string{cfg.Host},
+			Topic:     gamesTopic,
+			Partition: partition.ID,
+
+			Logger:      kafkautils.CreateLogger(logger),
+			ErrorLogger: kafkautils.CreateErrorLogger(logger),
+		})
+		if err := reader.SetOffset(start); err != nil {
+			return nil, fmt.Errorf("failed to set offset of partition %d: %w", partition.ID, err)
+		}
+
+		logger.Infow("replaying partition", "partition", partition.ID, "startOffset", start, "endOffset", end)
+		cursors = append(cursors, &partitionCursor{partition: partition.ID, reader: reader, end: end})
+	}
+
+	return cursors, nil
+}
+
+// partitionRange returns the offset to start replaying the partition from and the offset after its last message
+func partitionRange(ctx context.Context, cfg config.KafkaConfig, partition int, opts ReplayOptions) (int64, int64, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", cfg.Host, gamesTopic, partition)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	first, last, err := conn.ReadOffsets()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if opts.From.IsZero() {
+		return max(opts.Offset, first), last, nil
+	}
+
+	start, err := conn.ReadOffset(opts.From)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return start, last, nil
+}
+
+// fill fetches the cursor's next message if it doesn't have one, marking it done once the end is reached
+func (c *partitionCursor) fill(ctx context.Context, to time.Time) error {
+	if c.done || c.next != nil {
+		return nil
+	}
+
+	if c.reader.Offset() >= c.end {
+		c.done = true
+		return nil
+	}
+
+	msg, err := c.reader.FetchMessage(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read partition %d: %w", c.partition, err)
+	}
+
+	if msg.Offset >= c.end || (!to.IsZero() && !msg.Time.Before(to)) {
+		c.done = true
+		return nil
+	}
+
+	c.next = &msg
+	return nil
+}
+
+// replayMessage decodes and processes a message, counting the outcome. Failures are logged and don't stop the replay.
+func (p *processor) replayMessage(ctx context.Context, msg *kafka.Message, result *ReplayResult) {
+	decoded, err := decodeMessage(msg)
+	if err != nil {
+		result.Failed++
+		p.logger.Errorw("failed to decode message", "partition", msg.Partition, "offset", msg.Offset, "error", err)
+		return
+	}
+
+	switch m := decoded.(type) {
+	case *gametracker.GameStartMessage:
+		err = p.processGameStart(ctx, m, msg.Time)
+		if err == nil {
+			result.Started++
+		}
+	case *gametracker.GameUpdateMessage:
+		err = p.processGameUpdate(ctx, m, msg.Time)
+		if err == nil {
+			result.Updated++
+		}
+	case *gametracker.GameFinishMessage:
+		err = p.processGameFinish(ctx, m, msg.Time)
+		if err == nil {
+			result.Finished++
+		}
+	default:
+		result.Ignored++
+	}
+
+	if err != nil {
+		result.Failed++
+		p.logger.Errorw("failed to replay message", "partition", msg.Partition, "offset", msg.Offset, "error", err)
+	}
+}
+
+// decodeMessage decodes a message using its X-Proto-Type header in the same way as kafkautils.ConsumerHandler
+func decodeMessage(msg *kafka.Message) (proto.Message, error) {
+	protoTypeName, err := kafkautils.ProtoTypeFromHeaders(msg.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	protoType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(protoTypeName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find proto type %s: %w", protoTypeName, err)
+	}
+
+	decoded := protoType.New().Interface()
+	if err := proto.Unmarshal(msg.Value, decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", protoTypeName, err)
+	}
+
+	return decoded, nil
+}
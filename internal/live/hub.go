@@ -0,0 +1,131 @@
+package live
+
+import (
+	"context"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"sync"
+)
+
+// subscriberBufferSize is the number of events a subscriber may fall behind by before it is disconnected
+const subscriberBufferSize = 64
+
+type EventType string
+
+const (
+	EventCreated  EventType = "created"
+	EventUpdated  EventType = "updated"
+	EventFinished EventType = "finished"
+)
+
+// Event is a change to a live game. LiveGame is set for created and updated events, HistoricGame for finished events.
+type Event struct {
+	Type         EventType
+	LiveGame     *model.LiveGame
+	HistoricGame *model.HistoricGame
+}
+
+func (e *Event) Game() *model.Game {
+	if e.HistoricGame != nil {
+		return e.HistoricGame.Game
+	}
+
+	return e.LiveGame.Game
+}
+
+// Filter narrows down the events a subscriber receives. Zero value fields are ignored.
+type Filter struct {
+	GameModeId string
+	ServerId   string
+	PlayerId   uuid.UUID
+}
+
+func (f Filter) Matches(e *Event) bool {
+	game := e.Game()
+
+	if f.GameModeId != "" && game.GameModeId != f.GameModeId {
+		return false
+	}
+	if f.ServerId != "" && game.ServerId != f.ServerId {
+		return false
+	}
+
+	if f.PlayerId != uuid.Nil {
+		for _, p := range game.Players {
+			if p.Id == f.PlayerId {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+type subscription struct {
+	filter Filter
+	events chan *Event
+}
+
+// Hub fans live game events out to subscribers
+type Hub struct {
+	logger *zap.SugaredLogger
+
+	mu            sync.Mutex
+	subscriptions map[*subscription]struct{}
+}
+
+func NewHub(logger *zap.SugaredLogger) *Hub {
+	return &Hub{
+		logger:        logger,
+		subscriptions: make(map[*subscription]struct{}),
+	}
+}
+
+// Subscribe returns a channel of events matching the filter. The channel is closed when the context is cancelled,
+// or early if the subscriber falls too far behind, in which case it should resubscribe and reload the games.
+func (h *Hub) Subscribe(ctx context.Context, filter Filter) <-chan *Event {
+	sub := &subscription{filter: filter, events: make(chan *Event, subscriberBufferSize)}
+
+	h.mu.Lock()
+	h.subscriptions[sub] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		h.unsubscribe(sub)
+		h.mu.Unlock()
+	}()
+
+	return sub.events
+}
+
+// unsubscribe must be called with the lock held
+func (h *Hub) unsubscribe(sub *subscription) {
+	if _, ok := h.subscriptions[sub]; !ok {
+		return
+	}
+
+	delete(h.subscriptions, sub)
+	close(sub.events)
+}
+
+func (h *Hub) Publish(e *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscriptions {
+		if !sub.filter.Matches(e) {
+			continue
+		}
+
+		select {
+		case sub.events <- e:
+		default:
+			h.logger.Warnw("disconnecting slow live game subscriber", "filter", sub.filter)
+			h.unsubscribe(sub)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package live
+
+import (
+	"context"
+	"game-tracker/internal/repository"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+const maxWatchBackoff = 30 * time.Second
+
+// WatchRepository publishes the game changes made by every tracker replica to the hub using a change stream.
+// Only historic games that finished a live game are published as finished, so imports and archive restores are ignored.
+func WatchRepository(ctx context.Context, wg *sync.WaitGroup, logger *zap.SugaredLogger, repo repository.Repository, hub *Hub) {
+	handle := func(change *repository.GameChange) {
+		if change.HistoricGame != nil {
+			if change.Finished {
+				hub.Publish(&Event{Type: EventFinished, HistoricGame: change.HistoricGame})
+			}
+			return
+		}
+
+		eventType := EventUpdated
+		if change.Created {
+			eventType = EventCreated
+		}
+		hub.Publish(&Event{Type: eventType, LiveGame: change.LiveGame})
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		backoff := time.Second
+		for {
+			start := time.Now()
+			err := repo.WatchGames(ctx, handle)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if time.Since(start) > time.Minute {
+				backoff = time.Second
+			}
+
+			// Changes made while the stream is down are missed, subscribers catch up with the next update
+			logger.Errorw("live game change stream stopped, restarting", "retryIn", backoff, "error", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, maxWatchBackoff)
+		}
+	}()
+}
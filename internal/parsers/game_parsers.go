@@ -7,9 +7,11 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// The live parsers run after LastUpdated has been set to the time of the message being handled
+
 func handleTowerDefenceStartData(m proto.Message, g *model.LiveGame) error {
 	cast := m.(*pbmodel.TowerDefenceStartData)
-	g.SetGameData(model.CreateLiveTowerDefenceDataFromStart(cast))
+	g.SetGameData(model.CreateLiveTowerDefenceDataFromStart(cast, g.GameTime(g.LastUpdated)))
 
 	return nil
 }
@@ -17,7 +19,7 @@ func handleTowerDefenceStartData(m proto.Message, g *model.LiveGame) error {
 func handleTowerDefenceUpdateData(m proto.Message, g *model.LiveGame) error {
 	cast := m.(*pbmodel.TowerDefenceUpdateData)
 
-	(g.GameData).(*model.LiveTowerDefenceData).Update(cast)
+	(g.GameData).(*model.LiveTowerDefenceData).Update(cast, g.GameTime(g.LastUpdated))
 
 	return nil
 }
@@ -25,7 +27,7 @@ func handleTowerDefenceUpdateData(m proto.Message, g *model.LiveGame) error {
 func handleTowerDefenceFinishData(m proto.Message, g *model.HistoricGame) error {
 	cast := m.(*pbmodel.TowerDefenceFinishData)
 
-	g.GameData = model.CreateHistoricTowerDefenceDataFromFinish(cast)
+	g.SetGameData(model.CreateHistoricTowerDefenceDataFromFinish(cast))
 
 	return nil
 }
@@ -61,7 +63,7 @@ func handleBlockSumoFinishData(m proto.Message, g *model.HistoricGame) error {
 		return fmt.Errorf("failed to create historic block sumo data: %w", err)
 	}
 
-	g.GameData = data
+	g.SetGameData(data)
 
 	return nil
 }
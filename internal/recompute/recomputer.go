@@ -0,0 +1,262 @@
+package recompute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	batchSize = 100
+	// logInterval is the number of games between progress logs
+	logInterval = 5000
+)
+
+// consumerWait is longer than a consumer's finish transaction can run, so after it every consumer has either
+// committed the games it was finishing or seen the recompute's change of phase
+var consumerWait = time.Minute
+
+// Recomputer rebuilds the player stats, streaks, pairs, leaderboards and ratings from historic games after their
+// formulas change. Games are applied into separate collections, which are swapped in once every game is applied.
+//
+// Consumers keep recording into the live collections meanwhile, and record the games they finish into the recompute
+// collections too. Each game is claimed in the transaction that applies it, so it is applied exactly once.
+// Games are walked in the order they finished, then the games the walk missed because they were saved behind it,
+// and not recorded by a consumer, are caught up in id order. Consumers pause finishing games during the swap.
+type Recomputer struct {
+	logger  *zap.SugaredLogger
+	repo    repository.Repository
+	target  repository.Repository
+	seasons config.Seasons
+}
+
+func NewRecomputer(logger *zap.SugaredLogger, repo repository.Repository, seasons config.Seasons) *Recomputer {
+	return &Recomputer{
+		logger:  logger,
+		repo:    repo,
+		target:  repo.RecomputeTarget(),
+		seasons: seasons,
+	}
+}
+
+// Run applies every historic game and swaps in the rebuilt collections. If resume is true and a previous run
+// was interrupted, it continues after the last game that run walked. An interrupted swap is always resumed.
+func (r *Recomputer) Run(ctx context.Context, resume bool) (*model.RecomputeCheckpoint, error) {
+	// Without transactions a game could be applied by both the recompute and a consumer
+	if !r.repo.SupportsTransactions() {
+		return nil, errors.New("recomputes require transactions, which require mongo to run as a replica set")
+	}
+
+	checkpoint, err := r.start(ctx, resume)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint.Phase == model.RecomputeApplying {
+		if checkpoint, err = r.walk(ctx, checkpoint); err != nil {
+			return checkpoint, err
+		}
+		if checkpoint, err = r.catchUp(ctx, checkpoint); err != nil {
+			return checkpoint, err
+		}
+
+		next := *checkpoint
+		next.Phase = model.RecomputeSwapping
+		next.UpdatedAt = time.Now()
+		if err := r.repo.SaveRecomputeCheckpoint(ctx, &next); err != nil {
+			return checkpoint, err
+		}
+		checkpoint = &next
+	}
+
+	// Consumers that haven't seen the swap yet could still be recording into the collections being swapped
+	r.logger.Infow("applied every game, pausing consumers' stats to swap in recomputed collections",
+		"games", checkpoint.Games)
+	if err := wait(ctx, consumerWait); err != nil {
+		return checkpoint, err
+	}
+
+	if err := r.repo.SwapRecompute(ctx); err != nil {
+		return checkpoint, err
+	}
+
+	return checkpoint, nil
+}
+
+// start returns the checkpoint to continue from, starting a fresh recompute if there isn't one
+func (r *Recomputer) start(ctx context.Context, resume bool) (*model.RecomputeCheckpoint, error) {
+	checkpoint, err := r.repo.GetRecomputeCheckpoint(ctx)
+	switch {
+	case err == nil && checkpoint.Phase == model.RecomputeSwapping:
+		// Starting over would drop the collections that haven't been swapped in yet
+		r.logger.Infow("resuming interrupted swap", "startedAt", checkpoint.StartedAt, "games", checkpoint.Games)
+		return checkpoint, nil
+	case err == nil && resume:
+		r.logger.Infow("resuming recompute", "startedAt", checkpoint.StartedAt, "games", checkpoint.Games,
+			"lastEndTime", checkpoint.LastEndTime, "walked", checkpoint.Walked)
+		return checkpoint, nil
+	case err != nil && !errors.Is(err, repository.ErrNotFound):
+		return nil, err
+	}
+
+	now := time.Now()
+	checkpoint = &model.RecomputeCheckpoint{Phase: model.RecomputeApplying, StartedAt: now, UpdatedAt: now}
+	if err := r.repo.StartRecompute(ctx, checkpoint); err != nil {
+		return nil, err
+	}
+	r.logger.Infow("starting recompute")
+
+	return checkpoint, nil
+}
+
+// walk applies the games in the order they finished, saving the checkpoint in the same transaction as each batch
+func (r *Recomputer) walk(ctx context.Context, checkpoint *model.RecomputeCheckpoint) (*model.RecomputeCheckpoint, error) {
+	started := time.Now()
+	startGames := checkpoint.Games
+
+	for !checkpoint.Walked {
+		var next model.RecomputeCheckpoint
+		err := r.repo.WithTransaction(ctx, func(ctx context.Context) error {
+			// The transaction may be retried, so the checkpoint is rebuilt each time
+			next = *checkpoint
+			next.UpdatedAt = time.Now()
+
+			games, err := r.repo.ListHistoricGamesAfter(ctx, checkpoint.LastEndTime, checkpoint.LastGameId, batchSize)
+			if err != nil {
+				return err
+			}
+			if len(games) == 0 {
+				next.Walked = true
+				return r.repo.SaveRecomputeCheckpoint(ctx, &next)
+			}
+
+			for _, game := range games {
+				applied, err := r.apply(ctx, game)
+				if err != nil {
+					return fmt.Errorf("failed to apply game %s: %w", game.Id.Hex(), err)
+				}
+				if applied {
+					next.Games++
+				}
+			}
+
+			last := games[len(games)-1]
+			next.LastEndTime = last.EndTime
+			next.LastGameId = last.Id
+			return r.repo.SaveRecomputeCheckpoint(ctx, &next)
+		})
+		if err != nil {
+			return checkpoint, err
+		}
+
+		if next.Games/logInterval != checkpoint.Games/logInterval {
+			rate := float64(next.Games-startGames) / time.Since(started).Seconds()
+			r.logger.Infow("recomputing", "games", next.Games, "lastEndTime", next.LastEndTime,
+				"gamesPerSecond", int64(rate))
+		}
+		checkpoint = &next
+	}
+
+	return checkpoint, nil
+}
+
+// catchUp applies, in id order, the games the walk missed because they were saved behind it. Consumers claim the
+// games they finish in the transaction saving them, so a game is left unclaimed only if its consumer started
+// finishing it before the recompute started. The games are listed after consumerWait, by when those have committed.
+func (r *Recomputer) catchUp(ctx context.Context, checkpoint *model.RecomputeCheckpoint) (*model.RecomputeCheckpoint, error) {
+	if err := wait(ctx, consumerWait); err != nil {
+		return checkpoint, err
+	}
+
+	ids, err := r.repo.ListUnclaimedRecomputeGames(ctx)
+	if err != nil {
+		return checkpoint, err
+	}
+	if len(ids) == 0 {
+		return checkpoint, nil
+	}
+
+	r.logger.Infow("catching up games the walk missed", "games", len(ids))
+
+	for start := 0; start < len(ids); start += batchSize {
+		batch := ids[start:min(start+batchSize, len(ids))]
+
+		var next model.RecomputeCheckpoint
+		err := r.repo.WithTransaction(ctx, func(ctx context.Context) error {
+			next = *checkpoint
+			next.UpdatedAt = time.Now()
+
+			for _, id := range batch {
+				applied, err := r.applyId(ctx, id)
+				if err != nil {
+					return fmt.Errorf("failed to apply game %s: %w", id.Hex(), err)
+				}
+				if applied {
+					next.Games++
+				}
+			}
+
+			return r.repo.SaveRecomputeCheckpoint(ctx, &next)
+		})
+		if err != nil {
+			return checkpoint, err
+		}
+		checkpoint = &next
+	}
+
+	return checkpoint, nil
+}
+
+// applyId applies the game with the id, unless it has been deleted since it was listed
+func (r *Recomputer) applyId(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	game, err := r.repo.GetHistoricGame(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return r.apply(ctx, game)
+}
+
+// apply claims the game and records it exactly as the consumer does when it finishes, without unlocking achievements.
+// It returns false if the game was already claimed by a consumer.
+func (r *Recomputer) apply(ctx context.Context, game *model.HistoricGame) (bool, error) {
+	claimed, err := r.repo.ClaimRecomputeGame(ctx, game.Id)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	seasonId := r.seasons.IdAt(game.EndTime)
+
+	if err := r.target.RecordPlayerPairs(ctx, game, seasonId); err != nil {
+		return false, err
+	}
+	if err := r.target.RecordLeaderboardScores(ctx, game, seasonId); err != nil {
+		return false, err
+	}
+	if _, err := r.target.RecordPlayerRatings(ctx, game, seasonId); err != nil {
+		return false, err
+	}
+	if _, err := r.target.RecordPlayerStats(ctx, game, seasonId); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,281 @@
+package recompute
+
+import (
+	"context"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+// recomputeRepo is an in-memory recompute. The games applied to the recompute collections are recorded in order.
+type recomputeRepo struct {
+	repository.Repository
+
+	transactions bool
+	games        []*model.HistoricGame
+	claimed      map[primitive.ObjectID]bool
+	checkpoint   *model.RecomputeCheckpoint
+	applied      []primitive.ObjectID
+	swapped      bool
+
+	// onWalk is called after the first batch of games is walked, standing in for a consumer saving a game meanwhile
+	onWalk func(r *recomputeRepo)
+}
+
+func (r *recomputeRepo) SupportsTransactions() bool {
+	return r.transactions
+}
+
+func (r *recomputeRepo) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (r *recomputeRepo) StartRecompute(_ context.Context, checkpoint *model.RecomputeCheckpoint) error {
+	r.claimed = make(map[primitive.ObjectID]bool)
+	r.applied = nil
+	r.checkpoint = checkpoint
+	return nil
+}
+
+func (r *recomputeRepo) GetRecomputeCheckpoint(context.Context) (*model.RecomputeCheckpoint, error) {
+	if r.checkpoint == nil {
+		return nil, repository.ErrNotFound
+	}
+	checkpoint := *r.checkpoint
+	return &checkpoint, nil
+}
+
+func (r *recomputeRepo) SaveRecomputeCheckpoint(_ context.Context, checkpoint *model.RecomputeCheckpoint) error {
+	if r.checkpoint == nil {
+		return repository.ErrNotFound
+	}
+	saved := *checkpoint
+	r.checkpoint = &saved
+	return nil
+}
+
+func (r *recomputeRepo) ListHistoricGamesAfter(_ context.Context, endTime time.Time, id primitive.ObjectID,
+	limit int64) ([]*model.HistoricGame, error) {
+
+	games := slices.Clone(r.games)
+	sort.Slice(games, func(i, j int) bool {
+		if !games[i].EndTime.Equal(games[j].EndTime) {
+			return games[i].EndTime.Before(games[j].EndTime)
+		}
+		return games[i].Id.Hex() < games[j].Id.Hex()
+	})
+
+	after := make([]*model.HistoricGame, 0)
+	for _, g := range games {
+		if g.EndTime.After(endTime) || (g.EndTime.Equal(endTime) && g.Id.Hex() > id.Hex()) {
+			after = append(after, g)
+		}
+	}
+
+	if r.onWalk != nil {
+		r.onWalk(r)
+		r.onWalk = nil
+	}
+
+	return after[:min(int64(len(after)), limit)], nil
+}
+
+func (r *recomputeRepo) ListUnclaimedRecomputeGames(context.Context) ([]primitive.ObjectID, error) {
+	ids := make([]primitive.ObjectID, 0)
+	for _, g := range r.games {
+		if !r.claimed[g.Id] {
+			ids = append(ids, g.Id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b primitive.ObjectID) int {
+		return slices.Compare(a[:], b[:])
+	})
+
+	return ids, nil
+}
+
+func (r *recomputeRepo) GetHistoricGame(_ context.Context, id primitive.ObjectID) (*model.HistoricGame, error) {
+	for _, g := range r.games {
+		if g.Id == id {
+			return g, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *recomputeRepo) ClaimRecomputeGame(_ context.Context, gameId primitive.ObjectID) (bool, error) {
+	if r.claimed[gameId] {
+		return false, nil
+	}
+	r.claimed[gameId] = true
+	return true, nil
+}
+
+func (r *recomputeRepo) RecomputeTarget() repository.Repository {
+	return &recomputeTarget{repo: r}
+}
+
+func (r *recomputeRepo) SwapRecompute(context.Context) error {
+	r.swapped = true
+	r.checkpoint = nil
+	return nil
+}
+
+// recomputeTarget records the games applied to the recompute collections
+type recomputeTarget struct {
+	repository.Repository
+
+	repo *recomputeRepo
+}
+
+func (t *recomputeTarget) RecordPlayerPairs(context.Context, *model.HistoricGame, string) error {
+	return nil
+}
+
+func (t *recomputeTarget) RecordLeaderboardScores(context.Context, *model.HistoricGame, string) error {
+	return nil
+}
+
+func (t *recomputeTarget) RecordPlayerRatings(context.Context, *model.HistoricGame, string) ([]*model.PlayerRating, error) {
+	return nil, nil
+}
+
+func (t *recomputeTarget) RecordPlayerStats(_ context.Context, game *model.HistoricGame,
+	_ string) ([]*model.PlayerStats, error) {
+
+	t.repo.applied = append(t.repo.applied, game.Id)
+	return nil, nil
+}
+
+func TestRun(t *testing.T) {
+	consumerWait = 0
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	game := func(id byte, endMinutes int) *model.HistoricGame {
+		return &model.HistoricGame{
+			Game:    &model.Game{Id: primitive.ObjectID{11: id}},
+			EndTime: start.Add(time.Duration(endMinutes) * time.Minute),
+		}
+	}
+	a, b, c := game(1, 30), game(2, 10), game(3, 20)
+	// late is saved during the walk but finished before the games already walked
+	late := game(4, 5)
+
+	tests := []struct {
+		name         string
+		transactions bool
+		resume       bool
+		checkpoint   *model.RecomputeCheckpoint
+		claimed      []primitive.ObjectID
+		onWalk       func(r *recomputeRepo)
+
+		wantApplied []primitive.ObjectID
+		wantGames   int64
+		wantErr     bool
+	}{
+		{
+			name:         "walks in end time order",
+			transactions: true,
+			wantApplied:  []primitive.ObjectID{b.Id, c.Id, a.Id},
+			wantGames:    3,
+		},
+		{
+			name:         "catches up games saved behind the walk",
+			transactions: true,
+			onWalk: func(r *recomputeRepo) {
+				r.games = append(r.games, late)
+			},
+			wantApplied: []primitive.ObjectID{b.Id, c.Id, a.Id, late.Id},
+			wantGames:   4,
+		},
+		{
+			name:         "skips games claimed by a consumer",
+			transactions: true,
+			onWalk: func(r *recomputeRepo) {
+				r.games = append(r.games, late)
+				r.claimed[late.Id] = true
+				r.claimed[c.Id] = true
+			},
+			wantApplied: []primitive.ObjectID{b.Id, a.Id},
+			wantGames:   2,
+		},
+		{
+			name:         "resumes after the last game walked",
+			transactions: true,
+			resume:       true,
+			checkpoint: &model.RecomputeCheckpoint{Phase: model.RecomputeApplying, LastEndTime: c.EndTime,
+				LastGameId: c.Id, Games: 2},
+			claimed:     []primitive.ObjectID{b.Id, c.Id},
+			wantApplied: []primitive.ObjectID{a.Id},
+			wantGames:   3,
+		},
+		{
+			name:         "fresh ignores the checkpoint",
+			transactions: true,
+			checkpoint: &model.RecomputeCheckpoint{Phase: model.RecomputeApplying, LastEndTime: c.EndTime,
+				LastGameId: c.Id, Games: 2},
+			claimed:     []primitive.ObjectID{b.Id, c.Id},
+			wantApplied: []primitive.ObjectID{b.Id, c.Id, a.Id},
+			wantGames:   3,
+		},
+		{
+			name:         "resumes an interrupted swap even if fresh",
+			transactions: true,
+			checkpoint:   &model.RecomputeCheckpoint{Phase: model.RecomputeSwapping, Walked: true, Games: 3},
+			claimed:      []primitive.ObjectID{a.Id, b.Id, c.Id},
+			wantGames:    3,
+		},
+		{
+			name:    "requires transactions",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &recomputeRepo{
+				transactions: tt.transactions,
+				games:        []*model.HistoricGame{a, b, c},
+				claimed:      make(map[primitive.ObjectID]bool),
+				checkpoint:   tt.checkpoint,
+				onWalk:       tt.onWalk,
+			}
+			for _, id := range tt.claimed {
+				repo.claimed[id] = true
+			}
+
+			r := NewRecomputer(zap.NewNop().Sugar(), repo, config.Seasons{})
+			checkpoint, err := r.Run(context.Background(), tt.resume)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if repo.swapped {
+					t.Error("swapped after failing")
+				}
+				return
+			}
+
+			if !slices.Equal(repo.applied, tt.wantApplied) {
+				t.Errorf("applied %v, want %v", repo.applied, tt.wantApplied)
+			}
+			if checkpoint.Games != tt.wantGames {
+				t.Errorf("Games = %d, want %d", checkpoint.Games, tt.wantGames)
+			}
+			if !repo.swapped {
+				t.Error("didn't swap")
+			}
+			for _, g := range repo.games {
+				if !repo.claimed[g.Id] {
+					t.Errorf("game %s left unclaimed", g.Id.Hex())
+				}
+			}
+		})
+	}
+}
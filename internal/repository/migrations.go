@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"game-tracker/internal/repository/registrytypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const schemaVersionField = "schemaVersion"
+
+// migration upgrades a raw game document from Version-1 to Version.
+// Migrations must be idempotent as a document may be upgraded in memory on read many times before it is persisted.
+type migration struct {
+	Version     int32
+	Description string
+	Up          func(doc bson.M) error
+}
+
+// migrations must be ordered by version and the last version must equal model.CurrentSchemaVersion.
+var migrations = []migration{
+	{
+		Version:     1,
+		Description: "set gameDataType on historic games",
+		Up:          backfillHistoricGameDataType,
+	},
+	{
+		Version:     2,
+		Description: "set duration on historic games",
+		Up:          backfillHistoricGameDuration,
+	},
+}
+
+// backfillHistoricGameDataType sets the gameDataType of historic games saved before it was written.
+// Live games have always had the type set, so a missing type means the document is a historic game.
+func backfillHistoricGameDataType(doc bson.M) error {
+	if _, ok := doc["gameDataType"]; ok {
+		return nil
+	}
+
+	data, ok := doc["gameData"].(bson.M)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := data["maxHealth"]; ok {
+		doc["gameDataType"] = model.HistoricTowerDefenceDataId
+		return nil
+	}
+
+	if _, ok := data["scoreboard"]; ok {
+		doc["gameDataType"] = model.HistoricBlockSumoDataId
+		return nil
+	}
+
+	return fmt.Errorf("unable to infer game data type of game %v", doc["_id"])
+}
+
+// backfillHistoricGameDuration computes the duration of historic games saved before it was written.
+func backfillHistoricGameDuration(doc bson.M) error {
+	if _, ok := doc["duration"]; ok {
+		return nil
+	}
+
+	startTime, ok := doc["startTime"].(primitive.DateTime)
+	if !ok {
+		return nil
+	}
+
+	endTime, ok := doc["endTime"].(primitive.DateTime)
+	if !ok {
+		return nil
+	}
+
+	doc["duration"] = int64(endTime.Time().Sub(startTime.Time()))
+	return nil
+}
+
+func documentSchemaVersion(doc bson.M) int32 {
+	switch v := doc[schemaVersionField].(type) {
+	case int32:
+		return v
+	case int64:
+		return int32(v)
+	case float64:
+		return int32(v)
+	default:
+		return 0
+	}
+}
+
+// upgradeDocument applies every migration newer than the document's schema version.
+// It returns false if the document was already up-to-date.
+func upgradeDocument(doc bson.M) (bool, error) {
+	version := documentSchemaVersion(doc)
+	if version >= model.CurrentSchemaVersion {
+		return false, nil
+	}
+
+	for _, mig := range migrations {
+		if mig.Version <= version {
+			continue
+		}
+
+		if err := mig.Up(doc); err != nil {
+			return false, fmt.Errorf("failed to apply migration %d (%s): %w", mig.Version, mig.Description, err)
+		}
+		version = mig.Version
+	}
+
+	doc[schemaVersionField] = version
+	return true, nil
+}
+
+// decodeGame decodes a raw game document, upgrading it in memory first if it was stored with an older schema.
+func decodeGame(raw bson.Raw, v interface{}) error {
+	version, ok := raw.Lookup(schemaVersionField).Int32OK()
+	if !ok || version < model.CurrentSchemaVersion {
+		var doc bson.M
+		if err := bson.Unmarshal(raw, &doc); err != nil {
+			return fmt.Errorf("failed to unmarshal document: %w", err)
+		}
+
+		if _, err := upgradeDocument(doc); err != nil {
+			return fmt.Errorf("failed to upgrade document: %w", err)
+		}
+
+		upgraded, err := bson.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("failed to marshal upgraded document: %w", err)
+		}
+		raw = upgraded
+	}
+
+	dec, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to create decoder: %w", err)
+	}
+
+	if err := dec.SetRegistry(registrytypes.CodecRegistry); err != nil {
+		return fmt.Errorf("failed to set registry: %w", err)
+	}
+
+	return dec.Decode(v)
+}
+
+func (m *mongoRepository) MigrateGames(ctx context.Context, batchSize int) (int, error) {
+	total := 0
+	for _, coll := range []*mongo.Collection{m.liveGameCollection, m.historicGameCollection} {
+		count, err := m.migrateCollection(ctx, coll, batchSize)
+		total += count
+		if err != nil {
+			return total, fmt.Errorf("failed to migrate %s: %w", coll.Name(), err)
+		}
+	}
+
+	return total, nil
+}
+
+func (m *mongoRepository) migrateCollection(ctx context.Context, coll *mongo.Collection, batchSize int) (int, error) {
+	outdated := bson.M{schemaVersionField: bson.M{"$not": bson.M{"$gte": model.CurrentSchemaVersion}}}
+
+	cursor, err := coll.Find(ctx, outdated, options.Find().SetBatchSize(int32(batchSize)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find outdated games: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	writes := make([]mongo.WriteModel, 0, batchSize)
+
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		result, err := coll.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return fmt.Errorf("failed to write migrated games: %w", err)
+		}
+
+		migrated += int(result.ModifiedCount)
+		m.logger.Infow("migrated batch of games", "collection", coll.Name(), "batchSize", len(writes), "total", migrated)
+		writes = writes[:0]
+		return nil
+	}
+
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return migrated, fmt.Errorf("failed to decode game: %w", err)
+		}
+
+		changed, err := upgradeDocument(doc)
+		if err != nil {
+			m.logger.Errorw("failed to upgrade game, skipping", "collection", coll.Name(), "gameId", doc["_id"], "error", err)
+			continue
+		}
+		if !changed {
+			continue
+		}
+
+		// Only replace documents that haven't been written by the consumer in the meantime
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": doc["_id"], schemaVersionField: outdated[schemaVersionField]}).
+			SetReplacement(doc))
+
+		if len(writes) >= batchSize {
+			if err := flush(); err != nil {
+				return migrated, err
+			}
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return migrated, fmt.Errorf("failed to iterate games: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return migrated, err
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+func TestMigrationsEndAtCurrentVersion(t *testing.T) {
+	for i, mig := range migrations {
+		if mig.Version != int32(i+1) {
+			t.Errorf("migration %d has version %d, want %d", i, mig.Version, i+1)
+		}
+	}
+
+	if last := migrations[len(migrations)-1].Version; last != model.CurrentSchemaVersion {
+		t.Errorf("last migration is version %d, want model.CurrentSchemaVersion %d", last, model.CurrentSchemaVersion)
+	}
+}
+
+func TestUpgradeDocument(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+
+	tests := []struct {
+		name        string
+		doc         bson.M
+		wantChanged bool
+		wantErr     bool
+		want        bson.M
+	}{
+		{
+			name: "unversioned tower defence game",
+			doc: bson.M{"gameData": bson.M{"maxHealth": int32(100)},
+				"startTime": primitive.NewDateTimeFromTime(start), "endTime": primitive.NewDateTimeFromTime(end)},
+			wantChanged: true,
+			want: bson.M{"gameDataType": model.HistoricTowerDefenceDataId, "duration": int64(10 * time.Minute),
+				schemaVersionField: model.CurrentSchemaVersion},
+		},
+		{
+			name:        "unversioned block sumo game without a start time",
+			doc:         bson.M{"gameData": bson.M{"scoreboard": bson.M{}}, "endTime": primitive.NewDateTimeFromTime(end)},
+			wantChanged: true,
+			want: bson.M{"gameDataType": model.HistoricBlockSumoDataId,
+				schemaVersionField: model.CurrentSchemaVersion},
+		},
+		{
+			name: "version 1 keeps its game data type",
+			doc: bson.M{schemaVersionField: int32(1), "gameDataType": int32(99),
+				"startTime": primitive.NewDateTimeFromTime(start), "endTime": primitive.NewDateTimeFromTime(end)},
+			wantChanged: true,
+			want: bson.M{"gameDataType": int32(99), "duration": int64(10 * time.Minute),
+				schemaVersionField: model.CurrentSchemaVersion},
+		},
+		{
+			name:        "existing duration is kept",
+			doc:         bson.M{"gameDataType": int32(1), "duration": int64(5)},
+			wantChanged: true,
+			want:        bson.M{"duration": int64(5), schemaVersionField: model.CurrentSchemaVersion},
+		},
+		{
+			name: "version stored as a double",
+			doc:  bson.M{schemaVersionField: float64(model.CurrentSchemaVersion)},
+		},
+		{
+			name: "current version is untouched",
+			doc:  bson.M{schemaVersionField: model.CurrentSchemaVersion},
+		},
+		{
+			name:    "unknown game data",
+			doc:     bson.M{"gameData": bson.M{"other": true}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := upgradeDocument(tt.doc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("upgradeDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("upgradeDocument() = %v, want %v", changed, tt.wantChanged)
+			}
+
+			for key, want := range tt.want {
+				if got := tt.doc[key]; got != want {
+					t.Errorf("%s = %v (%T), want %v (%T)", key, got, got, want, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeGame(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+	id := primitive.ObjectID{11: 1}
+
+	tests := []struct {
+		name         string
+		doc          bson.M
+		wantDuration time.Duration
+		wantDataType int32
+		wantErr      bool
+	}{
+		{
+			name: "unversioned game is upgraded",
+			doc: bson.M{"_id": id, "gameModeId": "towerdefence", "gameData": bson.M{"maxHealth": int32(100)},
+				"startTime": start, "endTime": end},
+			wantDuration: 10 * time.Minute,
+			wantDataType: model.HistoricTowerDefenceDataId,
+		},
+		{
+			name: "current game is decoded as stored",
+			doc: bson.M{"_id": id, "gameModeId": "blocksumo", schemaVersionField: model.CurrentSchemaVersion,
+				"gameDataType": model.HistoricBlockSumoDataId, "startTime": start, "endTime": end,
+				"duration": int64(time.Minute)},
+			wantDuration: time.Minute,
+			wantDataType: model.HistoricBlockSumoDataId,
+		},
+		{
+			name:    "game that can't be upgraded",
+			doc:     bson.M{"_id": id, "gameData": bson.M{"other": true}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := bson.Marshal(tt.doc)
+			if err != nil {
+				t.Fatalf("failed to marshal document: %v", err)
+			}
+
+			var game model.HistoricGame
+			err = decodeGame(raw, &game)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeGame() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if game.Id != id {
+				t.Errorf("Id = %s, want %s", game.Id.Hex(), id.Hex())
+			}
+			if game.SchemaVersion != model.CurrentSchemaVersion {
+				t.Errorf("SchemaVersion = %d, want %d", game.SchemaVersion, model.CurrentSchemaVersion)
+			}
+			if game.Duration != tt.wantDuration {
+				t.Errorf("Duration = %s, want %s", game.Duration, tt.wantDuration)
+			}
+			if game.GameDataType != tt.wantDataType {
+				t.Errorf("GameDataType = %d, want %d", game.GameDataType, tt.wantDataType)
+			}
+			if !game.EndTime.Equal(end) {
+				t.Errorf("EndTime = %s, want %s", game.EndTime, end)
+			}
+		})
+	}
+}
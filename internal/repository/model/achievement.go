@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// PlayerAchievement is an achievement unlocked by a player. Each achievement can only be unlocked once per player.
+type PlayerAchievement struct {
+	Id            primitive.ObjectID `bson:"_id"`
+	PlayerId      uuid.UUID          `bson:"playerId"`
+	AchievementId string             `bson:"achievementId"`
+
+	// GameId is the game the achievement was unlocked in
+	GameId     primitive.ObjectID `bson:"gameId"`
+	GameModeId string             `bson:"gameModeId"`
+	UnlockedAt time.Time          `bson:"unlockedAt"`
+}
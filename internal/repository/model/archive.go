@@ -0,0 +1,133 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+	"time"
+)
+
+// Archive is the manifest of a file of historic games moved out of the database.
+type Archive struct {
+	Id         primitive.ObjectID `bson:"_id"`
+	GameModeId string             `bson:"gameModeId"`
+	Path       string             `bson:"path"`
+
+	// From and To are the end times of the first and last archived game
+	From      time.Time `bson:"from"`
+	To        time.Time `bson:"to"`
+	GameCount int       `bson:"gameCount"`
+
+	// PlayerIds is every player referenced by the archived games so erasure requests can find the archive
+	PlayerIds []uuid.UUID `bson:"playerIds"`
+
+	CreatedAt time.Time `bson:"createdAt"`
+
+	// Aggregates are the archived games' contribution to the stats, which are combined with the stored games' stats
+	Aggregates *ArchivedAggregates `bson:"aggregates"`
+}
+
+type ArchivedAggregates struct {
+	MapStats                []*MapStats               `bson:"mapStats"`
+	TowerDefenceMapWinRates []*TowerDefenceMapWinRate `bson:"towerDefenceMapWinRates"`
+	TeamColorWinRates       []*TeamColorWinRate       `bson:"teamColorWinRates"`
+}
+
+// MergeMapStats adds the stats in b to the stats of the same game mode and map in a
+func MergeMapStats(a []*MapStats, b []*MapStats) []*MapStats {
+	for _, other := range b {
+		var existing *MapStats
+		for _, s := range a {
+			if s.GameModeId == other.GameModeId && s.MapId == other.MapId {
+				existing = s
+				break
+			}
+		}
+
+		if existing == nil {
+			existing = &MapStats{GameModeId: other.GameModeId, MapId: other.MapId}
+			a = append(a, existing)
+		}
+
+		existing.Plays += other.Plays
+		existing.TimedPlays += other.TimedPlays
+		existing.TotalDurationMillis += other.TotalDurationMillis
+		existing.TotalPlayers += other.TotalPlayers
+
+	teams:
+		for _, otherWin := range other.TeamWins {
+			for _, w := range existing.TeamWins {
+				if w.TeamId == otherWin.TeamId {
+					w.Wins += otherWin.Wins
+					continue teams
+				}
+			}
+			existing.TeamWins = append(existing.TeamWins, &MapTeamWin{TeamId: otherWin.TeamId, Wins: otherWin.Wins})
+		}
+	}
+
+	sort.Slice(a, func(i, j int) bool {
+		if a[i].GameModeId != a[j].GameModeId {
+			return a[i].GameModeId < a[j].GameModeId
+		}
+		return a[i].MapId < a[j].MapId
+	})
+
+	return a
+}
+
+// MergeTowerDefenceMapWinRates adds the wins in b to the wins of the same map in a
+func MergeTowerDefenceMapWinRates(a []*TowerDefenceMapWinRate, b []*TowerDefenceMapWinRate) []*TowerDefenceMapWinRate {
+	for _, other := range b {
+		var existing *TowerDefenceMapWinRate
+		for _, r := range a {
+			if r.MapId == other.MapId {
+				existing = r
+				break
+			}
+		}
+
+		if existing == nil {
+			existing = &TowerDefenceMapWinRate{MapId: other.MapId}
+			a = append(a, existing)
+		}
+
+		existing.Games += other.Games
+		existing.RedWins += other.RedWins
+		existing.BlueWins += other.BlueWins
+	}
+
+	sort.Slice(a, func(i, j int) bool { return a[i].MapId < a[j].MapId })
+
+	return a
+}
+
+// MergeTeamColorWinRates adds the wins in b to the wins of the same game mode and colour in a
+func MergeTeamColorWinRates(a []*TeamColorWinRate, b []*TeamColorWinRate) []*TeamColorWinRate {
+	for _, other := range b {
+		var existing *TeamColorWinRate
+		for _, r := range a {
+			if r.GameModeId == other.GameModeId && r.Color == other.Color {
+				existing = r
+				break
+			}
+		}
+
+		if existing == nil {
+			existing = &TeamColorWinRate{GameModeId: other.GameModeId, Color: other.Color}
+			a = append(a, existing)
+		}
+
+		existing.Games += other.Games
+		existing.Wins += other.Wins
+	}
+
+	sort.Slice(a, func(i, j int) bool {
+		if a[i].GameModeId != a[j].GameModeId {
+			return a[i].GameModeId < a[j].GameModeId
+		}
+		return a[i].Color < a[j].Color
+	})
+
+	return a
+}
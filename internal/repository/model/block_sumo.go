@@ -8,10 +8,16 @@ import (
 
 type LiveBlockSumoData struct {
 	Scoreboard *BlockSumoScoreboard `bson:"scoreboard"`
+
+	// InitialLives is the remaining lives of every player when they first appeared on the scoreboard
+	InitialLives map[uuid.UUID]int32 `bson:"initialLives,omitempty"`
 }
 
 type HistoricBlockSumoData struct {
 	Scoreboard *BlockSumoScoreboard `bson:"scoreboard"`
+
+	// InitialLives is only present if the game's live data was available when it finished
+	InitialLives map[uuid.UUID]int32 `bson:"initialLives,omitempty"`
 }
 
 func CreateLiveBlockSumoDataFromUpdate(data *gametracker.BlockSumoUpdateData) (*LiveBlockSumoData, error) {
@@ -20,9 +26,12 @@ func CreateLiveBlockSumoDataFromUpdate(data *gametracker.BlockSumoUpdateData) (*
 		return nil, fmt.Errorf("failed to parse scoreboard: %w", err)
 	}
 
-	return &LiveBlockSumoData{
+	d := &LiveBlockSumoData{
 		Scoreboard: scoreboard,
-	}, nil
+	}
+	d.recordInitialLives()
+
+	return d, nil
 }
 
 func (d *LiveBlockSumoData) Update(data *gametracker.BlockSumoUpdateData) error {
@@ -32,10 +41,24 @@ func (d *LiveBlockSumoData) Update(data *gametracker.BlockSumoUpdateData) error
 	}
 
 	d.Scoreboard = scoreboard
+	d.recordInitialLives()
 
 	return nil
 }
 
+// recordInitialLives records the lives of players appearing on the scoreboard for the first time
+func (d *LiveBlockSumoData) recordInitialLives() {
+	if d.InitialLives == nil {
+		d.InitialLives = make(map[uuid.UUID]int32, len(d.Scoreboard.Entries))
+	}
+
+	for id, e := range d.Scoreboard.Entries {
+		if _, ok := d.InitialLives[id]; !ok {
+			d.InitialLives[id] = e.RemainingLives
+		}
+	}
+}
+
 func CreateBlockSumoScoreboard(data *gametracker.BlockSumoScoreboard) (*BlockSumoScoreboard, error) {
 	entries := make(map[uuid.UUID]*BlockSumoScoreboardEntry)
 
@@ -79,3 +102,18 @@ func CreateHistoricBlockSumoDataFromFinish(data *gametracker.BlockSumoFinishData
 		Scoreboard: scoreboard,
 	}, nil
 }
+
+// LivesLost returns the number of lives the player lost, or false if their initial lives aren't known
+func (d *HistoricBlockSumoData) LivesLost(playerId uuid.UUID) (int32, bool) {
+	initial, ok := d.InitialLives[playerId]
+	if !ok || d.Scoreboard == nil {
+		return 0, false
+	}
+
+	entry, ok := d.Scoreboard.Entries[playerId]
+	if !ok {
+		return 0, false
+	}
+
+	return max(initial-entry.RemainingLives, 0), true
+}
@@ -0,0 +1,197 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// ErasedUsername replaces the username of an erased player
+const ErasedUsername = "[erased]"
+
+// ErasureAudit records what was changed when a player's data was erased.
+// It deliberately does not contain the player's id, only a keyed hash of it so a repeat request can be recognised
+// and the player can be kept out of games that were live when they were erased.
+type ErasureAudit struct {
+	Id primitive.ObjectID `bson:"_id"`
+
+	// PlayerIdHash is the hex encoded HMAC-SHA256 of the erased player's id, keyed by a secret kept outside MongoDB
+	PlayerIdHash string `bson:"playerIdHash"`
+	// Pseudonym is the random id that replaced the player's id in stored games
+	Pseudonym   uuid.UUID `bson:"pseudonym"`
+	RequestedBy string    `bson:"requestedBy"`
+
+	StartTime time.Time `bson:"startTime"`
+	EndTime   time.Time `bson:"endTime"`
+
+	LiveGameIds          []primitive.ObjectID `bson:"liveGameIds"`
+	HistoricGameIds      []primitive.ObjectID `bson:"historicGameIds"`
+	DeletedPlayerRecords int64                `bson:"deletedPlayerRecords"`
+	// SeasonIds are the seasons whose hall of fame snapshot referenced the player
+	SeasonIds []string `bson:"seasonIds,omitempty"`
+	// OutboxEvents is the number of queued or recently sent events that were deleted or pseudonymised
+	OutboxEvents int64 `bson:"outboxEvents"`
+
+	// ArchivePaths are the archive files referencing the player. Their manifests are pseudonymised
+	// but the files themselves must be rewritten by whoever has access to the archive directory.
+	ArchivePaths []string `bson:"archivePaths,omitempty"`
+}
+
+// ReplacePlayer replaces every reference to the player with the pseudonym and scrubs their username.
+// It returns false if the game didn't reference the player.
+func (g *Game) ReplacePlayer(playerId uuid.UUID, pseudonym uuid.UUID) bool {
+	changed := false
+
+	for _, p := range g.Players {
+		if p.Id == playerId {
+			p.Id = pseudonym
+			p.Username = ErasedUsername
+			changed = true
+		}
+	}
+
+	for _, s := range g.PlayerSessions {
+		if s.PlayerId == playerId {
+			s.PlayerId = pseudonym
+			s.Username = ErasedUsername
+			changed = true
+		}
+	}
+
+	if g.TeamData != nil {
+		for _, t := range *g.TeamData {
+			changed = replaceId(t.PlayerIds, playerId, pseudonym) || changed
+		}
+	}
+
+	var scoreboard *BlockSumoScoreboard
+	var initialLives map[uuid.UUID]int32
+	switch data := g.GameData.(type) {
+	case *LiveBlockSumoData:
+		scoreboard, initialLives = data.Scoreboard, data.InitialLives
+	case *HistoricBlockSumoData:
+		scoreboard, initialLives = data.Scoreboard, data.InitialLives
+	}
+	if scoreboard != nil {
+		if entry, ok := scoreboard.Entries[playerId]; ok {
+			delete(scoreboard.Entries, playerId)
+			scoreboard.Entries[pseudonym] = entry
+			changed = true
+		}
+	}
+	if lives, ok := initialLives[playerId]; ok {
+		delete(initialLives, playerId)
+		initialLives[pseudonym] = lives
+		changed = true
+	}
+
+	return changed
+}
+
+// ReplacePlayer replaces every reference to the player with the pseudonym and scrubs their username,
+// including the historic only data. It returns false if the game didn't reference the player.
+func (g *HistoricGame) ReplacePlayer(playerId uuid.UUID, pseudonym uuid.UUID) bool {
+	changed := g.Game.ReplacePlayer(playerId, pseudonym)
+
+	if g.WinnerData != nil {
+		changed = replaceId(g.WinnerData.WinnerIds, playerId, pseudonym) || changed
+		changed = replaceId(g.WinnerData.LoserIds, playerId, pseudonym) || changed
+	}
+
+	for _, p := range g.Participation {
+		if p.PlayerId == playerId {
+			p.PlayerId = pseudonym
+			p.Username = ErasedUsername
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// ReferencedPlayerIds returns every player referenced by the game, in no particular order
+func (g *Game) ReferencedPlayerIds() []uuid.UUID {
+	set := make(map[uuid.UUID]struct{})
+	g.addReferencedPlayerIds(set)
+
+	return playerIdList(set)
+}
+
+// ReferencedPlayerIds returns every player referenced by the game, including the historic only data,
+// in no particular order
+func (g *HistoricGame) ReferencedPlayerIds() []uuid.UUID {
+	set := make(map[uuid.UUID]struct{})
+	g.addReferencedPlayerIds(set)
+
+	for _, p := range g.Participation {
+		set[p.PlayerId] = struct{}{}
+	}
+	if g.WinnerData != nil {
+		for _, id := range g.WinnerData.WinnerIds {
+			set[id] = struct{}{}
+		}
+		for _, id := range g.WinnerData.LoserIds {
+			set[id] = struct{}{}
+		}
+	}
+
+	return playerIdList(set)
+}
+
+func (g *Game) addReferencedPlayerIds(set map[uuid.UUID]struct{}) {
+	add := func(ids ...uuid.UUID) {
+		for _, id := range ids {
+			set[id] = struct{}{}
+		}
+	}
+
+	for _, p := range g.Players {
+		add(p.Id)
+	}
+	for _, s := range g.PlayerSessions {
+		add(s.PlayerId)
+	}
+	if g.TeamData != nil {
+		for _, t := range *g.TeamData {
+			add(t.PlayerIds...)
+		}
+	}
+
+	var scoreboard *BlockSumoScoreboard
+	var initialLives map[uuid.UUID]int32
+	switch data := g.GameData.(type) {
+	case *LiveBlockSumoData:
+		scoreboard, initialLives = data.Scoreboard, data.InitialLives
+	case *HistoricBlockSumoData:
+		scoreboard, initialLives = data.Scoreboard, data.InitialLives
+	}
+	if scoreboard != nil {
+		for id := range scoreboard.Entries {
+			add(id)
+		}
+	}
+	for id := range initialLives {
+		add(id)
+	}
+}
+
+func playerIdList(set map[uuid.UUID]struct{}) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func replaceId(ids []uuid.UUID, old uuid.UUID, replacement uuid.UUID) bool {
+	changed := false
+	for i, id := range ids {
+		if id == old {
+			ids[i] = replacement
+			changed = true
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// LeaderboardWindow is the length of the periods a leaderboard is reset after
+type LeaderboardWindow string
+
+const (
+	LeaderboardDaily   LeaderboardWindow = "daily"
+	LeaderboardWeekly  LeaderboardWindow = "weekly"
+	LeaderboardMonthly LeaderboardWindow = "monthly"
+	// LeaderboardSeason periods are keyed by season id rather than derived from the time
+	LeaderboardSeason  LeaderboardWindow = "season"
+	LeaderboardAllTime LeaderboardWindow = "all-time"
+)
+
+var LeaderboardWindows = []LeaderboardWindow{LeaderboardDaily, LeaderboardWeekly, LeaderboardMonthly,
+	LeaderboardSeason, LeaderboardAllTime}
+
+func (w LeaderboardWindow) Valid() bool {
+	for _, window := range LeaderboardWindows {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
+
+// Period returns the key of the window's period containing the time. Periods are in UTC and weeks are ISO weeks.
+// The all-time and season windows return an empty key.
+func (w LeaderboardWindow) Period(t time.Time) string {
+	t = t.UTC()
+
+	switch w {
+	case LeaderboardDaily:
+		return t.Format("2006-01-02")
+	case LeaderboardWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case LeaderboardMonthly:
+		return t.Format("2006-01")
+	}
+
+	return ""
+}
+
+// ExpiresAt returns when the scores of the period containing the time are deleted, or nil if they are kept forever.
+// Daily and weekly leaderboards are kept long enough to compare with the previous month and year.
+func (w LeaderboardWindow) ExpiresAt(t time.Time) *time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	var expiresAt time.Time
+	switch w {
+	case LeaderboardDaily:
+		expiresAt = day.AddDate(0, 0, 1+31)
+	case LeaderboardWeekly:
+		weekday := (int(day.Weekday()) + 6) % 7 // days since Monday
+		expiresAt = day.AddDate(0, 0, 7-weekday+53*7)
+	default:
+		return nil
+	}
+
+	return &expiresAt
+}
+
+// LeaderboardMetric is a value players are ranked by on the windowed leaderboards
+type LeaderboardMetric string
+
+const (
+	LeaderboardWins        LeaderboardMetric = "wins"
+	LeaderboardGamesPlayed LeaderboardMetric = "gamesPlayed"
+
+	LeaderboardBlockSumoKills      LeaderboardMetric = "blockSumo.kills"
+	LeaderboardBlockSumoFinalKills LeaderboardMetric = "blockSumo.finalKills"
+)
+
+var LeaderboardMetrics = []LeaderboardMetric{LeaderboardWins, LeaderboardGamesPlayed,
+	LeaderboardBlockSumoKills, LeaderboardBlockSumoFinalKills}
+
+func (m LeaderboardMetric) Valid() bool {
+	for _, metric := range LeaderboardMetrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// LeaderboardIncrements returns how much each metric increases by for the player's result in the game.
+// Metrics that don't increase are left out, so players only appear on leaderboards they have scored on.
+func (g *HistoricGame) LeaderboardIncrements(r *PlayerResult) map[LeaderboardMetric]int64 {
+	increments := map[LeaderboardMetric]int64{LeaderboardGamesPlayed: 1}
+	if r.Won {
+		increments[LeaderboardWins] = 1
+	}
+
+	if _, ok := g.GameData.(*HistoricBlockSumoData); ok {
+		if r.Kills > 0 {
+			increments[LeaderboardBlockSumoKills] = int64(r.Kills)
+		}
+		if r.FinalKills > 0 {
+			increments[LeaderboardBlockSumoFinalKills] = int64(r.FinalKills)
+		}
+	}
+
+	return increments
+}
+
+// LeaderboardKey identifies a single leaderboard
+type LeaderboardKey struct {
+	Window LeaderboardWindow `bson:"window"`
+	// Period is the window's period, or the season id of season leaderboards. It is empty for all-time leaderboards.
+	Period     string            `bson:"period"`
+	GameModeId string            `bson:"gameModeId"`
+	Metric     LeaderboardMetric `bson:"metric"`
+}
+
+// LeaderboardScore is a player's value on a leaderboard. Players are ranked by value, then by who reached
+// their value first, then by player id so every player has a distinct rank.
+type LeaderboardScore struct {
+	LeaderboardKey `bson:",inline"`
+	PlayerId       uuid.UUID `bson:"playerId"`
+
+	Value int64 `bson:"value"`
+	// ReachedAt is the end of the game that last increased the value
+	ReachedAt time.Time `bson:"reachedAt"`
+
+	ExpiresAt *time.Time `bson:"expiresAt,omitempty"`
+}
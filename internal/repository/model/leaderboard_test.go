@@ -0,0 +1,113 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeaderboardWindowPeriod(t *testing.T) {
+	plusTwo := time.FixedZone("UTC+2", 2*60*60)
+
+	tests := []struct {
+		name   string
+		window LeaderboardWindow
+		t      time.Time
+		want   string
+	}{
+		{name: "daily", window: LeaderboardDaily, t: at(0), want: "2026-01-01"},
+		{name: "daily is in utc", window: LeaderboardDaily, t: time.Date(2026, 1, 1, 1, 0, 0, 0, plusTwo), want: "2025-12-31"},
+		{name: "weekly", window: LeaderboardWeekly, t: at(0), want: "2026-W01"},
+		{
+			name:   "weekly belongs to the iso year",
+			window: LeaderboardWeekly,
+			t:      time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+			want:   "2025-W01",
+		},
+		{
+			name:   "week 53",
+			window: LeaderboardWeekly,
+			t:      time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:   "2026-W53",
+		},
+		{name: "monthly", window: LeaderboardMonthly, t: at(0), want: "2026-01"},
+		{name: "monthly is in utc", window: LeaderboardMonthly, t: time.Date(2026, 1, 1, 1, 0, 0, 0, plusTwo), want: "2025-12"},
+		{name: "season", window: LeaderboardSeason, t: at(0), want: ""},
+		{name: "all-time", window: LeaderboardAllTime, t: at(0), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Period(tt.t); got != tt.want {
+				t.Errorf("Period() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeaderboardWindowExpiresAt(t *testing.T) {
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	tests := []struct {
+		name   string
+		window LeaderboardWindow
+		t      time.Time
+		want   *time.Time
+	}{
+		// 2026-01-01 is a Thursday
+		{name: "daily", window: LeaderboardDaily, t: at(0), want: date(2026, time.February, 2)},
+		{
+			name:   "daily across a short month",
+			window: LeaderboardDaily,
+			t:      time.Date(2026, 1, 31, 23, 59, 0, 0, time.UTC),
+			want:   date(2026, time.March, 4),
+		},
+		{name: "weekly", window: LeaderboardWeekly, t: at(0), want: date(2027, time.January, 11)},
+		{
+			name:   "weekly on a monday",
+			window: LeaderboardWeekly,
+			t:      time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+			want:   date(2027, time.January, 18),
+		},
+		{
+			name:   "weekly on a sunday",
+			window: LeaderboardWeekly,
+			t:      time.Date(2026, 1, 4, 23, 59, 0, 0, time.UTC),
+			want:   date(2027, time.January, 11),
+		},
+		{name: "monthly is kept", window: LeaderboardMonthly, t: at(0)},
+		{name: "season is kept", window: LeaderboardSeason, t: at(0)},
+		{name: "all-time is kept", window: LeaderboardAllTime, t: at(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.window.ExpiresAt(tt.t)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("ExpiresAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeaderboardWindowValid(t *testing.T) {
+	tests := []struct {
+		window LeaderboardWindow
+		want   bool
+	}{
+		{window: LeaderboardDaily, want: true},
+		{window: LeaderboardAllTime, want: true},
+		{window: "yearly", want: false},
+		{window: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.window), func(t *testing.T) {
+			if got := tt.window.Valid(); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
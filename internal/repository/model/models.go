@@ -9,19 +9,28 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
 	"time"
 )
 
 type GameStage uint8
 
+// CurrentSchemaVersion is the schema version written to every game document.
+// Bump it and register a migration in the repository whenever a change would break decoding of older documents.
+const CurrentSchemaVersion int32 = 2
+
 const (
-	LiveTowerDefenceDataId int32 = 1
-	LiveBlockSumoDataId    int32 = 2
+	LiveTowerDefenceDataId     int32 = 1
+	LiveBlockSumoDataId        int32 = 2
+	HistoricTowerDefenceDataId int32 = 3
+	HistoricBlockSumoDataId    int32 = 4
 )
 
 var dataType = map[int32]interface{}{
-	LiveTowerDefenceDataId: &LiveTowerDefenceData{},
-	LiveBlockSumoDataId:    &LiveBlockSumoData{},
+	LiveTowerDefenceDataId:     &LiveTowerDefenceData{},
+	LiveBlockSumoDataId:        &LiveBlockSumoData{},
+	HistoricTowerDefenceDataId: &HistoricTowerDefenceData{},
+	HistoricBlockSumoDataId:    &HistoricBlockSumoData{},
 }
 
 func getDataType(example interface{}) int32 {
@@ -42,9 +51,22 @@ type Game struct {
 	Id         primitive.ObjectID `bson:"_id"`
 	GameModeId string             `bson:"gameModeId"`
 
+	// SchemaVersion is the version of the document layout. Documents stored before versioning have no version (0).
+	SchemaVersion int32 `bson:"schemaVersion"`
+
 	ServerId  string         `bson:"serverId"`
+	MapId     string         `bson:"mapId,omitempty"`
 	StartTime *time.Time     `bson:"startTime,omitempty"`
 	Players   []*BasicPlayer `bson:"players"`
+	// PlayerSessions is every period a player spent in the game, in the order they joined
+	PlayerSessions []*PlayerSession `bson:"playerSessions,omitempty"`
+
+	// CreatedAt is when the tracker first saw the game
+	CreatedAt time.Time `bson:"createdAt"`
+	// UpdateCount is the number of update messages received for the game
+	UpdateCount     int32      `bson:"updateCount"`
+	FirstUpdateTime *time.Time `bson:"firstUpdateTime,omitempty"`
+	LastUpdateTime  *time.Time `bson:"lastUpdateTime,omitempty"`
 
 	// The below data is all optional and varies by game mode
 
@@ -59,6 +81,67 @@ func (g *Game) GetGame() *Game {
 	return g
 }
 
+// RecordUpdate records that an update message was received for the game at the given time
+func (g *Game) RecordUpdate(t time.Time) {
+	if g.FirstUpdateTime == nil {
+		g.FirstUpdateTime = &t
+	}
+	g.LastUpdateTime = &t
+	g.UpdateCount++
+}
+
+// GameTime converts the time a message was sent to the game server's clock, which the start and end times are on,
+// using the difference between the start time and when the start message was sent. Sessions are kept on the game's
+// clock so they can be compared with its start and end. Times before the start are moved to the start.
+func (g *Game) GameTime(messageTime time.Time) time.Time {
+	if g.StartTime == nil || g.CreatedAt.IsZero() {
+		return messageTime
+	}
+
+	t := messageTime.Add(g.StartTime.Sub(g.CreatedAt))
+	if t.Before(*g.StartTime) {
+		return *g.StartTime
+	}
+
+	return t
+}
+
+// SyncPlayers replaces the game's players, opening a session for every player that joined
+// and closing the session of every player that left at the given time, which is on the game's clock.
+func (g *Game) SyncPlayers(players []*BasicPlayer, t time.Time) {
+	present := make(map[uuid.UUID]bool, len(players))
+	for _, p := range players {
+		present[p.Id] = true
+	}
+
+	open := make(map[uuid.UUID]bool)
+	for _, s := range g.PlayerSessions {
+		if s.LeaveTime != nil {
+			continue
+		}
+
+		if present[s.PlayerId] {
+			open[s.PlayerId] = true
+		} else {
+			s.LeaveTime = &t
+		}
+	}
+
+	for _, p := range players {
+		if open[p.Id] {
+			continue
+		}
+
+		g.PlayerSessions = append(g.PlayerSessions, &PlayerSession{
+			PlayerId: p.Id,
+			Username: p.Username,
+			JoinTime: t,
+		})
+	}
+
+	g.Players = players
+}
+
 func (g *Game) SetGameData(data interface{}) {
 	g.GameData = data
 	g.GameDataType = getDataType(data)
@@ -83,6 +166,9 @@ func (g *Game) ParseGameData() error {
 		return fmt.Errorf("unknown game data type: %d", g.GameDataType)
 	}
 
+	// Decode into a fresh value so games never share the same data instance
+	target := reflect.New(reflect.TypeOf(example).Elem()).Interface()
+
 	// Convert the interface{} to bytes
 	bytes, err := bson.Marshal(g.GameData)
 	if err != nil {
@@ -99,11 +185,11 @@ func (g *Game) ParseGameData() error {
 		return fmt.Errorf("failed to set registry: %w", err)
 	}
 
-	if err := dec.Decode(example); err != nil {
+	if err := dec.Decode(target); err != nil {
 		return fmt.Errorf("failed to decode: %w", err)
 	}
 
-	g.GameData = example
+	g.GameData = target
 
 	return nil
 }
@@ -121,9 +207,83 @@ type HistoricGame struct {
 	*Game `bson:",inline"`
 
 	EndTime time.Time `bson:"endTime"`
+	// Duration is the time between the start and end of the game, zero if the start time is unknown
+	Duration time.Duration `bson:"duration,omitempty"`
+
+	// Participation is the time every player that took part spent in the game
+	Participation []*PlayerParticipation `bson:"participation,omitempty"`
 
 	// The below data is all optional and varies by game mode
 	WinnerData *HistoricWinnerData `bson:"winnerData,omitempty"`
+
+	// WinningTeamId is the team the winners belong to, only present for team games with a single winning team
+	WinningTeamId string       `bson:"winningTeamId,omitempty"`
+	TeamStats     []*TeamStats `bson:"teamStats,omitempty"`
+}
+
+// SyncFinishPlayers syncs the players present at the finish. Players without any session were in the game
+// without being reported by an update, so they are treated as present since the start rather than joining at the end.
+func (g *HistoricGame) SyncFinishPlayers(players []*BasicPlayer) {
+	seen := make(map[uuid.UUID]bool, len(g.PlayerSessions))
+	for _, s := range g.PlayerSessions {
+		seen[s.PlayerId] = true
+	}
+
+	g.SyncPlayers(players, g.EndTime)
+
+	if g.StartTime == nil {
+		return
+	}
+	for _, s := range g.PlayerSessions {
+		if !seen[s.PlayerId] {
+			s.JoinTime = *g.StartTime
+		}
+	}
+}
+
+// ComputeParticipation closes every open player session at the end time and summarises the sessions of each player.
+// Sessions are clamped to the game's start and end, so clock differences can't make a player's time exceed the game's.
+func (g *HistoricGame) ComputeParticipation() {
+	participation := make(map[uuid.UUID]*PlayerParticipation)
+	g.Participation = make([]*PlayerParticipation, 0, len(g.PlayerSessions))
+
+	for _, s := range g.PlayerSessions {
+		if s.LeaveTime == nil || s.LeaveTime.After(g.EndTime) {
+			s.LeaveTime = &g.EndTime
+		}
+		if g.StartTime != nil && s.JoinTime.Before(*g.StartTime) {
+			s.JoinTime = *g.StartTime
+		}
+		if s.JoinTime.After(*s.LeaveTime) {
+			s.JoinTime = *s.LeaveTime
+		}
+
+		p, ok := participation[s.PlayerId]
+		if !ok {
+			p = &PlayerParticipation{
+				PlayerId:      s.PlayerId,
+				FirstJoinTime: s.JoinTime,
+			}
+			participation[s.PlayerId] = p
+			g.Participation = append(g.Participation, p)
+		}
+
+		p.Username = s.Username
+		p.LastLeaveTime = *s.LeaveTime
+		p.TimeInGame += s.LeaveTime.Sub(s.JoinTime)
+	}
+
+	for _, p := range g.Participation {
+		p.LeftEarly = p.LastLeaveTime.Before(g.EndTime)
+	}
+}
+
+func (g *HistoricGame) ComputeDuration() {
+	if g.StartTime == nil {
+		return
+	}
+
+	g.Duration = g.EndTime.Sub(*g.StartTime)
 }
 
 type HistoricWinnerData struct {
@@ -179,6 +339,139 @@ func BasicPlayersFromProto(players []*gametracker.BasicGamePlayer) ([]*BasicPlay
 	return basicPlayers, nil
 }
 
+type PlayerSession struct {
+	PlayerId  uuid.UUID  `bson:"playerId"`
+	Username  string     `bson:"username"`
+	JoinTime  time.Time  `bson:"joinTime"`
+	LeaveTime *time.Time `bson:"leaveTime,omitempty"`
+}
+
+type PlayerParticipation struct {
+	PlayerId uuid.UUID `bson:"playerId"`
+	Username string    `bson:"username"`
+
+	FirstJoinTime time.Time     `bson:"firstJoinTime"`
+	LastLeaveTime time.Time     `bson:"lastLeaveTime"`
+	TimeInGame    time.Duration `bson:"timeInGame"`
+
+	// LeftEarly is true if the player quit before the game finished
+	LeftEarly bool `bson:"leftEarly"`
+}
+
+type TeamStats struct {
+	TeamId       string `bson:"teamId"`
+	FriendlyName string `bson:"friendlyName"`
+	Color        int32  `bson:"color"`
+
+	Members int32 `bson:"members"`
+	Won     bool  `bson:"won"`
+
+	// Block Sumo
+	Kills      int32 `bson:"kills,omitempty"`
+	FinalKills int32 `bson:"finalKills,omitempty"`
+
+	// Tower Defence
+	RemainingHealth *int32 `bson:"remainingHealth,omitempty"`
+}
+
+// ComputeTeamOutcome resolves the winning team from the winner data and aggregates the stats of every team.
+func (g *HistoricGame) ComputeTeamOutcome() {
+	if g.TeamData == nil || len(*g.TeamData) == 0 {
+		return
+	}
+	teams := *g.TeamData
+
+	g.WinningTeamId = g.resolveWinningTeam(teams)
+
+	g.TeamStats = make([]*TeamStats, len(teams))
+	for i, t := range teams {
+		stats := &TeamStats{
+			TeamId:       t.Id,
+			FriendlyName: t.FriendlyName,
+			Color:        t.Color,
+			Members:      int32(len(t.PlayerIds)),
+			Won:          t.Id == g.WinningTeamId,
+		}
+
+		switch data := g.GameData.(type) {
+		case *HistoricBlockSumoData:
+			if data.Scoreboard == nil {
+				break
+			}
+
+			for _, id := range t.PlayerIds {
+				if entry, ok := data.Scoreboard.Entries[id]; ok {
+					stats.Kills += entry.Kills
+					stats.FinalKills += entry.FinalKills
+				}
+			}
+		case *HistoricTowerDefenceData:
+			switch t.Id {
+			case TowerDefenceRedTeamId:
+				stats.RemainingHealth = &data.RedHealth
+			case TowerDefenceBlueTeamId:
+				stats.RemainingHealth = &data.BlueHealth
+			}
+		}
+
+		g.TeamStats[i] = stats
+	}
+}
+
+// resolveWinningTeam returns the team with the most winners, or an empty string if there is no single such team.
+func (g *HistoricGame) resolveWinningTeam(teams []*Team) string {
+	if g.WinnerData == nil || len(g.WinnerData.WinnerIds) == 0 {
+		return ""
+	}
+
+	winners := make(map[uuid.UUID]bool, len(g.WinnerData.WinnerIds))
+	for _, id := range g.WinnerData.WinnerIds {
+		winners[id] = true
+	}
+
+	bestTeam := ""
+	bestCount := 0
+	tied := false
+	for _, t := range teams {
+		count := 0
+		for _, id := range t.PlayerIds {
+			if winners[id] {
+				count++
+			}
+		}
+
+		switch {
+		case count > bestCount:
+			bestTeam, bestCount, tied = t.Id, count, false
+		case count == bestCount && count > 0:
+			tied = true
+		}
+	}
+
+	if tied {
+		return ""
+	}
+
+	return bestTeam
+}
+
+// TeamColorWinRate is the number of games played and won by teams of a colour in a game mode.
+type TeamColorWinRate struct {
+	GameModeId string `bson:"gameModeId"`
+	Color      int32  `bson:"color"`
+
+	Games int32 `bson:"games"`
+	Wins  int32 `bson:"wins"`
+}
+
+func (r *TeamColorWinRate) WinRate() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+
+	return float64(r.Wins) / float64(r.Games)
+}
+
 type Team struct {
 	Id           string      `bson:"id"`
 	FriendlyName string      `bson:"friendlyName"`
@@ -218,3 +511,63 @@ func ParseUuids(uuidStrs []string) ([]uuid.UUID, error) {
 
 	return ids, nil
 }
+
+// MapStats is the aggregate of every historic game played on a map of a game mode.
+// Totals are stored rather than averages so stats can be combined.
+type MapStats struct {
+	GameModeId string `bson:"gameModeId"`
+	MapId      string `bson:"mapId"`
+
+	Plays int32 `bson:"plays"`
+	// TimedPlays is the number of plays with both a start and end time
+	TimedPlays          int32         `bson:"timedPlays"`
+	TotalDurationMillis int64         `bson:"totalDurationMillis"`
+	TotalPlayers        int64         `bson:"totalPlayers"`
+	TeamWins            []*MapTeamWin `bson:"teamWins"`
+}
+
+type MapTeamWin struct {
+	TeamId string `bson:"teamId"`
+	Wins   int32  `bson:"wins"`
+}
+
+func (s *MapStats) AverageDuration() time.Duration {
+	if s.TimedPlays == 0 {
+		return 0
+	}
+
+	return time.Duration(s.TotalDurationMillis/int64(s.TimedPlays)) * time.Millisecond
+}
+
+func (s *MapStats) AveragePlayers() float64 {
+	if s.Plays == 0 {
+		return 0
+	}
+
+	return float64(s.TotalPlayers) / float64(s.Plays)
+}
+
+// TeamWinRate returns the fraction of plays won by the team
+func (s *MapStats) TeamWinRate(teamId string) float64 {
+	if s.Plays == 0 {
+		return 0
+	}
+
+	for _, w := range s.TeamWins {
+		if w.TeamId == teamId {
+			return float64(w.Wins) / float64(s.Plays)
+		}
+	}
+
+	return 0
+}
+
+// DurationStats is the distribution of historic game durations for a game mode.
+type DurationStats struct {
+	GameModeId string `bson:"gameModeId"`
+
+	Games   int64         `bson:"games"`
+	Average time.Duration `bson:"average"`
+	Median  time.Duration `bson:"median"`
+	P95     time.Duration `bson:"p95"`
+}
@@ -0,0 +1,320 @@
+package model
+
+import (
+	"game-tracker/internal/utils"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+var (
+	testPlayerA = &BasicPlayer{Id: uuid.MustParse("00000000-0000-0000-0000-00000000000a"), Username: "a"}
+	testPlayerB = &BasicPlayer{Id: uuid.MustParse("00000000-0000-0000-0000-00000000000b"), Username: "b"}
+	testPlayerC = &BasicPlayer{Id: uuid.MustParse("00000000-0000-0000-0000-00000000000c"), Username: "c"}
+)
+
+// at returns the time the number of seconds after the test game starts
+func at(seconds int) time.Time {
+	return time.Date(2026, 1, 1, 12, 0, seconds, 0, time.UTC)
+}
+
+type testSession struct {
+	player *BasicPlayer
+	join   int
+	// leave is -1 for an open session
+	leave int
+}
+
+func sessionsOf(sessions []*PlayerSession) []testSession {
+	byId := map[uuid.UUID]*BasicPlayer{testPlayerA.Id: testPlayerA, testPlayerB.Id: testPlayerB, testPlayerC.Id: testPlayerC}
+
+	result := make([]testSession, len(sessions))
+	for i, s := range sessions {
+		result[i] = testSession{player: byId[s.PlayerId], join: int(s.JoinTime.Sub(at(0)).Seconds()), leave: -1}
+		if s.LeaveTime != nil {
+			result[i].leave = int(s.LeaveTime.Sub(at(0)).Seconds())
+		}
+	}
+
+	return result
+}
+
+func TestSyncPlayers(t *testing.T) {
+	type sync struct {
+		players []*BasicPlayer
+		at      int
+	}
+
+	tests := []struct {
+		name  string
+		syncs []sync
+		want  []testSession
+	}{
+		{
+			name:  "start",
+			syncs: []sync{{players: []*BasicPlayer{testPlayerA, testPlayerB}, at: 0}},
+			want:  []testSession{{testPlayerA, 0, -1}, {testPlayerB, 0, -1}},
+		},
+		{
+			name: "leave",
+			syncs: []sync{
+				{players: []*BasicPlayer{testPlayerA, testPlayerB}, at: 0},
+				{players: []*BasicPlayer{testPlayerA}, at: 30},
+			},
+			want: []testSession{{testPlayerA, 0, -1}, {testPlayerB, 0, 30}},
+		},
+		{
+			name: "rejoin",
+			syncs: []sync{
+				{players: []*BasicPlayer{testPlayerA, testPlayerB}, at: 0},
+				{players: []*BasicPlayer{testPlayerA}, at: 30},
+				{players: []*BasicPlayer{testPlayerA, testPlayerB}, at: 45},
+			},
+			want: []testSession{{testPlayerA, 0, -1}, {testPlayerB, 0, 30}, {testPlayerB, 45, -1}},
+		},
+		{
+			name: "unchanged",
+			syncs: []sync{
+				{players: []*BasicPlayer{testPlayerA}, at: 0},
+				{players: []*BasicPlayer{testPlayerA}, at: 10},
+				{players: []*BasicPlayer{testPlayerA}, at: 20},
+			},
+			want: []testSession{{testPlayerA, 0, -1}},
+		},
+		{
+			name: "everyone leaves",
+			syncs: []sync{
+				{players: []*BasicPlayer{testPlayerA, testPlayerB}, at: 0},
+				{players: nil, at: 5},
+			},
+			want: []testSession{{testPlayerA, 0, 5}, {testPlayerB, 0, 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &Game{}
+			for _, s := range tt.syncs {
+				g.SyncPlayers(s.players, at(s.at))
+			}
+
+			if got := sessionsOf(g.PlayerSessions); !equalSessions(got, tt.want) {
+				t.Errorf("sessions = %v, want %v", got, tt.want)
+			}
+			if last := tt.syncs[len(tt.syncs)-1].players; len(g.Players) != len(last) {
+				t.Errorf("players = %d, want %d", len(g.Players), len(last))
+			}
+		})
+	}
+}
+
+func equalSessions(a []testSession, b []testSession) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGameTime(t *testing.T) {
+	start := at(0)
+
+	tests := []struct {
+		name      string
+		game      *Game
+		message   time.Time
+		want      time.Time
+		wantStart bool
+	}{
+		{
+			name:    "same clock",
+			game:    &Game{StartTime: &start, CreatedAt: at(0)},
+			message: at(30),
+			want:    at(30),
+		},
+		{
+			name:    "message clock ahead",
+			game:    &Game{StartTime: &start, CreatedAt: at(5)},
+			message: at(35),
+			want:    at(30),
+		},
+		{
+			name:    "message clock behind",
+			game:    &Game{StartTime: &start, CreatedAt: at(-5)},
+			message: at(25),
+			want:    at(30),
+		},
+		{
+			name:    "before the start",
+			game:    &Game{StartTime: &start, CreatedAt: at(10)},
+			message: at(5),
+			want:    at(0),
+		},
+		{
+			name:    "no start time",
+			game:    &Game{CreatedAt: at(10)},
+			message: at(20),
+			want:    at(20),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.game.GameTime(tt.message); !got.Equal(tt.want) {
+				t.Errorf("GameTime() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeParticipation(t *testing.T) {
+	type participation struct {
+		player     *BasicPlayer
+		firstJoin  int
+		lastLeave  int
+		timeInGame time.Duration
+		leftEarly  bool
+	}
+
+	tests := []struct {
+		name string
+		// sessions are the game's sessions before the finish, which happens at 60s
+		sessions []*PlayerSession
+		finish   []*BasicPlayer
+		noStart  bool
+		want     []participation
+	}{
+		{
+			name:     "played to the end",
+			sessions: []*PlayerSession{{PlayerId: testPlayerA.Id, JoinTime: at(0)}},
+			finish:   []*BasicPlayer{testPlayerA},
+			want:     []participation{{testPlayerA, 0, 60, time.Minute, false}},
+		},
+		{
+			name: "left early",
+			sessions: []*PlayerSession{
+				{PlayerId: testPlayerA.Id, JoinTime: at(0)},
+				{PlayerId: testPlayerB.Id, JoinTime: at(0), LeaveTime: utils.Pointer(at(20))},
+			},
+			finish: []*BasicPlayer{testPlayerA},
+			want: []participation{
+				{testPlayerA, 0, 60, time.Minute, false},
+				{testPlayerB, 0, 20, 20 * time.Second, true},
+			},
+		},
+		{
+			name: "rejoined",
+			sessions: []*PlayerSession{
+				{PlayerId: testPlayerA.Id, JoinTime: at(0), LeaveTime: utils.Pointer(at(10))},
+				{PlayerId: testPlayerA.Id, JoinTime: at(40)},
+			},
+			finish: []*BasicPlayer{testPlayerA},
+			want:   []participation{{testPlayerA, 0, 60, 30 * time.Second, false}},
+		},
+		{
+			name:     "first seen at the finish",
+			sessions: []*PlayerSession{{PlayerId: testPlayerA.Id, JoinTime: at(0)}},
+			finish:   []*BasicPlayer{testPlayerA, testPlayerC},
+			want: []participation{
+				{testPlayerA, 0, 60, time.Minute, false},
+				{testPlayerC, 0, 60, time.Minute, false},
+			},
+		},
+		{
+			name:    "first seen at the finish without a start time",
+			finish:  []*BasicPlayer{testPlayerC},
+			noStart: true,
+			want:    []participation{{testPlayerC, 60, 60, 0, false}},
+		},
+		{
+			name: "sessions outside the game",
+			sessions: []*PlayerSession{
+				{PlayerId: testPlayerA.Id, JoinTime: at(-5)},
+				{PlayerId: testPlayerB.Id, JoinTime: at(10), LeaveTime: utils.Pointer(at(70))},
+			},
+			finish: []*BasicPlayer{testPlayerA},
+			want: []participation{
+				{testPlayerA, 0, 60, time.Minute, false},
+				{testPlayerB, 10, 60, 50 * time.Second, false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := at(0)
+			g := &HistoricGame{Game: &Game{StartTime: &start, PlayerSessions: tt.sessions}, EndTime: at(60)}
+			if tt.noStart {
+				g.StartTime = nil
+			}
+
+			g.SyncFinishPlayers(tt.finish)
+			g.ComputeParticipation()
+
+			if len(g.Participation) != len(tt.want) {
+				t.Fatalf("participation = %d players, want %d", len(g.Participation), len(tt.want))
+			}
+			for i, p := range g.Participation {
+				want := tt.want[i]
+				got := participation{
+					player:     map[uuid.UUID]*BasicPlayer{testPlayerA.Id: testPlayerA, testPlayerB.Id: testPlayerB, testPlayerC.Id: testPlayerC}[p.PlayerId],
+					firstJoin:  int(p.FirstJoinTime.Sub(at(0)).Seconds()),
+					lastLeave:  int(p.LastLeaveTime.Sub(at(0)).Seconds()),
+					timeInGame: p.TimeInGame,
+					leftEarly:  p.LeftEarly,
+				}
+				if got != want {
+					t.Errorf("participation %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveWinningTeam(t *testing.T) {
+	red := &Team{Id: "red", PlayerIds: []uuid.UUID{testPlayerA.Id, testPlayerB.Id}}
+	blue := &Team{Id: "blue", PlayerIds: []uuid.UUID{testPlayerC.Id}}
+
+	tests := []struct {
+		name    string
+		winners []uuid.UUID
+		noData  bool
+		want    string
+	}{
+		{name: "no winner data", noData: true, want: ""},
+		{name: "no winners", want: ""},
+		{name: "whole team", winners: []uuid.UUID{testPlayerA.Id, testPlayerB.Id}, want: "red"},
+		{name: "part of a team", winners: []uuid.UUID{testPlayerA.Id}, want: "red"},
+		{name: "other team", winners: []uuid.UUID{testPlayerC.Id}, want: "blue"},
+		{name: "most winners", winners: []uuid.UUID{testPlayerA.Id, testPlayerB.Id, testPlayerC.Id}, want: "red"},
+		{name: "tied", winners: []uuid.UUID{testPlayerA.Id, testPlayerC.Id}, want: ""},
+		{name: "winner without a team", winners: []uuid.UUID{uuid.New()}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &HistoricGame{Game: &Game{TeamData: &[]*Team{red, blue}}}
+			if !tt.noData {
+				g.WinnerData = &HistoricWinnerData{WinnerIds: tt.winners}
+			}
+
+			if got := g.resolveWinningTeam(*g.TeamData); got != tt.want {
+				t.Errorf("resolveWinningTeam() = %q, want %q", got, tt.want)
+			}
+
+			g.ComputeTeamOutcome()
+			if g.WinningTeamId != tt.want {
+				t.Errorf("winning team id = %q, want %q", g.WinningTeamId, tt.want)
+			}
+			for _, s := range g.TeamStats {
+				if s.Won != (s.TeamId == tt.want) {
+					t.Errorf("team %s won = %v", s.TeamId, s.Won)
+				}
+			}
+		})
+	}
+}
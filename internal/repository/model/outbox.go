@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// OutboxEvent is an encoded Kafka message saved alongside the data it describes and published by the outbox relay.
+type OutboxEvent struct {
+	Id primitive.ObjectID `bson:"_id"`
+
+	// Key is the Kafka message key
+	Key string `bson:"key"`
+	// ProtoType is the full name of the proto message, sent as the X-Proto-Type header
+	ProtoType string `bson:"protoType"`
+	Payload   []byte `bson:"payload"`
+	// PlayerIds are the players the event references, so it can be pseudonymised when one of them is erased
+	PlayerIds []uuid.UUID `bson:"playerIds,omitempty"`
+
+	CreatedAt time.Time `bson:"createdAt"`
+	// SentAt is unset until the event has been published
+	SentAt *time.Time `bson:"sentAt,omitempty"`
+
+	Attempts  int32  `bson:"attempts"`
+	LastError string `bson:"lastError,omitempty"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Player is a directory entry recording the usernames a player has been seen with across all games.
+type Player struct {
+	Id uuid.UUID `bson:"_id"`
+
+	Username      string `bson:"username"`
+	UsernameLower string `bson:"usernameLower"`
+	// UsernameFirstSeen is when the player was first seen with their current username
+	UsernameFirstSeen time.Time `bson:"usernameFirstSeen"`
+
+	// PreviousUsernames is every former username of the player, oldest first
+	PreviousUsernames []*UsernameRecord `bson:"previousUsernames,omitempty"`
+
+	FirstSeen time.Time `bson:"firstSeen"`
+	LastSeen  time.Time `bson:"lastSeen"`
+}
+
+type UsernameRecord struct {
+	Username      string    `bson:"username"`
+	UsernameLower string    `bson:"usernameLower"`
+	FirstSeen     time.Time `bson:"firstSeen"`
+	LastSeen      time.Time `bson:"lastSeen"`
+}
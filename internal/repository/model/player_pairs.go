@@ -0,0 +1,91 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// PlayerPairResult is how a game went for a player against or alongside another player
+type PlayerPairResult struct {
+	PlayerId uuid.UUID
+	OtherId  uuid.UUID
+	// Teammates is true if both players were on the same team, otherwise they were opponents
+	Teammates bool
+
+	// Won and Lost are from the player's side. Opponents only count as a win or loss if the other player had the opposite result.
+	Won  bool
+	Lost bool
+}
+
+// PlayerPairResults returns the result of every ordered pair of players that took part in the game,
+// so each pair appears twice, once from each player's side
+func (g *HistoricGame) PlayerPairResults() []*PlayerPairResult {
+	results := g.PlayerResults()
+
+	pairs := make([]*PlayerPairResult, 0, len(results)*(len(results)-1))
+	for _, r := range results {
+		for _, other := range results {
+			if r.PlayerId == other.PlayerId {
+				continue
+			}
+
+			pair := &PlayerPairResult{
+				PlayerId:  r.PlayerId,
+				OtherId:   other.PlayerId,
+				Teammates: r.TeamId != "" && r.TeamId == other.TeamId,
+			}
+			if pair.Teammates {
+				pair.Won, pair.Lost = r.Won, r.Lost
+			} else {
+				pair.Won = r.Won && other.Lost
+				pair.Lost = r.Lost && other.Won
+			}
+
+			pairs = append(pairs, pair)
+		}
+	}
+
+	return pairs
+}
+
+// PlayerPairStats are a player's totals against and alongside another player in a game mode.
+// Every pair is stored from both sides.
+type PlayerPairStats struct {
+	PlayerId uuid.UUID `bson:"playerId"`
+	OtherId  uuid.UUID `bson:"otherId"`
+	// GameModeId is empty when the stats are totals across every game mode
+	GameModeId string `bson:"gameModeId"`
+	// SeasonId is empty for all-time stats
+	SeasonId string `bson:"seasonId,omitempty"`
+
+	GamesAgainst  int64 `bson:"gamesAgainst"`
+	WinsAgainst   int64 `bson:"winsAgainst"`
+	LossesAgainst int64 `bson:"lossesAgainst"`
+
+	GamesWith  int64 `bson:"gamesWith"`
+	WinsWith   int64 `bson:"winsWith"`
+	LossesWith int64 `bson:"lossesWith"`
+
+	LastPlayed time.Time `bson:"lastPlayed"`
+}
+
+type PlayerPairKind string
+
+const (
+	// PlayerPairRivals are the players most played against
+	PlayerPairRivals PlayerPairKind = "rivals"
+	// PlayerPairPartners are the teammates with the highest win rate together
+	PlayerPairPartners PlayerPairKind = "partners"
+)
+
+// partnerMinGames is the default number of games together partners need to be ranked,
+// as a win rate from a game or two says little
+const partnerMinGames = 5
+
+// DefaultMinGames is the number of games of the kind a pair needs to be ranked if no minimum is given
+func (k PlayerPairKind) DefaultMinGames() int64 {
+	if k == PlayerPairPartners {
+		return partnerMinGames
+	}
+	return 1
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestPlayerPairResults(t *testing.T) {
+	type pair struct {
+		teammates bool
+		won       bool
+		lost      bool
+	}
+	type key struct {
+		player *BasicPlayer
+		other  *BasicPlayer
+	}
+
+	tests := []struct {
+		name          string
+		players       []*BasicPlayer
+		teams         *[]*Team
+		winnerData    *HistoricWinnerData
+		winningTeamId string
+		want          map[key]pair
+	}{
+		{
+			name:          "teams",
+			players:       []*BasicPlayer{testPlayerA, testPlayerB, testPlayerC},
+			teams:         testTeams(),
+			winningTeamId: "red",
+			want: map[key]pair{
+				{testPlayerA, testPlayerB}: {teammates: true, won: true},
+				{testPlayerB, testPlayerA}: {teammates: true, won: true},
+				{testPlayerA, testPlayerC}: {won: true},
+				{testPlayerC, testPlayerA}: {lost: true},
+				{testPlayerB, testPlayerC}: {won: true},
+				{testPlayerC, testPlayerB}: {lost: true},
+			},
+		},
+		{
+			// Both losers of a free-for-all lost, but not to each other
+			name:       "free-for-all",
+			players:    []*BasicPlayer{testPlayerA, testPlayerB, testPlayerC},
+			winnerData: &HistoricWinnerData{WinnerIds: []uuid.UUID{testPlayerA.Id}},
+			want: map[key]pair{
+				{testPlayerA, testPlayerB}: {won: true},
+				{testPlayerB, testPlayerA}: {lost: true},
+				{testPlayerA, testPlayerC}: {won: true},
+				{testPlayerC, testPlayerA}: {lost: true},
+				{testPlayerB, testPlayerC}: {},
+				{testPlayerC, testPlayerB}: {},
+			},
+		},
+		{
+			name:    "no result",
+			players: []*BasicPlayer{testPlayerA, testPlayerB},
+			want: map[key]pair{
+				{testPlayerA, testPlayerB}: {},
+				{testPlayerB, testPlayerA}: {},
+			},
+		},
+		{
+			name:    "single player",
+			players: []*BasicPlayer{testPlayerA},
+			want:    map[key]pair{},
+		},
+	}
+
+	byId := map[uuid.UUID]*BasicPlayer{testPlayerA.Id: testPlayerA, testPlayerB.Id: testPlayerB, testPlayerC.Id: testPlayerC}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := &HistoricGame{
+				Game:          &Game{Players: tt.players, TeamData: tt.teams},
+				WinnerData:    tt.winnerData,
+				WinningTeamId: tt.winningTeamId,
+			}
+
+			got := game.PlayerPairResults()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d pairs, want %d", len(got), len(tt.want))
+			}
+			for _, r := range got {
+				k := key{byId[r.PlayerId], byId[r.OtherId]}
+				want, ok := tt.want[k]
+				if !ok {
+					t.Errorf("unexpected pair %s and %s", r.PlayerId, r.OtherId)
+					continue
+				}
+				if (pair{r.Teammates, r.Won, r.Lost}) != want {
+					t.Errorf("%s with %s: teammates %v won %v lost %v, want %+v", k.player.Username, k.other.Username,
+						r.Teammates, r.Won, r.Lost, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlayerPairKindDefaultMinGames(t *testing.T) {
+	tests := []struct {
+		kind PlayerPairKind
+		want int64
+	}{
+		{kind: PlayerPairRivals, want: 1},
+		{kind: PlayerPairPartners, want: partnerMinGames},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			if got := tt.kind.DefaultMinGames(); got != tt.want {
+				t.Errorf("DefaultMinGames() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Outcome is the result of a game for a player, used to maintain streaks
+type Outcome string
+
+const (
+	OutcomeWin  Outcome = "win"
+	OutcomeLoss Outcome = "loss"
+	// OutcomeDraw is a game that finished with a result that didn't make the player a winner or loser
+	OutcomeDraw Outcome = "draw"
+	// OutcomeNone is a game abandoned without any result, which leaves streaks unchanged
+	OutcomeNone Outcome = ""
+)
+
+// PlayerResult is how a single player did in a historic game
+type PlayerResult struct {
+	PlayerId uuid.UUID
+	// TeamId is empty if the game has no teams or the player wasn't in one
+	TeamId string
+
+	Won       bool
+	Lost      bool
+	LeftEarly bool
+	// Outcome follows Won and Lost, except that leaving a game that had a result early is a loss
+	Outcome Outcome
+
+	TimeInGame time.Duration
+
+	// Block Sumo
+	Kills      int32
+	FinalKills int32
+}
+
+// PlayerResults returns the result of everyone who took part in the game, including players who left early.
+// Players are winners or losers according to the winner data, falling back to the members of the winning team.
+// Winner data without any winners is a draw.
+func (g *HistoricGame) PlayerResults() []*PlayerResult {
+	results := make([]*PlayerResult, 0, len(g.Players))
+	byId := make(map[uuid.UUID]*PlayerResult)
+
+	addPlayer := func(id uuid.UUID) *PlayerResult {
+		if r, ok := byId[id]; ok {
+			return r
+		}
+
+		r := &PlayerResult{PlayerId: id}
+		byId[id] = r
+		results = append(results, r)
+		return r
+	}
+
+	// Games saved before participation was tracked only have the players present at the finish
+	for _, p := range g.Participation {
+		r := addPlayer(p.PlayerId)
+		r.LeftEarly = p.LeftEarly
+		r.TimeInGame = p.TimeInGame
+	}
+	for _, p := range g.Players {
+		addPlayer(p.Id)
+	}
+
+	if g.TeamData != nil {
+		for _, t := range *g.TeamData {
+			for _, id := range t.PlayerIds {
+				if r, ok := byId[id]; ok {
+					r.TeamId = t.Id
+				}
+			}
+		}
+	}
+
+	switch {
+	case g.WinnerData != nil && (len(g.WinnerData.WinnerIds) > 0 || len(g.WinnerData.LoserIds) > 0):
+		for _, id := range g.WinnerData.WinnerIds {
+			if r, ok := byId[id]; ok {
+				r.Won = true
+			}
+		}
+		for _, id := range g.WinnerData.LoserIds {
+			if r, ok := byId[id]; ok && !r.Won {
+				r.Lost = true
+			}
+		}
+		// Not every game mode lists its losers, so everyone else lost if anyone won
+		if len(g.WinnerData.WinnerIds) > 0 {
+			for _, r := range results {
+				r.Lost = !r.Won
+			}
+		}
+	case g.WinningTeamId != "":
+		for _, r := range results {
+			if r.TeamId == "" {
+				continue
+			}
+
+			r.Won = r.TeamId == g.WinningTeamId
+			r.Lost = !r.Won
+		}
+	}
+
+	// A game has a result if it sent winner data, even without any winners, or a winning team was resolved
+	decided := g.WinnerData != nil
+	for _, r := range results {
+		decided = decided || r.Won || r.Lost
+	}
+	for _, r := range results {
+		switch {
+		case r.Won:
+			r.Outcome = OutcomeWin
+		case r.Lost:
+			r.Outcome = OutcomeLoss
+		case !decided:
+			r.Outcome = OutcomeNone
+		case r.LeftEarly:
+			r.Outcome = OutcomeLoss
+		default:
+			r.Outcome = OutcomeDraw
+		}
+	}
+
+	if data, ok := g.GameData.(*HistoricBlockSumoData); ok && data.Scoreboard != nil {
+		for id, e := range data.Scoreboard.Entries {
+			if r, ok := byId[id]; ok {
+				r.Kills = e.Kills
+				r.FinalKills = e.FinalKills
+			}
+		}
+	}
+
+	return results
+}
+
+// PlayerStats are a player's totals across every game of a game mode, updated as games finish
+type PlayerStats struct {
+	PlayerId   uuid.UUID `bson:"playerId"`
+	GameModeId string    `bson:"gameModeId"`
+	// SeasonId is empty for all-time stats
+	SeasonId string `bson:"seasonId,omitempty"`
+
+	GamesPlayed int64 `bson:"gamesPlayed"`
+	Wins        int64 `bson:"wins"`
+	Losses      int64 `bson:"losses"`
+	Draws       int64 `bson:"draws"`
+	LeftEarly   int64 `bson:"leftEarly"`
+
+	// WinStreak and LossStreak are the current streaks. At most one is non-zero.
+	// Draws end both, games abandoned without a result leave them unchanged.
+	WinStreak      int64 `bson:"winStreak"`
+	BestWinStreak  int64 `bson:"bestWinStreak"`
+	LossStreak     int64 `bson:"lossStreak"`
+	BestLossStreak int64 `bson:"bestLossStreak"`
+
+	TimePlayed time.Duration `bson:"timePlayed"`
+
+	// Block Sumo
+	Kills      int64 `bson:"kills"`
+	FinalKills int64 `bson:"finalKills"`
+
+	LastPlayed time.Time `bson:"lastPlayed"`
+}
+
+// StatsMetric is a player stats field players can be ranked by
+type StatsMetric string
+
+const (
+	StatsMetricWins          StatsMetric = "wins"
+	StatsMetricGamesPlayed   StatsMetric = "gamesPlayed"
+	StatsMetricBestWinStreak StatsMetric = "bestWinStreak"
+	StatsMetricKills         StatsMetric = "kills"
+	StatsMetricFinalKills    StatsMetric = "finalKills"
+)
+
+var StatsMetrics = []StatsMetric{StatsMetricWins, StatsMetricGamesPlayed, StatsMetricBestWinStreak,
+	StatsMetricKills, StatsMetricFinalKills}
+
+func (m StatsMetric) Valid() bool {
+	for _, metric := range StatsMetrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the stats' value of the metric
+func (m StatsMetric) Value(s *PlayerStats) int64 {
+	switch m {
+	case StatsMetricWins:
+		return s.Wins
+	case StatsMetricGamesPlayed:
+		return s.GamesPlayed
+	case StatsMetricBestWinStreak:
+		return s.BestWinStreak
+	case StatsMetricKills:
+		return s.Kills
+	case StatsMetricFinalKills:
+		return s.FinalKills
+	}
+	return 0
+}
@@ -0,0 +1,186 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+// testResult is the part of a PlayerResult that depends on the game's result
+type testResult struct {
+	player  *BasicPlayer
+	teamId  string
+	outcome Outcome
+	won     bool
+	lost    bool
+}
+
+// testTeams puts A and B on red and C on blue
+func testTeams() *[]*Team {
+	return &[]*Team{
+		{Id: "red", PlayerIds: []uuid.UUID{testPlayerA.Id, testPlayerB.Id}},
+		{Id: "blue", PlayerIds: []uuid.UUID{testPlayerC.Id}},
+	}
+}
+
+func TestPlayerResults(t *testing.T) {
+	players := []*BasicPlayer{testPlayerA, testPlayerB, testPlayerC}
+
+	tests := []struct {
+		name          string
+		players       []*BasicPlayer
+		participation []*PlayerParticipation
+		teams         *[]*Team
+		winnerData    *HistoricWinnerData
+		winningTeamId string
+		want          []testResult
+	}{
+		{
+			name:       "winners and losers",
+			players:    players,
+			winnerData: &HistoricWinnerData{WinnerIds: []uuid.UUID{testPlayerA.Id}, LoserIds: []uuid.UUID{testPlayerB.Id}},
+			want: []testResult{
+				{player: testPlayerA, outcome: OutcomeWin, won: true},
+				{player: testPlayerB, outcome: OutcomeLoss, lost: true},
+				// Everyone who didn't win lost, even if the losers aren't listed
+				{player: testPlayerC, outcome: OutcomeLoss, lost: true},
+			},
+		},
+		{
+			name:       "losers without winners",
+			players:    players,
+			winnerData: &HistoricWinnerData{LoserIds: []uuid.UUID{testPlayerB.Id}},
+			want: []testResult{
+				{player: testPlayerA, outcome: OutcomeDraw},
+				{player: testPlayerB, outcome: OutcomeLoss, lost: true},
+				{player: testPlayerC, outcome: OutcomeDraw},
+			},
+		},
+		{
+			name:       "winner data without winners is a draw",
+			players:    players,
+			winnerData: &HistoricWinnerData{},
+			want: []testResult{
+				{player: testPlayerA, outcome: OutcomeDraw},
+				{player: testPlayerB, outcome: OutcomeDraw},
+				{player: testPlayerC, outcome: OutcomeDraw},
+			},
+		},
+		{
+			name:    "no result",
+			players: players,
+			want: []testResult{
+				{player: testPlayerA, outcome: OutcomeNone},
+				{player: testPlayerB, outcome: OutcomeNone},
+				{player: testPlayerC, outcome: OutcomeNone},
+			},
+		},
+		{
+			name:          "winning team",
+			players:       players,
+			teams:         testTeams(),
+			winningTeamId: "blue",
+			want: []testResult{
+				{player: testPlayerA, teamId: "red", outcome: OutcomeLoss, lost: true},
+				{player: testPlayerB, teamId: "red", outcome: OutcomeLoss, lost: true},
+				{player: testPlayerC, teamId: "blue", outcome: OutcomeWin, won: true},
+			},
+		},
+		{
+			name:          "winner data takes precedence over the winning team",
+			players:       players,
+			teams:         testTeams(),
+			winnerData:    &HistoricWinnerData{WinnerIds: []uuid.UUID{testPlayerA.Id}},
+			winningTeamId: "blue",
+			want: []testResult{
+				{player: testPlayerA, teamId: "red", outcome: OutcomeWin, won: true},
+				{player: testPlayerB, teamId: "red", outcome: OutcomeLoss, lost: true},
+				{player: testPlayerC, teamId: "blue", outcome: OutcomeLoss, lost: true},
+			},
+		},
+		{
+			name:    "players who left early come first and lose a decided game",
+			players: []*BasicPlayer{testPlayerA, testPlayerB},
+			participation: []*PlayerParticipation{
+				{PlayerId: testPlayerC.Id, LeftEarly: true, TimeInGame: time.Minute},
+				{PlayerId: testPlayerA.Id},
+				{PlayerId: testPlayerB.Id},
+			},
+			winnerData: &HistoricWinnerData{},
+			want: []testResult{
+				{player: testPlayerC, outcome: OutcomeLoss},
+				{player: testPlayerA, outcome: OutcomeDraw},
+				{player: testPlayerB, outcome: OutcomeDraw},
+			},
+		},
+		{
+			name:          "leaving early without a result",
+			players:       []*BasicPlayer{testPlayerA},
+			participation: []*PlayerParticipation{{PlayerId: testPlayerB.Id, LeftEarly: true}},
+			want: []testResult{
+				{player: testPlayerB, outcome: OutcomeNone},
+				{player: testPlayerA, outcome: OutcomeNone},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := &HistoricGame{
+				Game:          &Game{Players: tt.players, TeamData: tt.teams},
+				Participation: tt.participation,
+				WinnerData:    tt.winnerData,
+				WinningTeamId: tt.winningTeamId,
+			}
+
+			got := game.PlayerResults()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d results, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				r := got[i]
+				if r.PlayerId != want.player.Id {
+					t.Fatalf("result %d is of %s, want %s", i, r.PlayerId, want.player.Username)
+				}
+				if r.TeamId != want.teamId || r.Outcome != want.outcome || r.Won != want.won || r.Lost != want.lost {
+					t.Errorf("%s: team %q outcome %q won %v lost %v, want team %q outcome %q won %v lost %v",
+						want.player.Username, r.TeamId, r.Outcome, r.Won, r.Lost,
+						want.teamId, want.outcome, want.won, want.lost)
+				}
+			}
+		})
+	}
+}
+
+func TestPlayerResultsBlockSumo(t *testing.T) {
+	game := &HistoricGame{
+		Game: &Game{
+			Players: []*BasicPlayer{testPlayerA, testPlayerB},
+			GameData: &HistoricBlockSumoData{Scoreboard: &BlockSumoScoreboard{Entries: map[uuid.UUID]*BlockSumoScoreboardEntry{
+				testPlayerA.Id: {Kills: 3, FinalKills: 1},
+				testPlayerC.Id: {Kills: 5},
+			}}},
+		},
+	}
+
+	tests := []struct {
+		player     *BasicPlayer
+		kills      int32
+		finalKills int32
+	}{
+		{player: testPlayerA, kills: 3, finalKills: 1},
+		{player: testPlayerB},
+	}
+
+	results := game.PlayerResults()
+	if len(results) != len(tests) {
+		t.Fatalf("got %d results, want %d, so the scoreboard added a player", len(results), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.player.Username, func(t *testing.T) {
+			if r := results[i]; r.Kills != tt.kills || r.FinalKills != tt.finalKills {
+				t.Errorf("kills %d final kills %d, want %d and %d", r.Kills, r.FinalKills, tt.kills, tt.finalKills)
+			}
+		})
+	}
+}
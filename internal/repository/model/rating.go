@@ -0,0 +1,91 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
+
+const (
+	// InitialRating is the rating of a player's first game in a game mode, all-time and in each season
+	InitialRating = 1000
+
+	// ratingK is the most a rating can change by in one game
+	ratingK = 32
+	// ratingScale is the rating difference at which the higher rated player is expected to win 10 times as often
+	ratingScale = 400
+)
+
+// PlayerRating is a player's Elo rating in a game mode, updated as games with a result finish
+type PlayerRating struct {
+	PlayerId   uuid.UUID `bson:"playerId"`
+	GameModeId string    `bson:"gameModeId"`
+	// SeasonId is empty for the all-time rating
+	SeasonId string `bson:"seasonId"`
+
+	Rating     float64 `bson:"rating"`
+	PeakRating float64 `bson:"peakRating"`
+	// LastChange is how much the last rated game changed the rating by
+	LastChange float64 `bson:"lastChange"`
+	// Games is the number of rated games, which excludes games without a result
+	Games int64 `bson:"games"`
+
+	LastPlayed time.Time `bson:"lastPlayed"`
+}
+
+// RatingChanges returns how much the rating of each player changes by from the game's results, given their ratings
+// before it. Players missing from ratings are rated InitialRating.
+//
+// Each player is compared with every opponent that had a different outcome, or that drew along with them.
+// Teammates and players sharing a win or loss (such as the losers of a free-for-all) aren't compared.
+// A player's change is the average of their pairwise Elo changes, so it doesn't grow with the number of opponents.
+// Players without a result, or without anyone to be compared with, aren't returned.
+func RatingChanges(results []*PlayerResult, ratings map[uuid.UUID]float64) map[uuid.UUID]float64 {
+	rating := func(id uuid.UUID) float64 {
+		if r, ok := ratings[id]; ok {
+			return r
+		}
+		return InitialRating
+	}
+
+	changes := make(map[uuid.UUID]float64)
+	for _, a := range results {
+		var total float64
+		var opponents int
+
+		for _, b := range results {
+			if a == b || (a.TeamId != "" && a.TeamId == b.TeamId) {
+				continue
+			}
+
+			score, ok := pairScore(a.Outcome, b.Outcome)
+			if !ok {
+				continue
+			}
+
+			expected := 1 / (1 + math.Pow(10, (rating(b.PlayerId)-rating(a.PlayerId))/ratingScale))
+			total += ratingK * (score - expected)
+			opponents++
+		}
+
+		if opponents > 0 {
+			changes[a.PlayerId] = total / float64(opponents)
+		}
+	}
+
+	return changes
+}
+
+// pairScore returns a's Elo score against b, or false if they shouldn't be compared
+func pairScore(a Outcome, b Outcome) (float64, bool) {
+	switch {
+	case a == OutcomeWin && b == OutcomeLoss:
+		return 1, true
+	case a == OutcomeLoss && b == OutcomeWin:
+		return 0, true
+	case a == OutcomeDraw && b == OutcomeDraw:
+		return 0.5, true
+	default:
+		return 0, false
+	}
+}
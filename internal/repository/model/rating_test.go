@@ -0,0 +1,88 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"testing"
+)
+
+func TestRatingChanges(t *testing.T) {
+	a := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	b := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	c := uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	d := uuid.MustParse("00000000-0000-0000-0000-00000000000d")
+
+	tests := []struct {
+		name    string
+		results []*PlayerResult
+		ratings map[uuid.UUID]float64
+		want    map[uuid.UUID]float64
+	}{
+		{
+			name: "equal ratings",
+			results: []*PlayerResult{
+				{PlayerId: a, Outcome: OutcomeWin},
+				{PlayerId: b, Outcome: OutcomeLoss},
+			},
+			want: map[uuid.UUID]float64{a: 16, b: -16},
+		},
+		{
+			name: "favourite wins",
+			results: []*PlayerResult{
+				{PlayerId: a, Outcome: OutcomeWin},
+				{PlayerId: b, Outcome: OutcomeLoss},
+			},
+			ratings: map[uuid.UUID]float64{a: 1400},
+			want:    map[uuid.UUID]float64{a: 32 * (1 - 10.0/11), b: -32 * (1 - 10.0/11)},
+		},
+		{
+			name: "draw with equal ratings",
+			results: []*PlayerResult{
+				{PlayerId: a, Outcome: OutcomeDraw},
+				{PlayerId: b, Outcome: OutcomeDraw},
+			},
+			want: map[uuid.UUID]float64{a: 0, b: 0},
+		},
+		{
+			name: "free for all losers aren't compared",
+			results: []*PlayerResult{
+				{PlayerId: a, Outcome: OutcomeWin},
+				{PlayerId: b, Outcome: OutcomeLoss},
+				{PlayerId: c, Outcome: OutcomeLoss},
+			},
+			want: map[uuid.UUID]float64{a: 16, b: -16, c: -16},
+		},
+		{
+			name: "teammates aren't compared",
+			results: []*PlayerResult{
+				{PlayerId: a, TeamId: "red", Outcome: OutcomeWin},
+				{PlayerId: b, TeamId: "red", Outcome: OutcomeWin},
+				{PlayerId: c, TeamId: "blue", Outcome: OutcomeLoss},
+				{PlayerId: d, TeamId: "blue", Outcome: OutcomeLoss},
+			},
+			want: map[uuid.UUID]float64{a: 16, b: 16, c: -16, d: -16},
+		},
+		{
+			name: "no result",
+			results: []*PlayerResult{
+				{PlayerId: a, Outcome: OutcomeNone},
+				{PlayerId: b, Outcome: OutcomeNone},
+			},
+			want: map[uuid.UUID]float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RatingChanges(tt.results, tt.ratings)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d changes, want %d: %v", len(got), len(tt.want), got)
+			}
+			for id, want := range tt.want {
+				if change, ok := got[id]; !ok || math.Abs(change-want) > 1e-9 {
+					t.Errorf("change of %s = %v, want %v", id, change, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type RecomputePhase string
+
+const (
+	// RecomputeApplying is while games are applied to the recompute collections. Consumers record the games that
+	// finish meanwhile into both the live and the recompute collections.
+	RecomputeApplying RecomputePhase = "applying"
+	// RecomputeSwapping is while the recompute collections replace the live ones. Consumers pause recording stats
+	// until the swap is complete.
+	RecomputeSwapping RecomputePhase = "swapping"
+)
+
+// RecomputeCheckpoint is the progress of a recompute of the derived collections. Games are walked in end time
+// order, breaking ties by id, so the last game walked is enough to resume from. Its existence tells consumers
+// a recompute is running.
+type RecomputeCheckpoint struct {
+	Phase     RecomputePhase `bson:"phase"`
+	StartedAt time.Time      `bson:"startedAt"`
+	UpdatedAt time.Time      `bson:"updatedAt"`
+
+	// LastEndTime and LastGameId are of the last game walked, zero if none have been
+	LastEndTime time.Time          `bson:"lastEndTime"`
+	LastGameId  primitive.ObjectID `bson:"lastGameId"`
+	// Walked is true once every game has been walked, after which the games the walk missed are caught up
+	Walked bool `bson:"walked"`
+	// Games is the number of games applied by the recompute, excluding those applied by consumers
+	Games int64 `bson:"games"`
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SeasonSnapshot is the final standings of a season, saved once it ends for the hall of fame
+type SeasonSnapshot struct {
+	SeasonId  string    `bson:"_id"`
+	Start     time.Time `bson:"start"`
+	End       time.Time `bson:"end"`
+	CreatedAt time.Time `bson:"createdAt"`
+
+	GameModes []*SeasonGameModeSnapshot `bson:"gameModes"`
+
+	// PlayerIds are every player in the snapshot, kept so they can be erased
+	PlayerIds []uuid.UUID `bson:"playerIds"`
+}
+
+type SeasonGameModeSnapshot struct {
+	GameModeId   string               `bson:"gameModeId"`
+	Leaderboards []*SeasonLeaderboard `bson:"leaderboards"`
+	// Ratings are the highest season ratings, absent from snapshots taken before ratings were added
+	Ratings []*SeasonRatingEntry `bson:"ratings,omitempty"`
+}
+
+type SeasonLeaderboard struct {
+	Metric  StatsMetric               `bson:"metric"`
+	Entries []*SeasonLeaderboardEntry `bson:"entries"`
+}
+
+type SeasonLeaderboardEntry struct {
+	Rank     int       `bson:"rank"`
+	PlayerId uuid.UUID `bson:"playerId"`
+	// Username is the player's username when the season ended
+	Username string `bson:"username"`
+	Value    int64  `bson:"value"`
+}
+
+type SeasonRatingEntry struct {
+	Rank     int       `bson:"rank"`
+	PlayerId uuid.UUID `bson:"playerId"`
+	// Username is the player's username when the season ended
+	Username   string  `bson:"username"`
+	Rating     float64 `bson:"rating"`
+	PeakRating float64 `bson:"peakRating"`
+	Games      int64   `bson:"games"`
+}
+
+// ReplacePlayer replaces the player with the pseudonym and scrubs their username.
+// It returns false if the snapshot didn't reference the player.
+func (s *SeasonSnapshot) ReplacePlayer(playerId uuid.UUID, pseudonym uuid.UUID) bool {
+	changed := replaceId(s.PlayerIds, playerId, pseudonym)
+
+	for _, g := range s.GameModes {
+		for _, l := range g.Leaderboards {
+			for _, e := range l.Entries {
+				if e.PlayerId == playerId {
+					e.PlayerId = pseudonym
+					e.Username = ErasedUsername
+					changed = true
+				}
+			}
+		}
+
+		for _, e := range g.Ratings {
+			if e.PlayerId == playerId {
+				e.PlayerId = pseudonym
+				e.Username = ErasedUsername
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
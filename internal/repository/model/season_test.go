@@ -0,0 +1,71 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestSeasonSnapshotReplacePlayer(t *testing.T) {
+	player := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	other := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	pseudonym := uuid.MustParse("00000000-0000-0000-0000-0000000000ff")
+
+	tests := []struct {
+		name     string
+		snapshot *SeasonSnapshot
+		want     bool
+	}{
+		{
+			name: "leaderboard entry",
+			snapshot: &SeasonSnapshot{PlayerIds: []uuid.UUID{player}, GameModes: []*SeasonGameModeSnapshot{{
+				Leaderboards: []*SeasonLeaderboard{{Entries: []*SeasonLeaderboardEntry{{PlayerId: player, Username: "player"}}}},
+			}}},
+			want: true,
+		},
+		{
+			name: "rating entry",
+			snapshot: &SeasonSnapshot{PlayerIds: []uuid.UUID{player}, GameModes: []*SeasonGameModeSnapshot{{
+				Ratings: []*SeasonRatingEntry{{PlayerId: other, Username: "other"}, {PlayerId: player, Username: "player"}},
+			}}},
+			want: true,
+		},
+		{
+			name: "other players",
+			snapshot: &SeasonSnapshot{PlayerIds: []uuid.UUID{other}, GameModes: []*SeasonGameModeSnapshot{{
+				Ratings: []*SeasonRatingEntry{{PlayerId: other, Username: "other"}},
+			}}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.snapshot.ReplacePlayer(player, pseudonym); got != tt.want {
+				t.Errorf("ReplacePlayer() = %v, want %v", got, tt.want)
+			}
+
+			for _, g := range tt.snapshot.GameModes {
+				for _, l := range g.Leaderboards {
+					for _, e := range l.Entries {
+						if e.PlayerId == player || (e.PlayerId == pseudonym && e.Username != ErasedUsername) {
+							t.Errorf("leaderboard entry %s %q not erased", e.PlayerId, e.Username)
+						}
+					}
+				}
+				for _, e := range g.Ratings {
+					if e.PlayerId == player || (e.PlayerId == pseudonym && e.Username != ErasedUsername) {
+						t.Errorf("rating entry %s %q not erased", e.PlayerId, e.Username)
+					}
+					if e.PlayerId == other && e.Username != "other" {
+						t.Errorf("other player's username changed to %q", e.Username)
+					}
+				}
+			}
+			for _, id := range tt.snapshot.PlayerIds {
+				if id == player {
+					t.Error("player id not replaced")
+				}
+			}
+		})
+	}
+}
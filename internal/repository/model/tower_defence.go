@@ -1,34 +1,104 @@
 package model
 
-import "github.com/emortalmc/proto-specs/gen/go/model/gametracker"
+import (
+	"github.com/emortalmc/proto-specs/gen/go/model/gametracker"
+	"time"
+)
+
+const (
+	TowerDefenceRedTeamId  = "red"
+	TowerDefenceBlueTeamId = "blue"
+)
+
+// maxHealthHistory bounds the live game document of a long game. Once reached, each change replaces the latest
+// snapshot, which only loses damage dealt between a tower being healed and the next change.
+const maxHealthHistory = 1000
 
 type LiveTowerDefenceData struct {
 	MaxHealth  int32 `bson:"maxHealth"`
 	RedHealth  int32 `bson:"redHealth"`
 	BlueHealth int32 `bson:"blueHealth"`
+
+	// HealthHistory is every change in health received for the game, used to compute analytics on finish
+	HealthHistory []*TowerDefenceHealthSnapshot `bson:"healthHistory,omitempty"`
+}
+
+type TowerDefenceHealthSnapshot struct {
+	Time       time.Time `bson:"time"`
+	RedHealth  int32     `bson:"redHealth"`
+	BlueHealth int32     `bson:"blueHealth"`
 }
 
-func (d *LiveTowerDefenceData) Update(data *gametracker.TowerDefenceUpdateData) {
+// Update applies the health of an update message. The time is when the message was sent, on the game's clock
+// (see Game.GameTime), so the history can be compared with the game's end time and replays record the same history.
+func (d *LiveTowerDefenceData) Update(data *gametracker.TowerDefenceUpdateData, t time.Time) {
 	healthData := data.HealthData
 
 	d.RedHealth = healthData.RedHealth
 	d.BlueHealth = healthData.BlueHealth
+	d.recordHealth(t)
+}
+
+// recordHealth adds the current health to the history if it has changed, as updates are also sent for other reasons
+func (d *LiveTowerDefenceData) recordHealth(t time.Time) {
+	snapshot := &TowerDefenceHealthSnapshot{
+		Time:       t,
+		RedHealth:  d.RedHealth,
+		BlueHealth: d.BlueHealth,
+	}
+
+	if n := len(d.HealthHistory); n > 0 {
+		last := d.HealthHistory[n-1]
+		if last.RedHealth == d.RedHealth && last.BlueHealth == d.BlueHealth {
+			return
+		}
+
+		if n >= maxHealthHistory {
+			d.HealthHistory[n-1] = snapshot
+			return
+		}
+	}
+
+	d.HealthHistory = append(d.HealthHistory, snapshot)
 }
 
-func CreateLiveTowerDefenceDataFromStart(data *gametracker.TowerDefenceStartData) *LiveTowerDefenceData {
+// CreateLiveTowerDefenceDataFromStart creates the live data of a start message sent at the time, on the game's clock
+func CreateLiveTowerDefenceDataFromStart(data *gametracker.TowerDefenceStartData, t time.Time) *LiveTowerDefenceData {
 	healthData := data.HealthData
 
-	return &LiveTowerDefenceData{
+	d := &LiveTowerDefenceData{
 		MaxHealth:  healthData.MaxHealth,
 		RedHealth:  healthData.RedHealth,
 		BlueHealth: healthData.BlueHealth,
 	}
+	d.recordHealth(t)
+
+	return d
 }
 
 type HistoricTowerDefenceData struct {
 	MaxHealth  int32 `bson:"maxHealth"`
 	RedHealth  int32 `bson:"redHealth"`
 	BlueHealth int32 `bson:"blueHealth"`
+
+	// Analytics is only present if the game's live data was available when it finished
+	Analytics *TowerDefenceAnalytics `bson:"analytics,omitempty"`
+}
+
+type TowerDefenceAnalytics struct {
+	// WinningTeam is the team that won according to the game's result, empty if there wasn't a single winning team
+	WinningTeam string `bson:"winningTeam,omitempty"`
+
+	// RedDamageDealt is the total damage dealt to the blue tower by the red team and vice versa
+	RedDamageDealt  int32 `bson:"redDamageDealt"`
+	BlueDamageDealt int32 `bson:"blueDamageDealt"`
+
+	FirstDamageTime *time.Time `bson:"firstDamageTime,omitempty"`
+
+	// LeadChanges is the number of times the team with the most health changed, ignoring ties
+	LeadChanges int32 `bson:"leadChanges"`
+	// Comeback is true if the winning team had less health than the losing team at some point
+	Comeback bool `bson:"comeback"`
 }
 
 func CreateHistoricTowerDefenceDataFromFinish(data *gametracker.TowerDefenceFinishData) *HistoricTowerDefenceData {
@@ -40,3 +110,82 @@ func CreateHistoricTowerDefenceDataFromFinish(data *gametracker.TowerDefenceFini
 		BlueHealth: healthData.BlueHealth,
 	}
 }
+
+// ComputeAnalytics derives the game's analytics from the live game's health history and the final health.
+// The winning team is the game's (see HistoricGame.WinningTeamId), as a game can be won by a team with less health,
+// such as by the other team leaving.
+func (d *HistoricTowerDefenceData) ComputeAnalytics(live *LiveTowerDefenceData, endTime time.Time, winningTeam string) {
+	history := make([]*TowerDefenceHealthSnapshot, len(live.HealthHistory), len(live.HealthHistory)+1)
+	copy(history, live.HealthHistory)
+	history = append(history, &TowerDefenceHealthSnapshot{
+		Time:       endTime,
+		RedHealth:  d.RedHealth,
+		BlueHealth: d.BlueHealth,
+	})
+
+	analytics := &TowerDefenceAnalytics{WinningTeam: winningTeam}
+
+	lastLeader := ""
+	for i, snapshot := range history {
+		if i > 0 {
+			previous := history[i-1]
+
+			redDamage := previous.BlueHealth - snapshot.BlueHealth
+			blueDamage := previous.RedHealth - snapshot.RedHealth
+			if redDamage > 0 {
+				analytics.RedDamageDealt += redDamage
+			}
+			if blueDamage > 0 {
+				analytics.BlueDamageDealt += blueDamage
+			}
+
+			if analytics.FirstDamageTime == nil && (redDamage > 0 || blueDamage > 0) {
+				analytics.FirstDamageTime = &snapshot.Time
+			}
+		}
+
+		leader := healthLeader(snapshot.RedHealth, snapshot.BlueHealth)
+		if leader == "" {
+			continue
+		}
+
+		if lastLeader != "" && leader != lastLeader {
+			analytics.LeadChanges++
+		}
+		lastLeader = leader
+
+		if analytics.WinningTeam != "" && leader != analytics.WinningTeam {
+			analytics.Comeback = true
+		}
+	}
+
+	d.Analytics = analytics
+}
+
+// healthLeader returns the team with the most health, or an empty string if they are tied.
+func healthLeader(redHealth int32, blueHealth int32) string {
+	switch {
+	case redHealth > blueHealth:
+		return TowerDefenceRedTeamId
+	case blueHealth > redHealth:
+		return TowerDefenceBlueTeamId
+	default:
+		return ""
+	}
+}
+
+// TowerDefenceMapWinRate is the number of wins for each team across all Tower Defence games played on a map.
+type TowerDefenceMapWinRate struct {
+	MapId    string `bson:"_id"`
+	Games    int32  `bson:"games"`
+	RedWins  int32  `bson:"redWins"`
+	BlueWins int32  `bson:"blueWins"`
+}
+
+func (r *TowerDefenceMapWinRate) RedWinRate() float64 {
+	return float64(r.RedWins) / float64(r.Games)
+}
+
+func (r *TowerDefenceMapWinRate) BlueWinRate() float64 {
+	return float64(r.BlueWins) / float64(r.Games)
+}
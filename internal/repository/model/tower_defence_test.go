@@ -0,0 +1,160 @@
+package model
+
+import (
+	"github.com/emortalmc/proto-specs/gen/go/model/gametracker"
+	"testing"
+)
+
+func TestComputeAnalytics(t *testing.T) {
+	type health struct {
+		second    int
+		red, blue int32
+	}
+
+	tests := []struct {
+		name        string
+		history     []health
+		final       health
+		winningTeam string
+		want        TowerDefenceAnalytics
+		// wantFirstDamage is the second of the first damage, -1 if none was dealt
+		wantFirstDamage int
+	}{
+		{
+			name:            "no damage",
+			history:         []health{{0, 100, 100}},
+			final:           health{60, 100, 100},
+			want:            TowerDefenceAnalytics{},
+			wantFirstDamage: -1,
+		},
+		{
+			name:            "red wins without losing the lead",
+			history:         []health{{0, 100, 100}, {10, 100, 80}, {20, 90, 50}},
+			final:           health{30, 90, 0},
+			winningTeam:     TowerDefenceRedTeamId,
+			want:            TowerDefenceAnalytics{WinningTeam: TowerDefenceRedTeamId, RedDamageDealt: 100, BlueDamageDealt: 10},
+			wantFirstDamage: 10,
+		},
+		{
+			name:        "blue comes back",
+			history:     []health{{0, 100, 100}, {10, 100, 60}, {20, 40, 60}},
+			final:       health{30, 0, 60},
+			winningTeam: TowerDefenceBlueTeamId,
+			want: TowerDefenceAnalytics{WinningTeam: TowerDefenceBlueTeamId, RedDamageDealt: 40, BlueDamageDealt: 100,
+				LeadChanges: 1, Comeback: true},
+			wantFirstDamage: 10,
+		},
+		{
+			name:        "winner with less health",
+			history:     []health{{0, 100, 100}, {10, 100, 70}},
+			final:       health{20, 100, 70},
+			winningTeam: TowerDefenceBlueTeamId,
+			want: TowerDefenceAnalytics{WinningTeam: TowerDefenceBlueTeamId, RedDamageDealt: 30,
+				Comeback: true},
+			wantFirstDamage: 10,
+		},
+		{
+			name:            "no winner",
+			history:         []health{{0, 100, 100}, {10, 100, 70}},
+			final:           health{20, 80, 70},
+			want:            TowerDefenceAnalytics{RedDamageDealt: 30, BlueDamageDealt: 20},
+			wantFirstDamage: 10,
+		},
+		{
+			name:            "healing isn't damage",
+			history:         []health{{0, 100, 100}, {10, 100, 50}, {20, 100, 80}},
+			final:           health{30, 100, 70},
+			winningTeam:     TowerDefenceRedTeamId,
+			want:            TowerDefenceAnalytics{WinningTeam: TowerDefenceRedTeamId, RedDamageDealt: 60},
+			wantFirstDamage: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := &LiveTowerDefenceData{MaxHealth: 100}
+			for _, h := range tt.history {
+				live.HealthHistory = append(live.HealthHistory, &TowerDefenceHealthSnapshot{Time: at(h.second), RedHealth: h.red, BlueHealth: h.blue})
+			}
+
+			data := &HistoricTowerDefenceData{MaxHealth: 100, RedHealth: tt.final.red, BlueHealth: tt.final.blue}
+			data.ComputeAnalytics(live, at(tt.final.second), tt.winningTeam)
+
+			got := *data.Analytics
+			firstDamage := -1
+			if got.FirstDamageTime != nil {
+				firstDamage = int(got.FirstDamageTime.Sub(at(0)).Seconds())
+			}
+			got.FirstDamageTime = nil
+
+			if got != tt.want {
+				t.Errorf("analytics = %+v, want %+v", got, tt.want)
+			}
+			if firstDamage != tt.wantFirstDamage {
+				t.Errorf("first damage = %ds, want %ds", firstDamage, tt.wantFirstDamage)
+			}
+		})
+	}
+}
+
+func TestUpdateHealth(t *testing.T) {
+	tests := []struct {
+		name string
+		// updates are sent a second apart
+		updates [][2]int32
+		// existing is the number of distinct snapshots already in the history
+		existing int
+		want     int
+		wantLast [2]int32
+		// wantLastSecond is when the last snapshot was taken
+		wantLastSecond int
+	}{
+		{
+			name:           "changes",
+			updates:        [][2]int32{{100, 90}, {100, 80}, {90, 80}},
+			want:           3,
+			wantLast:       [2]int32{90, 80},
+			wantLastSecond: 2,
+		},
+		{
+			name:     "unchanged",
+			updates:  [][2]int32{{100, 90}, {100, 90}, {100, 90}},
+			want:     1,
+			wantLast: [2]int32{100, 90},
+		},
+		{
+			name:           "full",
+			existing:       maxHealthHistory,
+			updates:        [][2]int32{{5, 5}, {4, 4}},
+			want:           maxHealthHistory,
+			wantLast:       [2]int32{4, 4},
+			wantLastSecond: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &LiveTowerDefenceData{}
+			for i := 0; i < tt.existing; i++ {
+				d.HealthHistory = append(d.HealthHistory, &TowerDefenceHealthSnapshot{RedHealth: int32(i + 1000)})
+			}
+
+			for i, u := range tt.updates {
+				d.Update(&gametracker.TowerDefenceUpdateData{
+					HealthData: &gametracker.TowerDefenceHealthData{RedHealth: u[0], BlueHealth: u[1]},
+				}, at(i))
+			}
+
+			if len(d.HealthHistory) != tt.want {
+				t.Errorf("history = %d snapshots, want %d", len(d.HealthHistory), tt.want)
+			}
+			last := d.HealthHistory[len(d.HealthHistory)-1]
+			if got := [2]int32{last.RedHealth, last.BlueHealth}; got != tt.wantLast {
+				t.Errorf("last snapshot = %v, want %v", got, tt.wantLast)
+			}
+			if !last.Time.Equal(at(tt.wantLastSecond)) {
+				t.Errorf("last snapshot time = %s, want %s", last.Time, at(tt.wantLastSecond))
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+	"time"
+)
+
+// WebhookSubscription is a URL notified when a game matching its filter finishes. Empty filter fields match any game.
+type WebhookSubscription struct {
+	Id  primitive.ObjectID `bson:"_id"`
+	Url string             `bson:"url"`
+	// Secret signs every delivery so the receiver can verify it was sent by the tracker
+	Secret      string `bson:"secret"`
+	Description string `bson:"description,omitempty"`
+	// Format is the payload sent, WebhookFormatGame if empty
+	Format WebhookFormat `bson:"format,omitempty"`
+
+	GameModeId     string `bson:"gameModeId,omitempty"`
+	ServerIdPrefix string `bson:"serverIdPrefix,omitempty"`
+	// PlayerIds matches games any of the players took part in, including those they left early
+	PlayerIds []uuid.UUID `bson:"playerIds,omitempty"`
+
+	CreatedAt time.Time `bson:"createdAt"`
+}
+
+func (s *WebhookSubscription) Matches(game *HistoricGame) bool {
+	if s.GameModeId != "" && game.GameModeId != s.GameModeId {
+		return false
+	}
+	if s.ServerIdPrefix != "" && !strings.HasPrefix(game.ServerId, s.ServerIdPrefix) {
+		return false
+	}
+
+	if len(s.PlayerIds) == 0 {
+		return true
+	}
+
+	for _, id := range s.PlayerIds {
+		for _, p := range game.Players {
+			if p.Id == id {
+				return true
+			}
+		}
+		for _, p := range game.Participation {
+			if p.PlayerId == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type WebhookFormat string
+
+const (
+	// WebhookFormatGame sends the full game in the export JSON format
+	WebhookFormatGame WebhookFormat = "game"
+	// WebhookFormatDiscord sends a match summary embed, so the URL can be a Discord channel webhook
+	WebhookFormatDiscord WebhookFormat = "discord"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed deliveries ran out of attempts or their subscription was removed
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a notification of a finished game to a subscription, saved with the game and sent by the dispatcher.
+type WebhookDelivery struct {
+	Id             primitive.ObjectID `bson:"_id"`
+	SubscriptionId primitive.ObjectID `bson:"subscriptionId"`
+	GameId         primitive.ObjectID `bson:"gameId"`
+	// PlayerIds are the players in the payload, kept so the delivery can be erased with any of them
+	PlayerIds []uuid.UUID `bson:"playerIds"`
+
+	// Payload is the JSON body, sent unchanged on every attempt
+	Payload []byte                `bson:"payload"`
+	Status  WebhookDeliveryStatus `bson:"status"`
+
+	Attempts []*WebhookAttempt `bson:"attempts,omitempty"`
+	// NextAttemptAt is when a pending delivery is next due. It is pushed back while a tracker is attempting it.
+	NextAttemptAt *time.Time `bson:"nextAttemptAt,omitempty"`
+
+	CreatedAt   time.Time  `bson:"createdAt"`
+	CompletedAt *time.Time `bson:"completedAt,omitempty"`
+}
+
+type WebhookAttempt struct {
+	Time time.Time `bson:"time"`
+	// StatusCode is the response status, zero if no response was received
+	StatusCode int           `bson:"statusCode,omitempty"`
+	Error      string        `bson:"error,omitempty"`
+	Duration   time.Duration `bson:"duration"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestWebhookSubscriptionMatches(t *testing.T) {
+	player := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	leaver := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	other := uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+
+	game := &HistoricGame{
+		Game: &Game{
+			GameModeId: "block-sumo",
+			ServerId:   "block-sumo-7d9f-abcde",
+			Players:    []*BasicPlayer{{Id: player, Username: "player"}},
+		},
+		Participation: []*PlayerParticipation{{PlayerId: player}, {PlayerId: leaver}},
+	}
+
+	tests := []struct {
+		name         string
+		subscription WebhookSubscription
+		want         bool
+	}{
+		{name: "no filter", want: true},
+		{name: "game mode", subscription: WebhookSubscription{GameModeId: "block-sumo"}, want: true},
+		{name: "other game mode", subscription: WebhookSubscription{GameModeId: "tower-defence"}, want: false},
+		{name: "server id prefix", subscription: WebhookSubscription{ServerIdPrefix: "block-sumo-7d9f"}, want: true},
+		{name: "other server id prefix", subscription: WebhookSubscription{ServerIdPrefix: "lobby"}, want: false},
+		{name: "player", subscription: WebhookSubscription{PlayerIds: []uuid.UUID{player}}, want: true},
+		{name: "player that left early", subscription: WebhookSubscription{PlayerIds: []uuid.UUID{leaver}}, want: true},
+		{name: "any of the players", subscription: WebhookSubscription{PlayerIds: []uuid.UUID{other, leaver}}, want: true},
+		{name: "other player", subscription: WebhookSubscription{PlayerIds: []uuid.UUID{other}}, want: false},
+		{
+			name:         "all filters",
+			subscription: WebhookSubscription{GameModeId: "block-sumo", ServerIdPrefix: "block-sumo", PlayerIds: []uuid.UUID{player}},
+			want:         true,
+		},
+		{
+			name:         "player in other game mode",
+			subscription: WebhookSubscription{GameModeId: "tower-defence", PlayerIds: []uuid.UUID{player}},
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.subscription.Matches(game); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
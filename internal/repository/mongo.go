@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"game-tracker/internal/config"
 	"game-tracker/internal/repository/model"
 	"game-tracker/internal/repository/registrytypes"
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -16,17 +18,56 @@ import (
 )
 
 const (
-	databaseName = "game-tracker"
-
 	liveGameCollectionName     = "liveGame"
 	historicGameCollectionName = "historicGame"
+	playerCollectionName       = "player"
+	erasureAuditCollectionName = "erasureAudit"
+	archiveCollectionName      = "archive"
+	outboxCollectionName       = "outbox"
+
+	webhookSubscriptionCollectionName = "webhookSubscription"
+	webhookDeliveryCollectionName     = "webhookDelivery"
+
+	playerStatsCollectionName       = "playerStats"
+	playerAchievementCollectionName = "playerAchievement"
+	playerPairCollectionName        = "playerPair"
+	playerSeasonStatsCollectionName = "playerSeasonStats"
+	playerSeasonPairCollectionName  = "playerSeasonPair"
+	seasonSnapshotCollectionName    = "seasonSnapshot"
+	leaderboardScoreCollectionName  = "leaderboardScore"
+	playerRatingCollectionName      = "playerRating"
+
+	recomputeCheckpointCollectionName = "recomputeCheckpoint"
+	recomputeClaimCollectionName      = "recomputeClaim"
 )
 
 type mongoRepository struct {
+	logger   *zap.SugaredLogger
 	database *mongo.Database
+	// transactions is true if MongoDB runs as a replica set or sharded cluster, which transactions require
+	transactions bool
 
 	liveGameCollection     *mongo.Collection
 	historicGameCollection *mongo.Collection
+	playerCollection       *mongo.Collection
+	erasureAuditCollection *mongo.Collection
+	archiveCollection      *mongo.Collection
+	outboxCollection       *mongo.Collection
+
+	webhookSubscriptionCollection *mongo.Collection
+	webhookDeliveryCollection     *mongo.Collection
+
+	playerStatsCollection       *mongo.Collection
+	playerAchievementCollection *mongo.Collection
+	playerPairCollection        *mongo.Collection
+	playerSeasonStatsCollection *mongo.Collection
+	playerSeasonPairCollection  *mongo.Collection
+	seasonSnapshotCollection    *mongo.Collection
+	leaderboardScoreCollection  *mongo.Collection
+	playerRatingCollection      *mongo.Collection
+
+	recomputeCheckpointCollection *mongo.Collection
+	recomputeClaimCollection      *mongo.Collection
 }
 
 func NewMongoRepository(ctx context.Context, logger *zap.SugaredLogger, wg *sync.WaitGroup, cfg config.MongoDBConfig) (Repository, error) {
@@ -35,11 +76,31 @@ func NewMongoRepository(ctx context.Context, logger *zap.SugaredLogger, wg *sync
 		return nil, fmt.Errorf("failed to connect to mongo: %w", err)
 	}
 
-	database := client.Database(databaseName)
+	database := client.Database(cfg.Database)
 	repo := &mongoRepository{
+		logger:                 logger,
 		database:               database,
 		liveGameCollection:     database.Collection(liveGameCollectionName),
 		historicGameCollection: database.Collection(historicGameCollectionName),
+		playerCollection:       database.Collection(playerCollectionName),
+		erasureAuditCollection: database.Collection(erasureAuditCollectionName),
+		archiveCollection:      database.Collection(archiveCollectionName),
+		outboxCollection:       database.Collection(outboxCollectionName),
+
+		webhookSubscriptionCollection: database.Collection(webhookSubscriptionCollectionName),
+		webhookDeliveryCollection:     database.Collection(webhookDeliveryCollectionName),
+
+		playerStatsCollection:       database.Collection(playerStatsCollectionName),
+		playerAchievementCollection: database.Collection(playerAchievementCollectionName),
+		playerPairCollection:        database.Collection(playerPairCollectionName),
+		playerSeasonStatsCollection: database.Collection(playerSeasonStatsCollectionName),
+		playerSeasonPairCollection:  database.Collection(playerSeasonPairCollectionName),
+		seasonSnapshotCollection:    database.Collection(seasonSnapshotCollectionName),
+		leaderboardScoreCollection:  database.Collection(leaderboardScoreCollectionName),
+		playerRatingCollection:      database.Collection(playerRatingCollectionName),
+
+		recomputeCheckpointCollection: database.Collection(recomputeCheckpointCollectionName),
+		recomputeClaimCollection:      database.Collection(recomputeClaimCollectionName),
 	}
 
 	wg.Add(1)
@@ -51,25 +112,109 @@ func NewMongoRepository(ctx context.Context, logger *zap.SugaredLogger, wg *sync
 		}
 	}()
 
+	if repo.transactions, err = repo.supportsTransactions(ctx); err != nil {
+		return nil, err
+	}
+
 	repo.createIndexes(ctx)
-	logger.Infow("created mongo indexes")
+	logger.Infow("created mongo indexes", "transactions", repo.transactions)
 
 	return repo, nil
 }
 
+// supportsTransactions asks the server whether it is a replica set member or a mongos router
+func (m *mongoRepository) supportsTransactions(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := m.database.Client().Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, fmt.Errorf("failed to get mongo topology: %w", err)
+	}
+
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
+
+func (m *mongoRepository) SupportsTransactions() bool {
+	return m.transactions
+}
+
+func (m *mongoRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested calls, such as FinishGame's in the consumer's finish transaction, join the transaction in progress
+	if !m.transactions || mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	session, err := m.database.Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// Repository methods called with the session context, or a context derived from it, join the transaction
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(ctx)
+	})
+
+	return err
+}
+
 var (
 	liveGameIndexes = []mongo.IndexModel{
 		{
 			Keys:    bson.D{{Key: "gameModeId", Value: 1}}, // todo this index might not be needed
 			Options: options.Index().SetName("gameModeId"),
 		},
+		{
+			Keys:    bson.D{{Key: "schemaVersion", Value: 1}},
+			Options: options.Index().SetName("schemaVersion"),
+		},
+		{
+			Keys:    bson.D{{Key: "mapId", Value: 1}},
+			Options: options.Index().SetName("mapId"),
+		},
 		// todo
 	}
 	historicGameIndexes = []mongo.IndexModel{
+		{
+			// Used by recomputes, which walk every game in the order they finished
+			Keys:    bson.D{{Key: "endTime", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("endTime_id"),
+		},
 		{
 			Keys:    bson.D{{Key: "gameModeId", Value: 1}}, // todo this index might not be needed
 			Options: options.Index().SetName("gameModeId"),
 		},
+		{
+			Keys:    bson.D{{Key: "schemaVersion", Value: 1}},
+			Options: options.Index().SetName("schemaVersion"),
+		},
+		{
+			Keys:    bson.D{{Key: "gameModeId", Value: 1}, {Key: "mapId", Value: 1}, {Key: "endTime", Value: -1}},
+			Options: options.Index().SetName("gameModeId_mapId_endTime"),
+		},
+		{
+			Keys:    bson.D{{Key: "mapId", Value: 1}, {Key: "endTime", Value: -1}},
+			Options: options.Index().SetName("mapId_endTime"),
+		},
+		{
+			Keys:    bson.D{{Key: "players.id", Value: 1}, {Key: "endTime", Value: -1}},
+			Options: options.Index().SetName("playerId_endTime"),
+		},
+		{
+			Keys:    bson.D{{Key: "participation.playerId", Value: 1}, {Key: "endTime", Value: -1}},
+			Options: options.Index().SetName("participationPlayerId_endTime"),
+		},
+		{
+			Keys:    bson.D{{Key: "gameModeId", Value: 1}, {Key: "duration", Value: 1}},
+			Options: options.Index().SetName("gameModeId_duration"),
+		},
 	} // todo
 )
 
@@ -77,6 +222,21 @@ func (m *mongoRepository) createIndexes(ctx context.Context) {
 	collIndexes := map[*mongo.Collection][]mongo.IndexModel{
 		m.liveGameCollection:     liveGameIndexes,
 		m.historicGameCollection: historicGameIndexes,
+		m.playerCollection:       playerIndexes,
+		m.erasureAuditCollection: erasureAuditIndexes,
+		m.archiveCollection:      archiveIndexes,
+		m.outboxCollection:       outboxIndexes,
+
+		m.webhookDeliveryCollection: webhookDeliveryIndexes,
+
+		m.playerStatsCollection:       playerStatsIndexes,
+		m.playerAchievementCollection: playerAchievementIndexes,
+		m.playerPairCollection:        playerPairIndexes,
+		m.playerSeasonStatsCollection: playerSeasonStatsIndexes,
+		m.playerSeasonPairCollection:  playerSeasonPairIndexes,
+		m.seasonSnapshotCollection:    seasonSnapshotIndexes,
+		m.leaderboardScoreCollection:  leaderboardScoreIndexes,
+		m.playerRatingCollection:      playerRatingIndexes,
 	}
 
 	wg := sync.WaitGroup{}
@@ -111,11 +271,19 @@ func (m *mongoRepository) GetLiveGame(ctx context.Context, id primitive.ObjectID
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	var game model.LiveGame
-	if err := m.liveGameCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&game); err != nil {
+	raw, err := m.liveGameCollection.FindOne(ctx, bson.M{"_id": id}).Raw()
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
 		return nil, fmt.Errorf("failed to get live game: %w", err)
 	}
 
+	var game model.LiveGame
+	if err := decodeGame(raw, &game); err != nil {
+		return nil, fmt.Errorf("failed to decode live game: %w", err)
+	}
+
 	if err := game.ParseGameData(); err != nil {
 		return nil, fmt.Errorf("failed to parse game data: %w", err)
 	}
@@ -123,13 +291,19 @@ func (m *mongoRepository) GetLiveGame(ctx context.Context, id primitive.ObjectID
 	return &game, nil
 }
 
-var ErrIdNotSet = fmt.Errorf("id not set")
+var (
+	ErrIdNotSet      = fmt.Errorf("id not set")
+	ErrNotFound      = fmt.Errorf("not found")
+	ErrDuplicateGame = fmt.Errorf("game already exists")
+)
 
 func (m *mongoRepository) SaveLiveGame(ctx context.Context, game *model.LiveGame) error {
 	if game.Id.IsZero() {
 		return ErrIdNotSet
 	}
 
+	game.SchemaVersion = model.CurrentSchemaVersion
+
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -141,6 +315,55 @@ func (m *mongoRepository) SaveLiveGame(ctx context.Context, game *model.LiveGame
 	return nil
 }
 
+func (m *mongoRepository) ListLiveGames(ctx context.Context, filter LiveGameFilter) ([]*model.LiveGame, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "startTime", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := m.liveGameCollection.Find(ctx, filter.toBson(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find live games: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	games := make([]*model.LiveGame, 0)
+	for cursor.Next(ctx) {
+		var game model.LiveGame
+		if err := decodeGame(cursor.Current, &game); err != nil {
+			return nil, fmt.Errorf("failed to decode live game: %w", err)
+		}
+
+		if err := game.ParseGameData(); err != nil {
+			return nil, fmt.Errorf("failed to parse game data: %w", err)
+		}
+
+		games = append(games, &game)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate live games: %w", err)
+	}
+
+	return games, nil
+}
+
+func (f LiveGameFilter) toBson() bson.M {
+	filter := bson.M{}
+
+	if f.GameModeId != "" {
+		filter["gameModeId"] = f.GameModeId
+	}
+	if f.ServerId != "" {
+		filter["serverId"] = f.ServerId
+	}
+	if f.PlayerId != uuid.Nil {
+		filter["players.id"] = f.PlayerId
+	}
+
+	return filter
+}
+
 func (m *mongoRepository) DeleteLiveGame(ctx context.Context, id primitive.ObjectID) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -150,6 +373,8 @@ func (m *mongoRepository) DeleteLiveGame(ctx context.Context, id primitive.Objec
 }
 
 func (m *mongoRepository) SaveHistoricGame(ctx context.Context, game *model.HistoricGame) error {
+	game.SchemaVersion = model.CurrentSchemaVersion
+
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -161,14 +386,97 @@ func (m *mongoRepository) GetHistoricGame(ctx context.Context, id primitive.Obje
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	var game model.HistoricGame
-	if err := m.historicGameCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&game); err != nil {
+	raw, err := m.historicGameCollection.FindOne(ctx, bson.M{"_id": id}).Raw()
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
 		return nil, fmt.Errorf("failed to get historic game: %w", err)
 	}
 
+	var game model.HistoricGame
+	if err := decodeGame(raw, &game); err != nil {
+		return nil, fmt.Errorf("failed to decode historic game: %w", err)
+	}
+
 	if err := game.ParseGameData(); err != nil {
 		return nil, fmt.Errorf("failed to parse game data: %w", err)
 	}
 
 	return &game, nil
 }
+
+func (m *mongoRepository) ListHistoricGames(ctx context.Context, filter HistoricGameFilter, page int64, pageSize int64) ([]*model.HistoricGame, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "endTime", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(page * pageSize).
+		SetLimit(pageSize)
+
+	return m.findHistoricGames(ctx, filter.toBson(), opts)
+}
+
+// findHistoricGames finds, decodes and parses the historic games matching the filter
+func (m *mongoRepository) findHistoricGames(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*model.HistoricGame, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := m.historicGameCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find historic games: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	games := make([]*model.HistoricGame, 0)
+	for cursor.Next(ctx) {
+		var game model.HistoricGame
+		if err := decodeGame(cursor.Current, &game); err != nil {
+			return nil, fmt.Errorf("failed to decode historic game: %w", err)
+		}
+
+		if err := game.ParseGameData(); err != nil {
+			return nil, fmt.Errorf("failed to parse game data: %w", err)
+		}
+
+		games = append(games, &game)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate historic games: %w", err)
+	}
+
+	return games, nil
+}
+
+func (f HistoricGameFilter) toBson() bson.M {
+	filter := bson.M{}
+
+	if f.GameModeId != "" {
+		filter["gameModeId"] = f.GameModeId
+	}
+	if f.MapId != "" {
+		filter["mapId"] = f.MapId
+	}
+	if f.PlayerId != uuid.Nil {
+		// Games saved before participation was tracked only have the players present at the finish
+		filter["$or"] = bson.A{
+			bson.M{"players.id": f.PlayerId},
+			bson.M{"participation.playerId": f.PlayerId},
+		}
+	}
+	if f.LeftEarlyPlayerId != uuid.Nil {
+		filter["participation"] = bson.M{"$elemMatch": bson.M{"playerId": f.LeftEarlyPlayerId, "leftEarly": true}}
+	}
+
+	endTime := bson.M{}
+	if f.From != nil {
+		endTime["$gte"] = *f.From
+	}
+	if f.To != nil {
+		endTime["$lt"] = *f.To
+	}
+	if len(endTime) > 0 {
+		filter["endTime"] = endTime
+	}
+
+	return filter
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+var playerAchievementIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "playerId", Value: 1}, {Key: "achievementId", Value: 1}},
+		Options: options.Index().SetName("playerId_achievementId").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "achievementId", Value: 1}, {Key: "unlockedAt", Value: 1}},
+		Options: options.Index().SetName("achievementId_unlockedAt"),
+	},
+}
+
+func (m *mongoRepository) UnlockAchievements(ctx context.Context, achievements []*model.PlayerAchievement) ([]*model.PlayerAchievement, error) {
+	if len(achievements) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Achievements the players already have are left out before inserting rather than by their duplicate key error,
+	// as any write error aborts the transaction the achievements are usually unlocked in
+	pairs := make(bson.A, len(achievements))
+	for i, a := range achievements {
+		pairs[i] = bson.M{"playerId": a.PlayerId, "achievementId": a.AchievementId}
+	}
+
+	cursor, err := m.playerAchievementCollection.Find(ctx, bson.M{"$or": pairs},
+		options.Find().SetProjection(bson.M{"playerId": 1, "achievementId": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find unlocked achievements: %w", err)
+	}
+
+	var existing []*model.PlayerAchievement
+	if err := cursor.All(ctx, &existing); err != nil {
+		return nil, fmt.Errorf("failed to decode unlocked achievements: %w", err)
+	}
+
+	type key struct {
+		playerId      uuid.UUID
+		achievementId string
+	}
+	has := make(map[key]bool, len(existing))
+	for _, a := range existing {
+		has[key{a.PlayerId, a.AchievementId}] = true
+	}
+
+	var unlocked []*model.PlayerAchievement
+	var docs []interface{}
+	for _, a := range achievements {
+		k := key{a.PlayerId, a.AchievementId}
+		if has[k] {
+			continue
+		}
+		has[k] = true
+
+		unlocked = append(unlocked, a)
+		docs = append(docs, a)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	if _, err := m.playerAchievementCollection.InsertMany(ctx, docs); err != nil {
+		return nil, fmt.Errorf("failed to insert achievements: %w", err)
+	}
+
+	return unlocked, nil
+}
+
+func (m *mongoRepository) GetPlayerAchievements(ctx context.Context, playerId uuid.UUID) ([]*model.PlayerAchievement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := m.playerAchievementCollection.Find(ctx, bson.M{"playerId": playerId},
+		options.Find().SetSort(bson.D{{Key: "unlockedAt", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player achievements: %w", err)
+	}
+
+	var achievements []*model.PlayerAchievement
+	if err := cursor.All(ctx, &achievements); err != nil {
+		return nil, fmt.Errorf("failed to decode player achievements: %w", err)
+	}
+
+	return achievements, nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"game-tracker/internal/repository/registrytypes"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const deleteBatchSize = 1000
+
+var archiveIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "gameModeId", Value: 1}, {Key: "from", Value: 1}},
+		Options: options.Index().SetName("gameModeId_from"),
+	},
+	{
+		Keys:    bson.D{{Key: "playerIds", Value: 1}},
+		Options: options.Index().SetName("playerIds"),
+	},
+}
+
+// DecodeHistoricGame decodes a raw historic game document as stored in the database or an archive
+func DecodeHistoricGame(raw bson.Raw) (*model.HistoricGame, error) {
+	var game model.HistoricGame
+	if err := decodeGame(raw, &game); err != nil {
+		return nil, fmt.Errorf("failed to decode historic game: %w", err)
+	}
+
+	if err := game.ParseGameData(); err != nil {
+		return nil, fmt.Errorf("failed to parse game data: %w", err)
+	}
+
+	return &game, nil
+}
+
+// EncodeHistoricGame encodes a historic game as it would be stored in the database
+func EncodeHistoricGame(game *model.HistoricGame) (bson.Raw, error) {
+	game.SchemaVersion = model.CurrentSchemaVersion
+
+	buf := new(bytes.Buffer)
+	vw, err := bsonrw.NewBSONValueWriter(buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create value writer: %w", err)
+	}
+
+	enc, err := bson.NewEncoder(vw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encoder: %w", err)
+	}
+
+	if err := enc.SetRegistry(registrytypes.CodecRegistry); err != nil {
+		return nil, fmt.Errorf("failed to set registry: %w", err)
+	}
+
+	if err := enc.Encode(game); err != nil {
+		return nil, fmt.Errorf("failed to encode historic game: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *mongoRepository) StreamHistoricGames(ctx context.Context, filter HistoricGameFilter, fn func(raw bson.Raw) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "endTime", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := m.historicGameCollection.Find(ctx, filter.toBson(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to find historic games: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		if err := fn(cursor.Current); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to iterate historic games: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) AggregateHistoricGames(ctx context.Context, filter HistoricGameFilter) (*model.ArchivedAggregates, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	mapStats, err := m.aggregateMapStats(ctx, filter.toBson())
+	if err != nil {
+		return nil, err
+	}
+
+	tdWinRates, err := m.aggregateTowerDefenceMapWinRates(ctx, filter.toBson())
+	if err != nil {
+		return nil, err
+	}
+
+	colorWinRates, err := m.aggregateTeamColorWinRates(ctx, filter.toBson())
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ArchivedAggregates{
+		MapStats:                mapStats,
+		TowerDefenceMapWinRates: tdWinRates,
+		TeamColorWinRates:       colorWinRates,
+	}, nil
+}
+
+func (m *mongoRepository) DeleteHistoricGames(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	var deleted int64
+	for start := 0; start < len(ids); start += deleteBatchSize {
+		end := min(start+deleteBatchSize, len(ids))
+
+		batchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		result, err := m.historicGameCollection.DeleteMany(batchCtx, bson.M{"_id": bson.M{"$in": ids[start:end]}})
+		cancel()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete historic games: %w", err)
+		}
+
+		deleted += result.DeletedCount
+	}
+
+	return deleted, nil
+}
+
+func (m *mongoRepository) RestoreHistoricGames(ctx context.Context, games []bson.Raw) (int64, error) {
+	if len(games) == 0 {
+		return 0, nil
+	}
+
+	writes := make([]mongo.WriteModel, len(games))
+	for i, raw := range games {
+		writes[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": raw.Lookup("_id")}).
+			SetReplacement(raw).
+			SetUpsert(true)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	result, err := m.historicGameCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore historic games: %w", err)
+	}
+
+	return result.UpsertedCount + result.ModifiedCount, nil
+}
+
+func (m *mongoRepository) SaveArchive(ctx context.Context, archive *model.Archive) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := m.archiveCollection.ReplaceOne(ctx, bson.M{"_id": archive.Id}, archive, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save archive: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) GetArchives(ctx context.Context, gameModeId string, from *time.Time, to *time.Time) ([]*model.Archive, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return m.getArchives(ctx, gameModeId, from, to)
+}
+
+// getArchives returns the archives of the game mode containing games that ended in the range, oldest first
+func (m *mongoRepository) getArchives(ctx context.Context, gameModeId string, from *time.Time, to *time.Time) ([]*model.Archive, error) {
+	filter := bson.M{}
+	if gameModeId != "" {
+		filter["gameModeId"] = gameModeId
+	}
+	if from != nil {
+		filter["to"] = bson.M{"$gte": *from}
+	}
+	if to != nil {
+		filter["from"] = bson.M{"$lt": *to}
+	}
+
+	cursor, err := m.archiveCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "from", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find archives: %w", err)
+	}
+
+	var archives []*model.Archive
+	if err := cursor.All(ctx, &archives); err != nil {
+		return nil, fmt.Errorf("failed to decode archives: %w", err)
+	}
+
+	return archives, nil
+}
+
+func (m *mongoRepository) DeleteArchive(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := m.archiveCollection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("failed to delete archive: %w", err)
+	}
+
+	return nil
+}
+
+// pseudonymiseArchives replaces the player in the manifest of every archive referencing them, returning those archives
+func (m *mongoRepository) pseudonymiseArchives(ctx context.Context, playerId uuid.UUID, pseudonym uuid.UUID) ([]*model.Archive, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := m.archiveCollection.Find(ctx, bson.M{"playerIds": playerId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find archives: %w", err)
+	}
+
+	var archives []*model.Archive
+	if err := cursor.All(ctx, &archives); err != nil {
+		return nil, fmt.Errorf("failed to decode archives: %w", err)
+	}
+
+	_, err = m.archiveCollection.UpdateMany(ctx,
+		bson.M{"playerIds": playerId},
+		bson.M{"$set": bson.M{"playerIds.$[id]": pseudonym}},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: bson.A{bson.M{"id": playerId}}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update archives: %w", err)
+	}
+
+	return archives, nil
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+var erasureAuditIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "playerIdHash", Value: 1}},
+		Options: options.Index().SetName("playerIdHash"),
+	},
+}
+
+// HashPlayerId returns the hex encoded HMAC-SHA256 of the player's id keyed by the erasure secret.
+// Player ids are public, so an unkeyed hash could be reversed by hashing every known id.
+func HashPlayerId(secret []byte, id uuid.UUID) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(id[:])
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (m *mongoRepository) ErasePlayer(ctx context.Context, playerId uuid.UUID, playerIdHash string,
+	requestedBy string) (*model.ErasureAudit, error) {
+
+	// A repeat erasure reuses the pseudonym so the player's games stay linked to each other
+	pseudonym := uuid.New()
+	previous, err := m.GetErasedPlayers(ctx, []string{playerIdHash})
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := previous[playerIdHash]; ok {
+		pseudonym = p
+	}
+
+	audit := &model.ErasureAudit{
+		Id:           primitive.NewObjectID(),
+		PlayerIdHash: playerIdHash,
+		Pseudonym:    pseudonym,
+		RequestedBy:  requestedBy,
+		StartTime:    time.Now(),
+	}
+
+	audit.LiveGameIds, err = m.erasePlayerFromGames(ctx, m.liveGameCollection, playerId, audit.Pseudonym,
+		func() model.IGame { return &model.LiveGame{} })
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase player from live games: %w", err)
+	}
+
+	audit.HistoricGameIds, err = m.erasePlayerFromGames(ctx, m.historicGameCollection, playerId, audit.Pseudonym,
+		func() model.IGame { return &model.HistoricGame{} })
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase player from historic games: %w", err)
+	}
+
+	archives, err := m.pseudonymiseArchives(ctx, playerId, audit.Pseudonym)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase player from archives: %w", err)
+	}
+	for _, a := range archives {
+		audit.ArchivePaths = append(audit.ArchivePaths, a.Path)
+	}
+
+	if audit.DeletedPlayerRecords, err = m.deletePlayerRecords(ctx, playerId); err != nil {
+		return nil, fmt.Errorf("failed to delete derived player data: %w", err)
+	}
+
+	deletedDeliveries, err := m.eraseWebhookPlayer(ctx, playerId, audit.Pseudonym)
+	if err != nil {
+		return nil, err
+	}
+	audit.DeletedPlayerRecords += deletedDeliveries
+
+	deletedPairs, err := m.erasePlayerPairs(ctx, playerId, audit.Pseudonym)
+	if err != nil {
+		return nil, err
+	}
+	audit.DeletedPlayerRecords += deletedPairs
+
+	deletedRecompute, err := m.eraseRecomputePlayer(ctx, playerId, audit.Pseudonym)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase player from recompute collections: %w", err)
+	}
+	audit.DeletedPlayerRecords += deletedRecompute
+
+	if audit.SeasonIds, err = m.eraseSeasonSnapshots(ctx, playerId, audit.Pseudonym); err != nil {
+		return nil, fmt.Errorf("failed to erase player from season snapshots: %w", err)
+	}
+
+	gameIds := append(append([]primitive.ObjectID{}, audit.LiveGameIds...), audit.HistoricGameIds...)
+	if audit.OutboxEvents, err = m.eraseOutboxPlayer(ctx, playerId, audit.Pseudonym, gameIds); err != nil {
+		return nil, err
+	}
+
+	audit.EndTime = time.Now()
+
+	insertCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := m.erasureAuditCollection.InsertOne(insertCtx, audit); err != nil {
+		return nil, fmt.Errorf("failed to save erasure audit: %w", err)
+	}
+
+	return audit, nil
+}
+
+func (m *mongoRepository) GetErasedPlayers(ctx context.Context, playerIdHashes []string) (map[string]uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := m.erasureAuditCollection.Find(ctx, bson.M{"playerIdHash": bson.M{"$in": playerIdHashes}},
+		options.Find().SetProjection(bson.M{"playerIdHash": 1, "pseudonym": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find erasure audits: %w", err)
+	}
+
+	var audits []*model.ErasureAudit
+	if err := cursor.All(ctx, &audits); err != nil {
+		return nil, fmt.Errorf("failed to decode erasure audits: %w", err)
+	}
+
+	pseudonyms := make(map[string]uuid.UUID, len(audits))
+	for _, a := range audits {
+		pseudonyms[a.PlayerIdHash] = a.Pseudonym
+	}
+
+	return pseudonyms, nil
+}
+
+func (m *mongoRepository) HasErasures(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	count, err := m.erasureAuditCollection.CountDocuments(ctx, bson.M{}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to count erasure audits: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// playerReferenceFilter matches every game document that references the player
+func playerReferenceFilter(playerId uuid.UUID) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"players.id": playerId},
+		bson.M{"playerSessions.playerId": playerId},
+		bson.M{"participation.playerId": playerId},
+		bson.M{"teams.playerIds": playerId},
+		bson.M{"winnerData.winnerIds": playerId},
+		bson.M{"winnerData.loserIds": playerId},
+		bson.M{"gameData.scoreboard.entries." + playerId.String(): bson.M{"$exists": true}},
+		bson.M{"gameData.initialLives." + playerId.String(): bson.M{"$exists": true}},
+	}}
+}
+
+func (m *mongoRepository) erasePlayerFromGames(ctx context.Context, coll *mongo.Collection, playerId uuid.UUID,
+	pseudonym uuid.UUID, newGame func() model.IGame) ([]primitive.ObjectID, error) {
+
+	cursor, err := coll.Find(ctx, playerReferenceFilter(playerId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find games: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	ids := make([]primitive.ObjectID, 0)
+	for cursor.Next(ctx) {
+		game := newGame()
+		if err := decodeGame(cursor.Current, game); err != nil {
+			return ids, fmt.Errorf("failed to decode game: %w", err)
+		}
+
+		g := game.GetGame()
+		if err := g.ParseGameData(); err != nil {
+			return ids, fmt.Errorf("failed to parse game data of %s: %w", g.Id.Hex(), err)
+		}
+
+		var changed bool
+		if historic, ok := game.(*model.HistoricGame); ok {
+			changed = historic.ReplacePlayer(playerId, pseudonym)
+		} else {
+			changed = g.ReplacePlayer(playerId, pseudonym)
+		}
+		if !changed {
+			continue
+		}
+		g.SchemaVersion = model.CurrentSchemaVersion
+
+		replaceCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err := coll.ReplaceOne(replaceCtx, bson.M{"_id": g.Id}, game)
+		cancel()
+		if err != nil {
+			return ids, fmt.Errorf("failed to replace game %s: %w", g.Id.Hex(), err)
+		}
+
+		ids = append(ids, g.Id)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return ids, fmt.Errorf("failed to iterate games: %w", err)
+	}
+
+	return ids, nil
+}
+
+// deletePlayerRecords deletes every document derived from the player's games, returning the number deleted
+func (m *mongoRepository) deletePlayerRecords(ctx context.Context, playerId uuid.UUID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := m.playerCollection.DeleteOne(ctx, bson.M{"_id": playerId})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete player: %w", err)
+	}
+	deleted := result.DeletedCount
+
+	result, err = m.playerAchievementCollection.DeleteMany(ctx, bson.M{"playerId": playerId})
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete from %s: %w", m.playerAchievementCollection.Name(), err)
+	}
+	deleted += result.DeletedCount
+
+	derived, err := m.deleteDerivedRecords(ctx, playerId)
+	return deleted + derived, err
+}
+
+// deleteDerivedRecords deletes the player's documents in the collections recomputes rebuild, except their pairs
+func (m *mongoRepository) deleteDerivedRecords(ctx context.Context, playerId uuid.UUID) (int64, error) {
+	var deleted int64
+	for _, coll := range []*mongo.Collection{m.playerStatsCollection, m.playerSeasonStatsCollection,
+		m.leaderboardScoreCollection, m.playerRatingCollection} {
+		result, err := coll.DeleteMany(ctx, bson.M{"playerId": playerId})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete from %s: %w", coll.Name(), err)
+		}
+		deleted += result.DeletedCount
+	}
+
+	return deleted, nil
+}
+
+// eraseRecomputePlayer erases the player from the collections a recompute in progress is rebuilding,
+// which would otherwise swap their records back in. The collections are empty if no recompute is in progress.
+func (m *mongoRepository) eraseRecomputePlayer(ctx context.Context, playerId uuid.UUID, pseudonym uuid.UUID) (int64, error) {
+	target := m.recomputeTarget()
+
+	deleteCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	deleted, err := target.deleteDerivedRecords(deleteCtx, playerId)
+	if err != nil {
+		return deleted, err
+	}
+
+	pairs, err := target.erasePlayerPairs(ctx, playerId, pseudonym)
+	return deleted + pairs, err
+}
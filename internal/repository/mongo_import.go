@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func (m *mongoRepository) ImportHistoricGames(ctx context.Context, games []*model.HistoricGame, mode DuplicateMode) (*ImportResult, error) {
+	if len(games) == 0 {
+		return &ImportResult{}, nil
+	}
+
+	writes := make([]mongo.WriteModel, len(games))
+	for i, game := range games {
+		game.SchemaVersion = model.CurrentSchemaVersion
+
+		if mode == DuplicateUpsert {
+			writes[i] = mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": game.Id}).SetReplacement(game).SetUpsert(true)
+		} else {
+			writes[i] = mongo.NewInsertOneModel().SetDocument(game)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	// Ordered writes stop at the first duplicate so nothing after it is imported in fail mode
+	opts := options.BulkWrite().SetOrdered(mode == DuplicateFail)
+
+	result, err := m.historicGameCollection.BulkWrite(ctx, writes, opts)
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+			return nil, fmt.Errorf("failed to import historic games: %w", err)
+		}
+
+		skipped := int64(0)
+		for _, writeErr := range bulkErr.WriteErrors {
+			if !mongo.IsDuplicateKeyError(writeErr) {
+				return nil, fmt.Errorf("failed to import historic game %s: %w", games[writeErr.Index].Id.Hex(), writeErr)
+			}
+
+			if mode == DuplicateFail {
+				return &ImportResult{Inserted: result.InsertedCount},
+					fmt.Errorf("%w: %s", ErrDuplicateGame, games[writeErr.Index].Id.Hex())
+			}
+			skipped++
+		}
+
+		return &ImportResult{Inserted: result.InsertedCount, Skipped: skipped}, nil
+	}
+
+	return &ImportResult{
+		Inserted: result.InsertedCount + result.UpsertedCount,
+		Updated:  result.ModifiedCount,
+	}, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// leaderboardSort is the order players are ranked in, matched by the ranking index
+var leaderboardSort = bson.D{{Key: "value", Value: -1}, {Key: "reachedAt", Value: 1}, {Key: "playerId", Value: 1}}
+
+var leaderboardScoreIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{{Key: "window", Value: 1}, {Key: "period", Value: 1}, {Key: "gameModeId", Value: 1},
+			{Key: "metric", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("window_period_gameModeId_metric_playerId").SetUnique(true),
+	},
+	{
+		Keys: bson.D{{Key: "window", Value: 1}, {Key: "period", Value: 1}, {Key: "gameModeId", Value: 1},
+			{Key: "metric", Value: 1}, {Key: "value", Value: -1}, {Key: "reachedAt", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("window_period_gameModeId_metric_rank"),
+	},
+	{
+		Keys:    bson.D{{Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("playerId"),
+	},
+	{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetName("expiresAt").SetExpireAfterSeconds(0),
+	},
+}
+
+func (m *mongoRepository) RecordLeaderboardScores(ctx context.Context, game *model.HistoricGame, seasonId string) error {
+	results := game.PlayerResults()
+	if len(results) == 0 {
+		return nil
+	}
+
+	var writes []mongo.WriteModel
+	for _, window := range model.LeaderboardWindows {
+		period := window.Period(game.EndTime)
+		if window == model.LeaderboardSeason {
+			if seasonId == "" {
+				continue
+			}
+			period = seasonId
+		}
+		expiresAt := window.ExpiresAt(game.EndTime)
+
+		for _, r := range results {
+			for metric, inc := range game.LeaderboardIncrements(r) {
+				key := model.LeaderboardKey{Window: window, Period: period, GameModeId: game.GameModeId, Metric: metric}
+
+				set := bson.M{"reachedAt": game.EndTime}
+				if expiresAt != nil {
+					set["expiresAt"] = expiresAt
+				}
+
+				writes = append(writes, mongo.NewUpdateOneModel().
+					SetFilter(leaderboardFilter(key, bson.M{"playerId": r.PlayerId})).
+					SetUpdate(bson.M{"$inc": bson.M{"value": inc}, "$set": set}).
+					SetUpsert(true))
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := m.leaderboardScoreCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to record leaderboard scores: %w", err)
+	}
+
+	return nil
+}
+
+// leaderboardFilter adds the leaderboard's key to a filter
+func leaderboardFilter(key model.LeaderboardKey, filter bson.M) bson.M {
+	filter["window"] = key.Window
+	filter["period"] = key.Period
+	filter["gameModeId"] = key.GameModeId
+	filter["metric"] = key.Metric
+	return filter
+}
+
+func (m *mongoRepository) GetLeaderboard(ctx context.Context, key model.LeaderboardKey, offset int64,
+	limit int64) ([]*model.LeaderboardScore, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(leaderboardSort).SetSkip(offset).SetLimit(limit)
+	cursor, err := m.leaderboardScoreCollection.Find(ctx, leaderboardFilter(key, bson.M{}), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find leaderboard scores: %w", err)
+	}
+
+	var scores []*model.LeaderboardScore
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, fmt.Errorf("failed to decode leaderboard scores: %w", err)
+	}
+
+	return scores, nil
+}
+
+func (m *mongoRepository) CountLeaderboard(ctx context.Context, key model.LeaderboardKey) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	count, err := m.leaderboardScoreCollection.CountDocuments(ctx, leaderboardFilter(key, bson.M{}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count leaderboard scores: %w", err)
+	}
+
+	return count, nil
+}
+
+func (m *mongoRepository) GetLeaderboardRank(ctx context.Context, key model.LeaderboardKey,
+	playerId uuid.UUID) (*model.LeaderboardScore, int64, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var score model.LeaderboardScore
+	err := m.leaderboardScoreCollection.FindOne(ctx, leaderboardFilter(key, bson.M{"playerId": playerId})).Decode(&score)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, 0, ErrNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to get leaderboard score: %w", err)
+	}
+
+	// Counts the players ranked above using the ranking index, following leaderboardSort
+	ahead := leaderboardFilter(key, bson.M{"$or": bson.A{
+		bson.M{"value": bson.M{"$gt": score.Value}},
+		bson.M{"value": score.Value, "reachedAt": bson.M{"$lt": score.ReachedAt}},
+		bson.M{"value": score.Value, "reachedAt": score.ReachedAt, "playerId": bson.M{"$lt": score.PlayerId}},
+	}})
+	count, err := m.leaderboardScoreCollection.CountDocuments(ctx, ahead)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count leaderboard scores ahead: %w", err)
+	}
+
+	return &score, count + 1, nil
+}
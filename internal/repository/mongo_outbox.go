@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/events"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// sentOutboxEventRetention is how long published events are kept for inspection before Mongo deletes them
+const sentOutboxEventRetention = 7 * 24 * time.Hour
+
+var outboxIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "sentAt", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("sentAt_id"),
+	},
+	{
+		Keys:    bson.D{{Key: "playerIds", Value: 1}},
+		Options: options.Index().SetName("playerIds"),
+	},
+	{
+		// Documents without a sentAt are never expired
+		Keys:    bson.D{{Key: "sentAt", Value: 1}},
+		Options: options.Index().SetName("sentAt_ttl").SetExpireAfterSeconds(int32(sentOutboxEventRetention.Seconds())),
+	},
+}
+
+func (m *mongoRepository) FinishGame(ctx context.Context, game *model.HistoricGame, events []*model.OutboxEvent,
+	deliveries []*model.WebhookDelivery) error {
+
+	game.SchemaVersion = model.CurrentSchemaVersion
+
+	deliveryDocs := make([]interface{}, len(deliveries))
+	for i, d := range deliveries {
+		deliveryDocs[i] = d
+	}
+
+	// Without transactions the live game is only deleted once the historic game is saved, so it is never lost
+	return m.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := m.SaveHistoricGame(ctx, game); err != nil {
+			return fmt.Errorf("failed to insert historic game: %w", err)
+		}
+
+		if err := m.DeleteLiveGame(ctx, game.Id); err != nil {
+			return fmt.Errorf("failed to delete live game: %w", err)
+		}
+
+		if err := m.SaveOutboxEvents(ctx, events); err != nil {
+			return err
+		}
+
+		if len(deliveryDocs) > 0 {
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			if _, err := m.webhookDeliveryCollection.InsertMany(ctx, deliveryDocs); err != nil {
+				return fmt.Errorf("failed to insert webhook deliveries: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *mongoRepository) SaveOutboxEvents(ctx context.Context, events []*model.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(events))
+	for i, e := range events {
+		docs[i] = e
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := m.outboxCollection.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("failed to insert outbox events: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) GetUnsentOutboxEvents(ctx context.Context, limit int64) ([]*model.OutboxEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+
+	cursor, err := m.outboxCollection.Find(ctx, bson.M{"sentAt": nil}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find unsent outbox events: %w", err)
+	}
+
+	var events []*model.OutboxEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (m *mongoRepository) MarkOutboxEventsSent(ctx context.Context, ids []primitive.ObjectID, sentAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := m.outboxCollection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}},
+		bson.M{"$set": bson.M{"sentAt": sentAt}, "$inc": bson.M{"attempts": 1}, "$unset": bson.M{"lastError": ""}})
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox events sent: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) RecordOutboxFailure(ctx context.Context, ids []primitive.ObjectID, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := m.outboxCollection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}},
+		bson.M{"$set": bson.M{"lastError": reason}, "$inc": bson.M{"attempts": 1}})
+	if err != nil {
+		return fmt.Errorf("failed to record outbox failure: %w", err)
+	}
+
+	return nil
+}
+
+// eraseOutboxPlayer deletes the events about the player and pseudonymises the player in the other events
+// referencing them, returning the number of events changed. Events queued before the players they reference
+// were recorded are found by the games they belong to.
+func (m *mongoRepository) eraseOutboxPlayer(ctx context.Context, playerId uuid.UUID, pseudonym uuid.UUID,
+	gameIds []primitive.ObjectID) (int64, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	keys := make([]string, len(gameIds))
+	for i, id := range gameIds {
+		keys[i] = id.Hex()
+	}
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"playerIds": playerId},
+		bson.M{"key": bson.M{"$in": keys}},
+	}}
+	cursor, err := m.outboxCollection.Find(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find outbox events: %w", err)
+	}
+
+	var outboxEvents []*model.OutboxEvent
+	if err := cursor.All(ctx, &outboxEvents); err != nil {
+		return 0, fmt.Errorf("failed to decode outbox events: %w", err)
+	}
+
+	var changed int64
+	for _, e := range outboxEvents {
+		payload, err := events.ErasePlayer(e.ProtoType, e.Payload, playerId, pseudonym)
+		if err != nil {
+			return changed, fmt.Errorf("failed to erase player from outbox event %s: %w", e.Id.Hex(), err)
+		}
+
+		if payload == nil {
+			_, err = m.outboxCollection.DeleteOne(ctx, bson.M{"_id": e.Id})
+		} else {
+			playerIds := make([]uuid.UUID, len(e.PlayerIds))
+			for i, id := range e.PlayerIds {
+				if id == playerId {
+					id = pseudonym
+				}
+				playerIds[i] = id
+			}
+
+			_, err = m.outboxCollection.UpdateByID(ctx, e.Id,
+				bson.M{"$set": bson.M{"payload": payload, "playerIds": playerIds}})
+		}
+		if err != nil {
+			return changed, fmt.Errorf("failed to erase player from outbox event %s: %w", e.Id.Hex(), err)
+		}
+		changed++
+	}
+
+	return changed, nil
+}
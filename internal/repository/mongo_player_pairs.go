@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+var playerPairIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "playerId", Value: 1}, {Key: "otherId", Value: 1}, {Key: "gameModeId", Value: 1}},
+		Options: options.Index().SetName("playerId_otherId_gameModeId").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "otherId", Value: 1}},
+		Options: options.Index().SetName("otherId"),
+	},
+}
+
+var playerSeasonPairIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "seasonId", Value: 1}, {Key: "playerId", Value: 1}, {Key: "otherId", Value: 1}, {Key: "gameModeId", Value: 1}},
+		Options: options.Index().SetName("seasonId_playerId_otherId_gameModeId").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("playerId"),
+	},
+	{
+		Keys:    bson.D{{Key: "otherId", Value: 1}},
+		Options: options.Index().SetName("otherId"),
+	},
+}
+
+// playerPairColl returns the collection of the season's pairs, or the all-time pairs if the season id is empty
+func (m *mongoRepository) playerPairColl(seasonId string) *mongo.Collection {
+	if seasonId == "" {
+		return m.playerPairCollection
+	}
+	return m.playerSeasonPairCollection
+}
+
+func (m *mongoRepository) RecordPlayerPairs(ctx context.Context, game *model.HistoricGame, seasonId string) error {
+	pairs := game.PlayerPairResults()
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if seasonId != "" {
+		if err := m.writePlayerPairs(ctx, game, pairs, seasonId); err != nil {
+			return fmt.Errorf("failed to record season player pairs: %w", err)
+		}
+	}
+
+	if err := m.writePlayerPairs(ctx, game, pairs, ""); err != nil {
+		return fmt.Errorf("failed to record player pairs: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) writePlayerPairs(ctx context.Context, game *model.HistoricGame, pairs []*model.PlayerPairResult,
+	seasonId string) error {
+
+	writes := make([]mongo.WriteModel, len(pairs))
+	for i, p := range pairs {
+		inc := bson.M{}
+		if p.Teammates {
+			inc["gamesWith"] = 1
+			if p.Won {
+				inc["winsWith"] = 1
+			}
+			if p.Lost {
+				inc["lossesWith"] = 1
+			}
+		} else {
+			inc["gamesAgainst"] = 1
+			if p.Won {
+				inc["winsAgainst"] = 1
+			}
+			if p.Lost {
+				inc["lossesAgainst"] = 1
+			}
+		}
+
+		filter := bson.M{"playerId": p.PlayerId, "otherId": p.OtherId, "gameModeId": game.GameModeId}
+		writes[i] = mongo.NewUpdateOneModel().
+			SetFilter(seasonFilter(filter, seasonId)).
+			SetUpdate(bson.M{"$inc": inc, "$max": bson.M{"lastPlayed": game.EndTime}}).
+			SetUpsert(true)
+	}
+
+	_, err := m.playerPairColl(seasonId).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (m *mongoRepository) GetHeadToHead(ctx context.Context, playerId uuid.UUID, otherId uuid.UUID,
+	seasonId string) ([]*model.PlayerPairStats, error) {
+
+	return m.findPlayerPairs(ctx, seasonId, bson.M{"playerId": playerId, "otherId": otherId})
+}
+
+func (m *mongoRepository) GetPlayerPairs(ctx context.Context, playerId uuid.UUID, seasonId string) ([]*model.PlayerPairStats, error) {
+	return m.findPlayerPairs(ctx, seasonId, bson.M{"playerId": playerId})
+}
+
+func (m *mongoRepository) findPlayerPairs(ctx context.Context, seasonId string, filter bson.M) ([]*model.PlayerPairStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := m.playerPairColl(seasonId).Find(ctx, seasonFilter(filter, seasonId),
+		options.Find().SetSort(bson.D{{Key: "otherId", Value: 1}, {Key: "gameModeId", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player pairs: %w", err)
+	}
+
+	var stats []*model.PlayerPairStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode player pairs: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (m *mongoRepository) GetTopPlayerPairs(ctx context.Context, playerId uuid.UUID, seasonId string, gameModeId string,
+	kind model.PlayerPairKind, minGames int64, limit int64) ([]*model.PlayerPairStats, error) {
+
+	var gamesField string
+	var sort bson.D
+	switch kind {
+	case model.PlayerPairRivals:
+		gamesField = "gamesAgainst"
+		sort = bson.D{{Key: "gamesAgainst", Value: -1}, {Key: "winsAgainst", Value: -1}, {Key: "lastPlayed", Value: -1}}
+	case model.PlayerPairPartners:
+		// Partners are ranked by win rate, with minGames keeping out pairs with too few games for it to mean much.
+		// Equal win rates are ranked by the most games together.
+		gamesField = "gamesWith"
+		sort = bson.D{{Key: "winRateWith", Value: -1}, {Key: "gamesWith", Value: -1}, {Key: "lastPlayed", Value: -1}}
+	default:
+		return nil, fmt.Errorf("unknown player pair kind %q", kind)
+	}
+
+	match := seasonFilter(bson.M{"playerId": playerId}, seasonId)
+	if gameModeId != "" {
+		match["gameModeId"] = gameModeId
+	}
+
+	// Pairs are summed across game modes, which leaves them unchanged if a game mode is given
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$otherId",
+			"gamesAgainst":  bson.M{"$sum": "$gamesAgainst"},
+			"winsAgainst":   bson.M{"$sum": "$winsAgainst"},
+			"lossesAgainst": bson.M{"$sum": "$lossesAgainst"},
+			"gamesWith":     bson.M{"$sum": "$gamesWith"},
+			"winsWith":      bson.M{"$sum": "$winsWith"},
+			"lossesWith":    bson.M{"$sum": "$lossesWith"},
+			"lastPlayed":    bson.M{"$max": "$lastPlayed"},
+		}}},
+		{{Key: "$match", Value: bson.M{gamesField: bson.M{"$gte": max(minGames, 1)}}}},
+		{{Key: "$set", Value: bson.M{"winRateWith": bson.M{"$cond": bson.A{
+			bson.M{"$gt": bson.A{"$gamesWith", 0}},
+			bson.M{"$divide": bson.A{"$winsWith", "$gamesWith"}},
+			0,
+		}}}}},
+		{{Key: "$sort", Value: sort}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$set", Value: bson.M{"playerId": playerId, "otherId": "$_id", "gameModeId": gameModeId, "seasonId": seasonId}}},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := m.playerPairColl(seasonId).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate player pairs: %w", err)
+	}
+
+	var stats []*model.PlayerPairStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode player pairs: %w", err)
+	}
+
+	return stats, nil
+}
+
+// erasePlayerPairs deletes the player's own pairs and pseudonymises them in the pairs of everyone they played with,
+// so the other players' head-to-head records stay complete. It returns the number of pairs deleted.
+func (m *mongoRepository) erasePlayerPairs(ctx context.Context, playerId uuid.UUID, pseudonym uuid.UUID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var deleted int64
+	for _, coll := range []*mongo.Collection{m.playerPairCollection, m.playerSeasonPairCollection} {
+		result, err := coll.DeleteMany(ctx, bson.M{"playerId": playerId})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete player pairs: %w", err)
+		}
+		deleted += result.DeletedCount
+
+		if _, err := coll.UpdateMany(ctx, bson.M{"otherId": playerId}, bson.M{"$set": bson.M{"otherId": pseudonym}}); err != nil {
+			return deleted, fmt.Errorf("failed to pseudonymise player pairs: %w", err)
+		}
+	}
+
+	return deleted, nil
+}
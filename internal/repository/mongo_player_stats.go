@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// Stats are kept all-time and per season. All-time stats have no season id and are stored in their own collections,
+// so the per season collections are keyed by season first.
+
+var playerStatsIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "playerId", Value: 1}, {Key: "gameModeId", Value: 1}},
+		Options: options.Index().SetName("playerId_gameModeId").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "gameModeId", Value: 1}, {Key: "winStreak", Value: -1}, {Key: "lastPlayed", Value: -1}},
+		Options: options.Index().SetName("gameModeId_winStreak_lastPlayed"),
+	},
+}
+
+var playerSeasonStatsIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "seasonId", Value: 1}, {Key: "gameModeId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("seasonId_gameModeId_playerId").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "seasonId", Value: 1}, {Key: "gameModeId", Value: 1}, {Key: "winStreak", Value: -1}, {Key: "lastPlayed", Value: -1}},
+		Options: options.Index().SetName("seasonId_gameModeId_winStreak_lastPlayed"),
+	},
+	{
+		Keys:    bson.D{{Key: "playerId", Value: 1}, {Key: "seasonId", Value: 1}},
+		Options: options.Index().SetName("playerId_seasonId"),
+	},
+}
+
+// playerStatsColl returns the collection of the season's stats, or the all-time stats if the season id is empty
+func (m *mongoRepository) playerStatsColl(seasonId string) *mongo.Collection {
+	if seasonId == "" {
+		return m.playerStatsCollection
+	}
+	return m.playerSeasonStatsCollection
+}
+
+// seasonFilter adds the season to a filter of stats in the season's collection
+func seasonFilter(filter bson.M, seasonId string) bson.M {
+	if seasonId != "" {
+		filter["seasonId"] = seasonId
+	}
+	return filter
+}
+
+func (m *mongoRepository) RecordPlayerStats(ctx context.Context, game *model.HistoricGame, seasonId string) ([]*model.PlayerStats, error) {
+	results := game.PlayerResults()
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if seasonId != "" {
+		if err := m.writePlayerStats(ctx, game, results, seasonId); err != nil {
+			return nil, fmt.Errorf("failed to record season player stats: %w", err)
+		}
+	}
+
+	if err := m.writePlayerStats(ctx, game, results, ""); err != nil {
+		return nil, fmt.Errorf("failed to record player stats: %w", err)
+	}
+
+	playerIds := make([]uuid.UUID, len(results))
+	for i, r := range results {
+		playerIds[i] = r.PlayerId
+	}
+
+	cursor, err := m.playerStatsCollection.Find(ctx, bson.M{"playerId": bson.M{"$in": playerIds}, "gameModeId": game.GameModeId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player stats: %w", err)
+	}
+
+	var stats []*model.PlayerStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode player stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (m *mongoRepository) writePlayerStats(ctx context.Context, game *model.HistoricGame, results []*model.PlayerResult,
+	seasonId string) error {
+
+	writes := make([]mongo.WriteModel, len(results))
+	for i, r := range results {
+		writes[i] = mongo.NewUpdateOneModel().
+			SetFilter(seasonFilter(bson.M{"playerId": r.PlayerId, "gameModeId": game.GameModeId}, seasonId)).
+			SetUpdate(playerStatsUpdate(r, game.EndTime)).
+			SetUpsert(true)
+	}
+
+	_, err := m.playerStatsColl(seasonId).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// playerStatsUpdate adds a result to a player's stats. It is a pipeline so the best streaks can be
+// compared with the streaks updated by the same write.
+func playerStatsUpdate(r *model.PlayerResult, endTime time.Time) mongo.Pipeline {
+	add := func(field string, v interface{}) bson.M {
+		return bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$" + field, 0}}, v}}
+	}
+	count := func(field string, b bool) bson.M {
+		if b {
+			return add(field, 1)
+		}
+		return add(field, 0)
+	}
+
+	set := bson.M{
+		"gamesPlayed": add("gamesPlayed", 1),
+		"wins":        count("wins", r.Won),
+		"losses":      count("losses", r.Lost),
+		"draws":       count("draws", r.Outcome == model.OutcomeDraw),
+		"leftEarly":   count("leftEarly", r.LeftEarly),
+		"timePlayed":  add("timePlayed", r.TimeInGame),
+		"kills":       add("kills", r.Kills),
+		"finalKills":  add("finalKills", r.FinalKills),
+		"lastPlayed":  bson.M{"$max": bson.A{"$lastPlayed", endTime}},
+	}
+
+	switch r.Outcome {
+	case model.OutcomeWin:
+		set["winStreak"] = add("winStreak", 1)
+		set["lossStreak"] = 0
+	case model.OutcomeLoss:
+		set["winStreak"] = 0
+		set["lossStreak"] = add("lossStreak", 1)
+	case model.OutcomeDraw:
+		set["winStreak"] = 0
+		set["lossStreak"] = 0
+	}
+
+	best := func(bestField string, field string) bson.M {
+		return bson.M{"$max": bson.A{bson.M{"$ifNull": bson.A{"$" + bestField, 0}}, bson.M{"$ifNull": bson.A{"$" + field, 0}}}}
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$set", Value: set}},
+		{{Key: "$set", Value: bson.M{
+			"bestWinStreak":  best("bestWinStreak", "winStreak"),
+			"bestLossStreak": best("bestLossStreak", "lossStreak"),
+		}}},
+	}
+}
+
+func (m *mongoRepository) GetPlayerStats(ctx context.Context, playerId uuid.UUID, seasonId string) ([]*model.PlayerStats, error) {
+	return m.findPlayerStats(ctx, m.playerStatsColl(seasonId), seasonFilter(bson.M{"playerId": playerId}, seasonId))
+}
+
+func (m *mongoRepository) GetPlayerSeasonStats(ctx context.Context, playerId uuid.UUID) ([]*model.PlayerStats, error) {
+	return m.findPlayerStats(ctx, m.playerSeasonStatsCollection, bson.M{"playerId": playerId})
+}
+
+func (m *mongoRepository) findPlayerStats(ctx context.Context, coll *mongo.Collection, filter bson.M) ([]*model.PlayerStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "seasonId", Value: 1}, {Key: "gameModeId", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player stats: %w", err)
+	}
+
+	var stats []*model.PlayerStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode player stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (m *mongoRepository) GetWinStreakLeaderboard(ctx context.Context, seasonId string, gameModeId string,
+	activeSince *time.Time, limit int64) ([]*model.PlayerStats, error) {
+
+	filter := bson.M{"gameModeId": gameModeId, "winStreak": bson.M{"$gt": 0}}
+	if activeSince != nil {
+		filter["lastPlayed"] = bson.M{"$gte": *activeSince}
+	}
+
+	// Equal streaks are ordered by the most recently played, as they are the most likely to still be extended
+	return m.statsLeaderboard(ctx, seasonId, seasonFilter(filter, seasonId),
+		bson.D{{Key: "winStreak", Value: -1}, {Key: "lastPlayed", Value: -1}}, limit)
+}
+
+func (m *mongoRepository) GetStatsLeaderboard(ctx context.Context, seasonId string, gameModeId string, metric model.StatsMetric,
+	limit int64) ([]*model.PlayerStats, error) {
+
+	if !metric.Valid() {
+		return nil, fmt.Errorf("unknown stats metric %q", metric)
+	}
+
+	// Equal values are ordered by who got there first, the player who last played the longest ago
+	filter := seasonFilter(bson.M{"gameModeId": gameModeId, string(metric): bson.M{"$gt": 0}}, seasonId)
+	return m.statsLeaderboard(ctx, seasonId, filter,
+		bson.D{{Key: string(metric), Value: -1}, {Key: "lastPlayed", Value: 1}}, limit)
+}
+
+func (m *mongoRepository) statsLeaderboard(ctx context.Context, seasonId string, filter bson.M, sort bson.D,
+	limit int64) ([]*model.PlayerStats, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := m.playerStatsColl(seasonId).Find(ctx, filter, options.Find().SetSort(sort).SetLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find leaderboard: %w", err)
+	}
+
+	var stats []*model.PlayerStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode leaderboard: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (m *mongoRepository) GetSeasonGameModes(ctx context.Context, seasonId string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	values, err := m.playerSeasonStatsCollection.Distinct(ctx, "gameModeId", bson.M{"seasonId": seasonId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find season game modes: %w", err)
+	}
+
+	gameModeIds := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			gameModeIds = append(gameModeIds, id)
+		}
+	}
+
+	return gameModeIds, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+	"time"
+)
+
+var playerIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "usernameLower", Value: 1}},
+		Options: options.Index().SetName("usernameLower"),
+	},
+	{
+		Keys:    bson.D{{Key: "previousUsernames.usernameLower", Value: 1}},
+		Options: options.Index().SetName("previousUsernameLower"),
+	},
+}
+
+func (m *mongoRepository) RecordPlayers(ctx context.Context, players []*model.BasicPlayer, seenAt time.Time) error {
+	if len(players) == 0 {
+		return nil
+	}
+
+	// Each player gets three mutually exclusive writes so the directory can be updated in a single round trip:
+	// seen with the same username, seen with a new username, or never seen before.
+	writes := make([]mongo.WriteModel, 0, len(players)*3)
+	for _, p := range players {
+		lower := strings.ToLower(p.Username)
+
+		writes = append(writes,
+			mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": p.Id, "username": p.Username}).
+				SetUpdate(bson.M{"$max": bson.M{"lastSeen": seenAt}}),
+			mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": p.Id, "username": bson.M{"$ne": p.Username}}).
+				SetUpdate(mongo.Pipeline{{{Key: "$set", Value: bson.M{
+					"previousUsernames": bson.M{"$concatArrays": bson.A{
+						bson.M{"$ifNull": bson.A{"$previousUsernames", bson.A{}}},
+						bson.A{bson.M{
+							"username":      "$username",
+							"usernameLower": "$usernameLower",
+							"firstSeen":     "$usernameFirstSeen",
+							"lastSeen":      "$lastSeen",
+						}},
+					}},
+					"username":          p.Username,
+					"usernameLower":     lower,
+					"usernameFirstSeen": seenAt,
+					"lastSeen":          seenAt,
+				}}}}),
+			mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": p.Id}).
+				SetUpdate(bson.M{"$setOnInsert": &model.Player{
+					Id:                p.Id,
+					Username:          p.Username,
+					UsernameLower:     lower,
+					UsernameFirstSeen: seenAt,
+					FirstSeen:         seenAt,
+					LastSeen:          seenAt,
+				}}).
+				SetUpsert(true),
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := m.playerCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to record players: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) GetPlayer(ctx context.Context, id uuid.UUID) (*model.Player, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var player model.Player
+	if err := m.playerCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&player); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	return &player, nil
+}
+
+func (m *mongoRepository) GetPlayers(ctx context.Context, ids []uuid.UUID) ([]*model.Player, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := m.playerCollection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find players: %w", err)
+	}
+
+	var players []*model.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, fmt.Errorf("failed to decode players: %w", err)
+	}
+
+	return players, nil
+}
+
+func (m *mongoRepository) SearchPlayersByUsername(ctx context.Context, username string) ([]*model.Player, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	lower := strings.ToLower(username)
+	filter := bson.M{"$or": bson.A{
+		bson.M{"usernameLower": lower},
+		bson.M{"previousUsernames.usernameLower": lower},
+	}}
+
+	cursor, err := m.playerCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"lastSeen": -1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find players: %w", err)
+	}
+
+	var players []*model.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, fmt.Errorf("failed to decode players: %w", err)
+	}
+
+	// Players currently using the username come first
+	current := make([]*model.Player, 0, len(players))
+	former := make([]*model.Player, 0, len(players))
+	for _, p := range players {
+		if p.UsernameLower == lower {
+			current = append(current, p)
+		} else {
+			former = append(former, p)
+		}
+	}
+
+	return append(current, former...), nil
+}
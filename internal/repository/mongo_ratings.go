@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// All-time and season ratings share a collection, all-time ratings having an empty season id
+
+var playerRatingIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "seasonId", Value: 1}, {Key: "gameModeId", Value: 1}, {Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("seasonId_gameModeId_playerId").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "seasonId", Value: 1}, {Key: "gameModeId", Value: 1}, {Key: "rating", Value: -1}},
+		Options: options.Index().SetName("seasonId_gameModeId_rating"),
+	},
+	{
+		Keys:    bson.D{{Key: "playerId", Value: 1}},
+		Options: options.Index().SetName("playerId"),
+	},
+}
+
+func (m *mongoRepository) RecordPlayerRatings(ctx context.Context, game *model.HistoricGame,
+	seasonId string) ([]*model.PlayerRating, error) {
+
+	results := game.PlayerResults()
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if seasonId != "" {
+		if _, err := m.writePlayerRatings(ctx, game, results, seasonId); err != nil {
+			return nil, fmt.Errorf("failed to record season player ratings: %w", err)
+		}
+	}
+
+	ratings, err := m.writePlayerRatings(ctx, game, results, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to record player ratings: %w", err)
+	}
+
+	return ratings, nil
+}
+
+// writePlayerRatings applies the game to the players' ratings in the season, returning the updated ratings
+func (m *mongoRepository) writePlayerRatings(ctx context.Context, game *model.HistoricGame, results []*model.PlayerResult,
+	seasonId string) ([]*model.PlayerRating, error) {
+
+	playerIds := make([]uuid.UUID, len(results))
+	for i, r := range results {
+		playerIds[i] = r.PlayerId
+	}
+
+	filter := bson.M{"seasonId": seasonId, "gameModeId": game.GameModeId, "playerId": bson.M{"$in": playerIds}}
+	cursor, err := m.playerRatingCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player ratings: %w", err)
+	}
+
+	var current []*model.PlayerRating
+	if err := cursor.All(ctx, &current); err != nil {
+		return nil, fmt.Errorf("failed to decode player ratings: %w", err)
+	}
+
+	byId := make(map[uuid.UUID]*model.PlayerRating, len(current))
+	ratings := make(map[uuid.UUID]float64, len(current))
+	for _, r := range current {
+		byId[r.PlayerId] = r
+		ratings[r.PlayerId] = r.Rating
+	}
+
+	changes := model.RatingChanges(results, ratings)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	updated := make([]*model.PlayerRating, 0, len(changes))
+	writes := make([]mongo.WriteModel, 0, len(changes))
+	for _, r := range results {
+		change, ok := changes[r.PlayerId]
+		if !ok {
+			continue
+		}
+
+		rating := byId[r.PlayerId]
+		if rating == nil {
+			rating = &model.PlayerRating{PlayerId: r.PlayerId, GameModeId: game.GameModeId, SeasonId: seasonId,
+				Rating: model.InitialRating, PeakRating: model.InitialRating}
+		}
+		rating.Rating += change
+		rating.LastChange = change
+		rating.PeakRating = max(rating.PeakRating, rating.Rating)
+		rating.Games++
+		if game.EndTime.After(rating.LastPlayed) {
+			rating.LastPlayed = game.EndTime
+		}
+		updated = append(updated, rating)
+
+		// The rating is set from the one read, so in a transaction a concurrent game of the same player
+		// conflicts and is retried rather than overwritten
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"seasonId": seasonId, "gameModeId": game.GameModeId, "playerId": r.PlayerId}).
+			SetUpdate(bson.M{"$set": rating}).
+			SetUpsert(true))
+	}
+
+	if _, err := m.playerRatingCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (m *mongoRepository) GetPlayerRatings(ctx context.Context, playerId uuid.UUID) ([]*model.PlayerRating, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "seasonId", Value: 1}, {Key: "gameModeId", Value: 1}})
+	cursor, err := m.playerRatingCollection.Find(ctx, bson.M{"playerId": playerId}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find player ratings: %w", err)
+	}
+
+	var ratings []*model.PlayerRating
+	if err := cursor.All(ctx, &ratings); err != nil {
+		return nil, fmt.Errorf("failed to decode player ratings: %w", err)
+	}
+
+	return ratings, nil
+}
+
+func (m *mongoRepository) GetRatingLeaderboard(ctx context.Context, seasonId string, gameModeId string, minGames int64,
+	limit int64) ([]*model.PlayerRating, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"seasonId": seasonId, "gameModeId": gameModeId}
+	if minGames > 0 {
+		filter["games"] = bson.M{"$gte": minGames}
+	}
+
+	// Equal ratings are ordered by who got there first, the player who last played the longest ago
+	opts := options.Find().SetSort(bson.D{{Key: "rating", Value: -1}, {Key: "lastPlayed", Value: 1}}).SetLimit(limit)
+	cursor, err := m.playerRatingCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find rating leaderboard: %w", err)
+	}
+
+	var ratings []*model.PlayerRating
+	if err := cursor.All(ctx, &ratings); err != nil {
+		return nil, fmt.Errorf("failed to decode rating leaderboard: %w", err)
+	}
+
+	return ratings, nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// recomputeSuffix is appended to the names of the collections a recompute writes into before they are swapped in
+	recomputeSuffix = "_recompute"
+
+	recomputeCheckpointId = "recompute"
+)
+
+// derivedCollectionIndexes are the indexes of the collections rebuilt from historic games by a recompute.
+// Achievements aren't rebuilt as they were announced when unlocked.
+var derivedCollectionIndexes = map[string][]mongo.IndexModel{
+	playerStatsCollectionName:       playerStatsIndexes,
+	playerPairCollectionName:        playerPairIndexes,
+	playerSeasonStatsCollectionName: playerSeasonStatsIndexes,
+	playerSeasonPairCollectionName:  playerSeasonPairIndexes,
+	leaderboardScoreCollectionName:  leaderboardScoreIndexes,
+	playerRatingCollectionName:      playerRatingIndexes,
+}
+
+func (m *mongoRepository) RecomputeTarget() Repository {
+	return m.recomputeTarget()
+}
+
+// recomputeTarget returns a copy of the repository that records derived data into the recompute collections
+func (m *mongoRepository) recomputeTarget() *mongoRepository {
+	target := *m
+	target.playerStatsCollection = m.database.Collection(playerStatsCollectionName + recomputeSuffix)
+	target.playerPairCollection = m.database.Collection(playerPairCollectionName + recomputeSuffix)
+	target.playerSeasonStatsCollection = m.database.Collection(playerSeasonStatsCollectionName + recomputeSuffix)
+	target.playerSeasonPairCollection = m.database.Collection(playerSeasonPairCollectionName + recomputeSuffix)
+	target.leaderboardScoreCollection = m.database.Collection(leaderboardScoreCollectionName + recomputeSuffix)
+	target.playerRatingCollection = m.database.Collection(playerRatingCollectionName + recomputeSuffix)
+
+	return &target
+}
+
+func (m *mongoRepository) StartRecompute(ctx context.Context, checkpoint *model.RecomputeCheckpoint) error {
+	// Games are claimed in the transactions that apply them, so without transactions a game could be applied twice
+	if !m.transactions {
+		return errors.New("recomputes require transactions, which require mongo to run as a replica set")
+	}
+
+	// The checkpoint goes first so consumers stop recording into collections that are about to be dropped
+	if err := m.deleteRecomputeCheckpoint(ctx); err != nil {
+		return err
+	}
+
+	for _, coll := range m.recomputeCollections() {
+		dropCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		err := coll.Drop(dropCtx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to drop %s: %w", coll.Name(), err)
+		}
+	}
+
+	for name, indexes := range derivedCollectionIndexes {
+		coll := m.database.Collection(name + recomputeSuffix)
+		if _, err := m.createCollIndexes(ctx, coll, indexes); err != nil {
+			return fmt.Errorf("failed to create indexes of %s: %w", coll.Name(), err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := m.recomputeCheckpointCollection.ReplaceOne(ctx, bson.M{"_id": recomputeCheckpointId}, checkpoint,
+		options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save recompute checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// recomputeCollections returns the collections a recompute writes into, including its claims
+func (m *mongoRepository) recomputeCollections() []*mongo.Collection {
+	colls := []*mongo.Collection{m.recomputeClaimCollection}
+	for name := range derivedCollectionIndexes {
+		colls = append(colls, m.database.Collection(name+recomputeSuffix))
+	}
+
+	return colls
+}
+
+func (m *mongoRepository) GetRecomputeCheckpoint(ctx context.Context) (*model.RecomputeCheckpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var checkpoint model.RecomputeCheckpoint
+	err := m.recomputeCheckpointCollection.FindOne(ctx, bson.M{"_id": recomputeCheckpointId}).Decode(&checkpoint)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get recompute checkpoint: %w", err)
+	}
+
+	return &checkpoint, nil
+}
+
+func (m *mongoRepository) SaveRecomputeCheckpoint(ctx context.Context, checkpoint *model.RecomputeCheckpoint) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Not upserted, so a recompute started over by another run fails rather than recreating the checkpoint
+	result, err := m.recomputeCheckpointCollection.ReplaceOne(ctx, bson.M{"_id": recomputeCheckpointId}, checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to save recompute checkpoint: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) ListHistoricGamesAfter(ctx context.Context, endTime time.Time, id primitive.ObjectID,
+	limit int64) ([]*model.HistoricGame, error) {
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"endTime": bson.M{"$gt": endTime}},
+		bson.M{"endTime": endTime, "_id": bson.M{"$gt": id}},
+	}}
+
+	return m.findHistoricGames(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "endTime", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(limit))
+}
+
+func (m *mongoRepository) ListUnclaimedRecomputeGames(ctx context.Context) ([]primitive.ObjectID, error) {
+	// Every game is looked up, so this takes far longer than a normal query
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$project", Value: bson.M{"_id": 1}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         recomputeClaimCollectionName,
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "claims",
+		}}},
+		{{Key: "$match", Value: bson.M{"claims": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := m.historicGameCollection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find unclaimed games: %w", err)
+	}
+
+	var results []struct {
+		Id primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode unclaimed games: %w", err)
+	}
+
+	ids := make([]primitive.ObjectID, len(results))
+	for i, r := range results {
+		ids[i] = r.Id
+	}
+
+	return ids, nil
+}
+
+func (m *mongoRepository) ClaimRecomputeGame(ctx context.Context, gameId primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// An upsert rather than an insert, as a duplicate key error would abort the transaction. Concurrent claims
+	// of the same game conflict, so the transaction that loses is retried and finds the game claimed.
+	result, err := m.recomputeClaimCollection.UpdateOne(ctx, bson.M{"_id": gameId},
+		bson.M{"$setOnInsert": bson.M{"claimedAt": time.Now()}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim game %s: %w", gameId.Hex(), err)
+	}
+
+	return result.UpsertedCount > 0, nil
+}
+
+func (m *mongoRepository) SwapRecompute(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	names := make([]string, 0, len(derivedCollectionIndexes))
+	for name := range derivedCollectionIndexes {
+		names = append(names, name+recomputeSuffix)
+	}
+
+	// The recompute collections already renamed by an interrupted swap are gone
+	remaining, err := m.database.ListCollectionNames(ctx, bson.M{"name": bson.M{"$in": names}})
+	if err != nil {
+		return fmt.Errorf("failed to list recompute collections: %w", err)
+	}
+
+	admin := m.database.Client().Database("admin")
+	for _, name := range remaining {
+		from := m.database.Name() + "." + name
+		to := from[:len(from)-len(recomputeSuffix)]
+
+		cmd := bson.D{{Key: "renameCollection", Value: from}, {Key: "to", Value: to}, {Key: "dropTarget", Value: true}}
+		if err := admin.RunCommand(ctx, cmd).Err(); err != nil {
+			return fmt.Errorf("failed to swap in %s: %w", to, err)
+		}
+	}
+
+	if err := m.recomputeClaimCollection.Drop(ctx); err != nil {
+		return fmt.Errorf("failed to drop recompute claims: %w", err)
+	}
+
+	// The checkpoint goes last, as it is what lets consumers record stats again
+	return m.deleteRecomputeCheckpoint(ctx)
+}
+
+func (m *mongoRepository) deleteRecomputeCheckpoint(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := m.recomputeCheckpointCollection.DeleteOne(ctx, bson.M{"_id": recomputeCheckpointId}); err != nil {
+		return fmt.Errorf("failed to delete recompute checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+var seasonSnapshotIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "playerIds", Value: 1}},
+		Options: options.Index().SetName("playerIds"),
+	},
+}
+
+func (m *mongoRepository) SaveSeasonSnapshot(ctx context.Context, snapshot *model.SeasonSnapshot) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := m.seasonSnapshotCollection.ReplaceOne(ctx, bson.M{"_id": snapshot.SeasonId}, snapshot,
+		options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save season snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) GetSeasonSnapshot(ctx context.Context, seasonId string) (*model.SeasonSnapshot, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var snapshot model.SeasonSnapshot
+	if err := m.seasonSnapshotCollection.FindOne(ctx, bson.M{"_id": seasonId}).Decode(&snapshot); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get season snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// eraseSeasonSnapshots pseudonymises the player in the hall of fame, returning the ids of the seasons changed
+func (m *mongoRepository) eraseSeasonSnapshots(ctx context.Context, playerId uuid.UUID, pseudonym uuid.UUID) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := m.seasonSnapshotCollection.Find(ctx, bson.M{"playerIds": playerId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find season snapshots: %w", err)
+	}
+
+	var snapshots []*model.SeasonSnapshot
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to decode season snapshots: %w", err)
+	}
+
+	seasonIds := make([]string, 0, len(snapshots))
+	for _, s := range snapshots {
+		if !s.ReplacePlayer(playerId, pseudonym) {
+			continue
+		}
+
+		if _, err := m.seasonSnapshotCollection.ReplaceOne(ctx, bson.M{"_id": s.SeasonId}, s); err != nil {
+			return seasonIds, fmt.Errorf("failed to replace season snapshot %s: %w", s.SeasonId, err)
+		}
+		seasonIds = append(seasonIds, s.SeasonId)
+	}
+
+	return seasonIds, nil
+}
@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math"
+	"time"
+)
+
+// seasonGameFilter filters the game mode's games to those that ended during the season, if one is given
+func seasonGameFilter(gameModeId string, season *config.Season) HistoricGameFilter {
+	filter := HistoricGameFilter{GameModeId: gameModeId}
+	if season != nil {
+		filter.From, filter.To = &season.Start, &season.End
+	}
+
+	return filter
+}
+
+// archiveInSeason returns true if every game in the archive ended during the season.
+// Archives are aggregated as a whole, so one straddling the start or end of a season can't be split.
+func archiveInSeason(archive *model.Archive, season *config.Season) bool {
+	return !archive.From.Before(season.Start) && archive.To.Before(season.End)
+}
+
+// getSeasonArchives returns the archives to merge into the aggregates of the season, or every archive if it is nil
+func (m *mongoRepository) getSeasonArchives(ctx context.Context, gameModeId string, season *config.Season) ([]*model.Archive, error) {
+	if season == nil {
+		return m.getArchives(ctx, gameModeId, nil, nil)
+	}
+
+	archives, err := m.getArchives(ctx, gameModeId, &season.Start, &season.End)
+	if err != nil {
+		return nil, err
+	}
+
+	inSeason := archives[:0]
+	for _, a := range archives {
+		if archiveInSeason(a, season) {
+			inSeason = append(inSeason, a)
+		}
+	}
+
+	return inSeason, nil
+}
+
+func (m *mongoRepository) GetTowerDefenceMapWinRates(ctx context.Context, season *config.Season) ([]*model.TowerDefenceMapWinRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	winRates, err := m.aggregateTowerDefenceMapWinRates(ctx, seasonGameFilter("", season).toBson())
+	if err != nil {
+		return nil, err
+	}
+
+	archives, err := m.getSeasonArchives(ctx, "", season)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range archives {
+		winRates = model.MergeTowerDefenceMapWinRates(winRates, a.Aggregates.TowerDefenceMapWinRates)
+	}
+
+	return winRates, nil
+}
+
+func (m *mongoRepository) aggregateTowerDefenceMapWinRates(ctx context.Context, match bson.M) ([]*model.TowerDefenceMapWinRate, error) {
+	match["gameDataType"] = model.HistoricTowerDefenceDataId
+	match["gameData.analytics"] = bson.M{"$exists": true}
+
+	winsOf := func(team string) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$gameData.analytics.winningTeam", team}}, 1, 0}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$mapId",
+			"games":    bson.M{"$sum": 1},
+			"redWins":  winsOf(model.TowerDefenceRedTeamId),
+			"blueWins": winsOf(model.TowerDefenceBlueTeamId),
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := m.historicGameCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate tower defence win rates: %w", err)
+	}
+
+	var winRates []*model.TowerDefenceMapWinRate
+	if err := cursor.All(ctx, &winRates); err != nil {
+		return nil, fmt.Errorf("failed to decode tower defence win rates: %w", err)
+	}
+
+	return winRates, nil
+}
+
+func (m *mongoRepository) GetMapStats(ctx context.Context, gameModeId string, season *config.Season) ([]*model.MapStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	stats, err := m.aggregateMapStats(ctx, seasonGameFilter(gameModeId, season).toBson())
+	if err != nil {
+		return nil, err
+	}
+
+	archives, err := m.getSeasonArchives(ctx, gameModeId, season)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range archives {
+		stats = model.MergeMapStats(stats, a.Aggregates.MapStats)
+	}
+
+	return stats, nil
+}
+
+func (m *mongoRepository) aggregateMapStats(ctx context.Context, match bson.M) ([]*model.MapStats, error) {
+	// Games saved before the winning team was stored use the team containing the first winner, if any
+	resolvedWinningTeam := bson.M{"$arrayElemAt": bson.A{
+		bson.M{"$map": bson.M{
+			"input": bson.M{"$filter": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$teams", bson.A{}}},
+				"as":    "team",
+				"cond": bson.M{"$in": bson.A{
+					bson.M{"$arrayElemAt": bson.A{"$winnerData.winnerIds", 0}},
+					bson.M{"$ifNull": bson.A{"$$team.playerIds", bson.A{}}},
+				}},
+			}},
+			"as": "team",
+			"in": "$$team.id",
+		}},
+		0,
+	}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$project", Value: bson.M{
+			"gameModeId":  1,
+			"mapId":       bson.M{"$ifNull": bson.A{"$mapId", ""}},
+			"duration":    bson.M{"$subtract": bson.A{"$endTime", "$startTime"}},
+			"players":     bson.M{"$size": bson.M{"$ifNull": bson.A{"$players", bson.A{}}}},
+			"winningTeam": bson.M{"$ifNull": bson.A{"$winningTeamId", resolvedWinningTeam}},
+		}}},
+		// Group by team first so the team wins can be pushed into each map
+		{{Key: "$group", Value: bson.M{
+			"_id":           bson.M{"gameModeId": "$gameModeId", "mapId": "$mapId", "teamId": "$winningTeam"},
+			"plays":         bson.M{"$sum": 1},
+			"timedPlays":    bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$type": "$duration"}, "long"}}, 1, 0}}},
+			"totalDuration": bson.M{"$sum": "$duration"},
+			"totalPlayers":  bson.M{"$sum": "$players"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           bson.M{"gameModeId": "$_id.gameModeId", "mapId": "$_id.mapId"},
+			"plays":         bson.M{"$sum": "$plays"},
+			"timedPlays":    bson.M{"$sum": "$timedPlays"},
+			"totalDuration": bson.M{"$sum": "$totalDuration"},
+			"totalPlayers":  bson.M{"$sum": "$totalPlayers"},
+			"teamWins":      bson.M{"$push": bson.M{"teamId": "$_id.teamId", "wins": "$plays"}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":                 0,
+			"gameModeId":          "$_id.gameModeId",
+			"mapId":               "$_id.mapId",
+			"plays":               1,
+			"timedPlays":          1,
+			"totalDurationMillis": "$totalDuration",
+			"totalPlayers":        1,
+			// Games without a winning team are only counted in plays
+			"teamWins": bson.M{"$filter": bson.M{
+				"input": "$teamWins",
+				"as":    "team",
+				"cond":  bson.M{"$ne": bson.A{bson.M{"$ifNull": bson.A{"$$team.teamId", nil}}, nil}},
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "gameModeId", Value: 1}, {Key: "mapId", Value: 1}}}},
+	}
+
+	cursor, err := m.historicGameCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate map stats: %w", err)
+	}
+
+	var stats []*model.MapStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode map stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (m *mongoRepository) GetDurationStats(ctx context.Context, gameModeId string, season *config.Season) ([]*model.DurationStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	gameModeIds := []string{gameModeId}
+	if gameModeId == "" {
+		distinct, err := m.historicGameCollection.Distinct(ctx, "gameModeId", seasonGameFilter("", season).toBson())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get game modes: %w", err)
+		}
+
+		gameModeIds = make([]string, 0, len(distinct))
+		for _, id := range distinct {
+			if str, ok := id.(string); ok {
+				gameModeIds = append(gameModeIds, str)
+			}
+		}
+	}
+
+	stats := make([]*model.DurationStats, 0, len(gameModeIds))
+	for _, id := range gameModeIds {
+		s, err := m.getGameModeDurationStats(ctx, id, season)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get duration stats of %s: %w", id, err)
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, nil
+}
+
+// getGameModeDurationStats uses the gameModeId_duration index to find percentiles without loading every duration.
+// Percentiles can't be combined, so archived games aren't included.
+func (m *mongoRepository) getGameModeDurationStats(ctx context.Context, gameModeId string,
+	season *config.Season) (*model.DurationStats, error) {
+
+	filter := seasonGameFilter(gameModeId, season).toBson()
+	filter["duration"] = bson.M{"$gt": 0}
+
+	stats := &model.DurationStats{GameModeId: gameModeId}
+
+	cursor, err := m.historicGameCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "games": bson.M{"$sum": 1}, "average": bson.M{"$avg": "$duration"}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate durations: %w", err)
+	}
+
+	var totals []struct {
+		Games   int64   `bson:"games"`
+		Average float64 `bson:"average"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil {
+		return nil, fmt.Errorf("failed to decode durations: %w", err)
+	}
+
+	if len(totals) == 0 {
+		return stats, nil
+	}
+	stats.Games = totals[0].Games
+	stats.Average = time.Duration(totals[0].Average)
+
+	percentile := func(p float64) (time.Duration, error) {
+		// nearest-rank percentile
+		rank := int64(math.Ceil(p*float64(stats.Games))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+
+		opts := options.FindOne().
+			SetSort(bson.D{{Key: "duration", Value: 1}}).
+			SetSkip(rank).
+			SetProjection(bson.M{"duration": 1})
+
+		var result struct {
+			Duration time.Duration `bson:"duration"`
+		}
+		if err := m.historicGameCollection.FindOne(ctx, filter, opts).Decode(&result); err != nil {
+			return 0, err
+		}
+
+		return result.Duration, nil
+	}
+
+	if stats.Median, err = percentile(0.5); err != nil {
+		return nil, fmt.Errorf("failed to get median duration: %w", err)
+	}
+	if stats.P95, err = percentile(0.95); err != nil {
+		return nil, fmt.Errorf("failed to get p95 duration: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (m *mongoRepository) GetTeamColorWinRates(ctx context.Context, gameModeId string,
+	season *config.Season) ([]*model.TeamColorWinRate, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	winRates, err := m.aggregateTeamColorWinRates(ctx, seasonGameFilter(gameModeId, season).toBson())
+	if err != nil {
+		return nil, err
+	}
+
+	archives, err := m.getSeasonArchives(ctx, gameModeId, season)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range archives {
+		winRates = model.MergeTeamColorWinRates(winRates, a.Aggregates.TeamColorWinRates)
+	}
+
+	return winRates, nil
+}
+
+func (m *mongoRepository) aggregateTeamColorWinRates(ctx context.Context, match bson.M) ([]*model.TeamColorWinRate, error) {
+	match["teamStats"] = bson.M{"$exists": true}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$teamStats"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"gameModeId": "$gameModeId", "color": "$teamStats.color"},
+			"games": bson.M{"$sum": 1},
+			"wins":  bson.M{"$sum": bson.M{"$cond": bson.A{"$teamStats.won", 1, 0}}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":        0,
+			"gameModeId": "$_id.gameModeId",
+			"color":      "$_id.color",
+			"games":      1,
+			"wins":       1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "gameModeId", Value: 1}, {Key: "color", Value: 1}}}},
+	}
+
+	cursor, err := m.historicGameCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate team colour win rates: %w", err)
+	}
+
+	var winRates []*model.TeamColorWinRate
+	if err := cursor.All(ctx, &winRates); err != nil {
+		return nil, fmt.Errorf("failed to decode team colour win rates: %w", err)
+	}
+
+	return winRates, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"testing"
+	"time"
+)
+
+func TestArchiveInSeason(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	season := &config.Season{Id: "s1", Start: day(10), End: day(20)}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     bool
+	}{
+		{name: "inside", from: day(11), to: day(19), want: true},
+		{name: "from the start", from: day(10), to: day(12), want: true},
+		{name: "up to the end", from: day(18), to: day(20).Add(-time.Millisecond), want: true},
+		{name: "game at the end", from: day(18), to: day(20), want: false},
+		{name: "straddles the start", from: day(9), to: day(12), want: false},
+		{name: "straddles the end", from: day(18), to: day(21), want: false},
+		{name: "before", from: day(1), to: day(5), want: false},
+		{name: "spans the season", from: day(1), to: day(25), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := archiveInSeason(&model.Archive{From: tt.from, To: tt.to}, season); got != tt.want {
+				t.Errorf("archiveInSeason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeasonGameFilter(t *testing.T) {
+	season := &config.Season{Id: "s1", Start: time.Unix(100, 0), End: time.Unix(200, 0)}
+
+	tests := []struct {
+		name       string
+		gameModeId string
+		season     *config.Season
+		wantKeys   []string
+	}{
+		{name: "all-time", wantKeys: nil},
+		{name: "game mode", gameModeId: "block-sumo", wantKeys: []string{"gameModeId"}},
+		{name: "season", season: season, wantKeys: []string{"endTime"}},
+		{name: "game mode in season", gameModeId: "block-sumo", season: season, wantKeys: []string{"gameModeId", "endTime"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := seasonGameFilter(tt.gameModeId, tt.season).toBson()
+			if len(filter) != len(tt.wantKeys) {
+				t.Errorf("filter = %v, want keys %v", filter, tt.wantKeys)
+			}
+			for _, key := range tt.wantKeys {
+				if _, ok := filter[key]; !ok {
+					t.Errorf("filter = %v, missing %s", filter, key)
+				}
+			}
+
+			if tt.season == nil {
+				return
+			}
+			endTime := filter["endTime"].(bson.M)
+			if endTime["$gte"] != season.Start || endTime["$lt"] != season.End {
+				t.Errorf("end time = %v, want [%s, %s)", endTime, season.Start, season.End)
+			}
+		})
+	}
+}
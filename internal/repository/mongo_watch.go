@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type changeEvent struct {
+	OperationType string `bson:"operationType"`
+	Ns            struct {
+		Coll string `bson:"coll"`
+	} `bson:"ns"`
+	DocumentKey struct {
+		Id primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument bson.Raw `bson:"fullDocument"`
+
+	// Lsid and TxnNumber identify the transaction the change was made in, and are absent outside transactions
+	Lsid      bson.Raw `bson:"lsid"`
+	TxnNumber *int64   `bson:"txnNumber"`
+}
+
+// finishTransaction tracks the changes of the transaction being read. FinishGame inserts the historic game
+// and deletes its live game in one transaction, whose changes are consecutive in the stream, so a historic game
+// finished a live game only if the same transaction deleted it. Only one transaction is tracked at a time.
+type finishTransaction struct {
+	key      string
+	historic map[primitive.ObjectID]*model.HistoricGame
+	deleted  map[primitive.ObjectID]bool
+}
+
+// finished records the change, returning the historic game if it completes a finished game
+func (t *finishTransaction) finished(event *changeEvent, historic *model.HistoricGame) *model.HistoricGame {
+	if event.TxnNumber == nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%x:%d", []byte(event.Lsid), *event.TxnNumber)
+	if key != t.key {
+		*t = finishTransaction{
+			key:      key,
+			historic: make(map[primitive.ObjectID]*model.HistoricGame),
+			deleted:  make(map[primitive.ObjectID]bool),
+		}
+	}
+
+	id := event.DocumentKey.Id
+	if historic != nil {
+		if t.deleted[id] {
+			return historic
+		}
+		t.historic[id] = historic
+		return nil
+	}
+
+	t.deleted[id] = true
+	return t.historic[id]
+}
+
+func (m *mongoRepository) WatchGames(ctx context.Context, fn func(change *GameChange)) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"ns.coll": liveGameCollectionName, "operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}},
+			bson.M{"ns.coll": historicGameCollectionName, "operationType": "insert"},
+		}}}},
+	}
+
+	stream, err := m.database.Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if err != nil {
+		return fmt.Errorf("failed to watch games: %w", err)
+	}
+	defer stream.Close(context.Background())
+
+	var txn finishTransaction
+	for stream.Next(ctx) {
+		var event changeEvent
+		if err := stream.Decode(&event); err != nil {
+			return fmt.Errorf("failed to decode change event: %w", err)
+		}
+
+		if event.OperationType == "delete" {
+			if game := txn.finished(&event, nil); game != nil {
+				fn(&GameChange{HistoricGame: game, Finished: true})
+			}
+			continue
+		}
+
+		// The game was deleted before the update could be looked up
+		if event.FullDocument == nil {
+			continue
+		}
+
+		change, err := m.decodeGameChange(&event)
+		if err != nil {
+			m.logger.Errorw("failed to decode changed game", "collection", event.Ns.Coll, "error", err)
+			continue
+		}
+
+		if change.HistoricGame != nil {
+			if game := txn.finished(&event, change.HistoricGame); game != nil {
+				change.Finished = true
+				fn(change)
+			}
+			continue
+		}
+
+		fn(change)
+	}
+
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("game change stream failed: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) decodeGameChange(event *changeEvent) (*GameChange, error) {
+	if event.Ns.Coll == historicGameCollectionName {
+		game, err := DecodeHistoricGame(event.FullDocument)
+		if err != nil {
+			return nil, err
+		}
+
+		return &GameChange{HistoricGame: game}, nil
+	}
+
+	var game model.LiveGame
+	if err := decodeGame(event.FullDocument, &game); err != nil {
+		return nil, fmt.Errorf("failed to decode live game: %w", err)
+	}
+
+	if err := game.ParseGameData(); err != nil {
+		return nil, fmt.Errorf("failed to parse game data: %w", err)
+	}
+
+	return &GameChange{Created: event.OperationType == "insert", LiveGame: &game}, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+func TestFinishTransaction(t *testing.T) {
+	gameId := primitive.NewObjectID()
+	otherId := primitive.NewObjectID()
+	game := &model.HistoricGame{Game: &model.Game{Id: gameId}}
+
+	lsid, err := bson.Marshal(bson.D{{Key: "id", Value: "session"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txn := func(n int64) *int64 {
+		return &n
+	}
+
+	type change struct {
+		id        primitive.ObjectID
+		historic  bool
+		txnNumber *int64
+	}
+
+	tests := []struct {
+		name    string
+		changes []change
+		// want is the index of the change expected to finish the game, or -1
+		want int
+	}{
+		{
+			name:    "insert then delete",
+			changes: []change{{id: gameId, historic: true, txnNumber: txn(1)}, {id: gameId, txnNumber: txn(1)}},
+			want:    1,
+		},
+		{
+			name:    "delete then insert",
+			changes: []change{{id: gameId, txnNumber: txn(1)}, {id: gameId, historic: true, txnNumber: txn(1)}},
+			want:    1,
+		},
+		{
+			name:    "import outside a transaction",
+			changes: []change{{id: gameId, historic: true}},
+			want:    -1,
+		},
+		{
+			name:    "delete in another transaction",
+			changes: []change{{id: gameId, historic: true, txnNumber: txn(1)}, {id: gameId, txnNumber: txn(2)}},
+			want:    -1,
+		},
+		{
+			name:    "delete of another game",
+			changes: []change{{id: gameId, historic: true, txnNumber: txn(1)}, {id: otherId, txnNumber: txn(1)}},
+			want:    -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tracked finishTransaction
+			got := -1
+
+			for i, c := range tt.changes {
+				event := &changeEvent{Lsid: lsid, TxnNumber: c.txnNumber}
+				event.DocumentKey.Id = c.id
+
+				var historic *model.HistoricGame
+				if c.historic {
+					historic = game
+				}
+
+				if finished := tracked.finished(event, historic); finished != nil {
+					if finished != game {
+						t.Fatalf("change %d finished the wrong game", i)
+					}
+					got = i
+				}
+			}
+
+			if got != tt.want {
+				t.Errorf("finished by change %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// completedWebhookDeliveryRetention is how long finished deliveries are kept for inspection before Mongo deletes them
+const completedWebhookDeliveryRetention = 30 * 24 * time.Hour
+
+var webhookDeliveryIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+		Options: options.Index().SetName("status_nextAttemptAt"),
+	},
+	{
+		Keys:    bson.D{{Key: "subscriptionId", Value: 1}, {Key: "_id", Value: -1}},
+		Options: options.Index().SetName("subscriptionId_id"),
+	},
+	{
+		Keys:    bson.D{{Key: "playerIds", Value: 1}},
+		Options: options.Index().SetName("playerIds"),
+	},
+	{
+		// Pending deliveries have no completedAt so are never expired
+		Keys:    bson.D{{Key: "completedAt", Value: 1}},
+		Options: options.Index().SetName("completedAt_ttl").SetExpireAfterSeconds(int32(completedWebhookDeliveryRetention.Seconds())),
+	},
+}
+
+func (m *mongoRepository) SaveWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	if subscription.Id.IsZero() {
+		return ErrIdNotSet
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := m.webhookSubscriptionCollection.ReplaceOne(ctx, bson.M{"_id": subscription.Id}, subscription,
+		options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) GetWebhookSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := m.webhookSubscriptionCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscriptions: %w", err)
+	}
+
+	var subscriptions []*model.WebhookSubscription
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (m *mongoRepository) DeleteWebhookSubscription(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := m.webhookSubscriptionCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": model.WebhookDeliveryPending, "nextAttemptAt": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery model.WebhookDelivery
+	if err := m.webhookDeliveryCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+func (m *mongoRepository) RecordWebhookAttempt(ctx context.Context, id primitive.ObjectID, attempt *model.WebhookAttempt,
+	status model.WebhookDeliveryStatus, nextAttemptAt *time.Time) error {
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	set := bson.M{"status": status}
+	unset := bson.M{}
+	if nextAttemptAt != nil {
+		set["nextAttemptAt"] = *nextAttemptAt
+	} else {
+		set["completedAt"] = attempt.Time
+		unset["nextAttemptAt"] = ""
+	}
+
+	update := bson.M{"$set": set, "$push": bson.M{"attempts": attempt}}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	if _, err := m.webhookDeliveryCollection.UpdateByID(ctx, id, update); err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mongoRepository) GetWebhookDeliveries(ctx context.Context, subscriptionId primitive.ObjectID, limit int64) ([]*model.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(limit)
+
+	cursor, err := m.webhookDeliveryCollection.Find(ctx, bson.M{"subscriptionId": subscriptionId}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+
+	var deliveries []*model.WebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// eraseWebhookPlayer deletes the deliveries containing the player and replaces them in subscription filters.
+// The pseudonym is kept in filters rather than removing the player, as an empty filter would match every game.
+func (m *mongoRepository) eraseWebhookPlayer(ctx context.Context, playerId uuid.UUID, pseudonym uuid.UUID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := m.webhookSubscriptionCollection.UpdateMany(ctx, bson.M{"playerIds": playerId},
+		bson.M{"$set": bson.M{"playerIds.$[p]": pseudonym}},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: bson.A{bson.M{"p": playerId}}}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to erase player from webhook subscriptions: %w", err)
+	}
+
+	result, err := m.webhookDeliveryCollection.DeleteMany(ctx, bson.M{"playerIds": playerId})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
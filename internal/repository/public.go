@@ -2,16 +2,247 @@ package repository
 
 import (
 	"context"
+	"game-tracker/internal/config"
 	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
 )
 
 type Repository interface {
+	// SupportsTransactions returns true if MongoDB runs as a replica set or sharded cluster.
+	// The outbox, webhooks and change streams rely on it.
+	SupportsTransactions() bool
+	// WithTransaction calls fn in a transaction, committing it if fn returns nil. Repository methods called with
+	// the context fn receives are part of the transaction, and so are nested calls to WithTransaction.
+	// Without transaction support fn is called directly, so its writes aren't atomic.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// GetLiveGame returns ErrNotFound if the game isn't live
 	GetLiveGame(ctx context.Context, id primitive.ObjectID) (*model.LiveGame, error)
+	// ListLiveGames returns every live game matching the filter, oldest first
+	ListLiveGames(ctx context.Context, filter LiveGameFilter) ([]*model.LiveGame, error)
 	// SaveLiveGame saves a game (with upsert)
 	SaveLiveGame(ctx context.Context, game *model.LiveGame) error
 	DeleteLiveGame(ctx context.Context, id primitive.ObjectID) error
 
 	SaveHistoricGame(ctx context.Context, game *model.HistoricGame) error
+	// FinishGame saves the historic game, deletes its live game, queues the events in the outbox and queues
+	// the webhook deliveries in a single transaction
+	FinishGame(ctx context.Context, game *model.HistoricGame, events []*model.OutboxEvent,
+		deliveries []*model.WebhookDelivery) error
+	// GetHistoricGame returns ErrNotFound if no game has the id
 	GetHistoricGame(ctx context.Context, id primitive.ObjectID) (*model.HistoricGame, error)
+	// ListHistoricGames returns a page of historic games matching the filter, most recently finished first
+	ListHistoricGames(ctx context.Context, filter HistoricGameFilter, page int64, pageSize int64) ([]*model.HistoricGame, error)
+
+	// The game aggregates are all-time if the season is nil, otherwise of the games that ended during the season.
+	// Archives are only included in a season's aggregates if every game in them ended during the season.
+
+	// GetMapStats returns the stats of every map played, including archived games, optionally limited to a game mode
+	GetMapStats(ctx context.Context, gameModeId string, season *config.Season) ([]*model.MapStats, error)
+	// GetTeamColorWinRates returns the win rate of each team colour per game mode, optionally limited to a game mode
+	GetTeamColorWinRates(ctx context.Context, gameModeId string, season *config.Season) ([]*model.TeamColorWinRate, error)
+	// GetDurationStats returns the duration distribution of every game mode, optionally limited to a single game mode
+	GetDurationStats(ctx context.Context, gameModeId string, season *config.Season) ([]*model.DurationStats, error)
+	// GetTowerDefenceMapWinRates returns the red and blue win counts of every map with analysed Tower Defence games
+	GetTowerDefenceMapWinRates(ctx context.Context, season *config.Season) ([]*model.TowerDefenceMapWinRate, error)
+
+	// RecordPlayers updates the player directory with the usernames the players were seen with
+	RecordPlayers(ctx context.Context, players []*model.BasicPlayer, seenAt time.Time) error
+	// GetPlayer returns ErrNotFound if the player has never been seen
+	GetPlayer(ctx context.Context, id uuid.UUID) (*model.Player, error)
+	// GetPlayers returns the players with the ids, in no particular order. Players never seen are left out.
+	GetPlayers(ctx context.Context, ids []uuid.UUID) ([]*model.Player, error)
+	// SearchPlayersByUsername finds players currently or formerly using the username, case-insensitively.
+	// Players currently using the username are returned first.
+	SearchPlayersByUsername(ctx context.Context, username string) ([]*model.Player, error)
+
+	// Stats, pairs and leaderboards are bucketed by season. An empty season id means all-time.
+
+	// RecordPlayerStats adds the game's results to the all-time and season stats of everyone who took part in it,
+	// returning their updated all-time stats for the game's mode. The season id is empty if the game isn't in a season.
+	RecordPlayerStats(ctx context.Context, game *model.HistoricGame, seasonId string) ([]*model.PlayerStats, error)
+	// GetPlayerStats returns the player's stats in every game mode they have played in the season
+	GetPlayerStats(ctx context.Context, playerId uuid.UUID, seasonId string) ([]*model.PlayerStats, error)
+	// GetPlayerSeasonStats returns the player's stats in every season and game mode they have played
+	GetPlayerSeasonStats(ctx context.Context, playerId uuid.UUID) ([]*model.PlayerStats, error)
+	// GetWinStreakLeaderboard returns the longest current win streaks of a game mode in the season,
+	// optionally only of players who have played since activeSince
+	GetWinStreakLeaderboard(ctx context.Context, seasonId string, gameModeId string, activeSince *time.Time,
+		limit int64) ([]*model.PlayerStats, error)
+	// GetStatsLeaderboard returns the players with the highest value of the metric in a game mode in the season
+	GetStatsLeaderboard(ctx context.Context, seasonId string, gameModeId string, metric model.StatsMetric,
+		limit int64) ([]*model.PlayerStats, error)
+	// GetSeasonGameModes returns every game mode played in the season
+	GetSeasonGameModes(ctx context.Context, seasonId string) ([]string, error)
+
+	// RecordPlayerPairs adds the game to the all-time and season head-to-head and teammate stats
+	// of every pair of players in it
+	RecordPlayerPairs(ctx context.Context, game *model.HistoricGame, seasonId string) error
+	// GetHeadToHead returns the player's stats against and alongside the other player in every game mode they played together
+	GetHeadToHead(ctx context.Context, playerId uuid.UUID, otherId uuid.UUID, seasonId string) ([]*model.PlayerPairStats, error)
+	// GetPlayerPairs returns the player's stats with every player they have played with, per game mode
+	GetPlayerPairs(ctx context.Context, playerId uuid.UUID, seasonId string) ([]*model.PlayerPairStats, error)
+	// GetTopPlayerPairs returns the player's top rivals or partners with at least minGames games of that kind,
+	// optionally limited to a game mode. Without a game mode the pairs are summed across every game mode.
+	// Rivals are ranked by games against, partners by win rate together.
+	GetTopPlayerPairs(ctx context.Context, playerId uuid.UUID, seasonId string, gameModeId string, kind model.PlayerPairKind,
+		minGames int64, limit int64) ([]*model.PlayerPairStats, error)
+
+	// RecordPlayerRatings updates the all-time and season ratings of everyone who took part in the game in its mode,
+	// returning their updated all-time ratings. Games without a result don't change any rating.
+	RecordPlayerRatings(ctx context.Context, game *model.HistoricGame, seasonId string) ([]*model.PlayerRating, error)
+	// GetPlayerRatings returns the player's all-time and season ratings in every game mode they have played
+	GetPlayerRatings(ctx context.Context, playerId uuid.UUID) ([]*model.PlayerRating, error)
+	// GetRatingLeaderboard returns the highest rated players of a game mode in the season with at least minGames rated games
+	GetRatingLeaderboard(ctx context.Context, seasonId string, gameModeId string, minGames int64,
+		limit int64) ([]*model.PlayerRating, error)
+
+	// RecordLeaderboardScores adds the game's results to the current daily, weekly, monthly, season and all-time
+	// leaderboards of its game mode. The season leaderboard is skipped if the season id is empty.
+	RecordLeaderboardScores(ctx context.Context, game *model.HistoricGame, seasonId string) error
+	// GetLeaderboard returns a page of the leaderboard's scores in rank order
+	GetLeaderboard(ctx context.Context, key model.LeaderboardKey, offset int64, limit int64) ([]*model.LeaderboardScore, error)
+	// CountLeaderboard returns the number of players on the leaderboard
+	CountLeaderboard(ctx context.Context, key model.LeaderboardKey) (int64, error)
+	// GetLeaderboardRank returns the player's score and 1-based rank, or ErrNotFound if they aren't on the leaderboard
+	GetLeaderboardRank(ctx context.Context, key model.LeaderboardKey, playerId uuid.UUID) (*model.LeaderboardScore, int64, error)
+
+	// Recomputes rebuild the derived collections (player stats, pairs, leaderboard scores and ratings) from historic
+	// games into separate collections, then swap them in. Each game is claimed in the transaction that applies it,
+	// so it is applied exactly once, either by the recompute or by the consumer finishing it.
+
+	// StartRecompute discards any previous recompute's collections, claims and checkpoint, then creates empty
+	// collections and saves the checkpoint. Recomputes require transactions.
+	StartRecompute(ctx context.Context, checkpoint *model.RecomputeCheckpoint) error
+	// GetRecomputeCheckpoint returns ErrNotFound if no recompute is in progress
+	GetRecomputeCheckpoint(ctx context.Context) (*model.RecomputeCheckpoint, error)
+	// SaveRecomputeCheckpoint replaces the checkpoint, returning ErrNotFound if the recompute has been discarded
+	SaveRecomputeCheckpoint(ctx context.Context, checkpoint *model.RecomputeCheckpoint) error
+	// ListHistoricGamesAfter returns the games that finished after the given game, in the order they finished
+	ListHistoricGamesAfter(ctx context.Context, endTime time.Time, id primitive.ObjectID, limit int64) ([]*model.HistoricGame, error)
+	// ListUnclaimedRecomputeGames returns the ids of the historic games the recompute hasn't claimed, in id order
+	ListUnclaimedRecomputeGames(ctx context.Context) ([]primitive.ObjectID, error)
+	// ClaimRecomputeGame claims the game for the recompute collections, returning false if it was already claimed
+	ClaimRecomputeGame(ctx context.Context, gameId primitive.ObjectID) (bool, error)
+	// RecomputeTarget returns a repository that records derived data into the recompute collections
+	RecomputeTarget() Repository
+	// SwapRecompute replaces each derived collection with its recompute collection, then deletes the claims and
+	// checkpoint. Each collection is swapped atomically, and collections already swapped by an interrupted swap
+	// are skipped.
+	SwapRecompute(ctx context.Context) error
+
+	// SaveSeasonSnapshot saves the hall of fame of a season, replacing any previous snapshot
+	SaveSeasonSnapshot(ctx context.Context, snapshot *model.SeasonSnapshot) error
+	// GetSeasonSnapshot returns ErrNotFound if the season hasn't been snapshotted
+	GetSeasonSnapshot(ctx context.Context, seasonId string) (*model.SeasonSnapshot, error)
+
+	// UnlockAchievements saves the achievements, returning those the players didn't already have
+	UnlockAchievements(ctx context.Context, achievements []*model.PlayerAchievement) ([]*model.PlayerAchievement, error)
+	// GetPlayerAchievements returns the player's achievements, oldest first
+	GetPlayerAchievements(ctx context.Context, playerId uuid.UUID) ([]*model.PlayerAchievement, error)
+
+	// StreamHistoricGames calls fn with every raw historic game matching the filter, oldest first
+	StreamHistoricGames(ctx context.Context, filter HistoricGameFilter, fn func(raw bson.Raw) error) error
+	// AggregateHistoricGames computes the stats of the games matching the filter so they can be kept once archived
+	AggregateHistoricGames(ctx context.Context, filter HistoricGameFilter) (*model.ArchivedAggregates, error)
+	DeleteHistoricGames(ctx context.Context, ids []primitive.ObjectID) (int64, error)
+	// RestoreHistoricGames upserts raw historic games, returning the number inserted or changed
+	RestoreHistoricGames(ctx context.Context, games []bson.Raw) (int64, error)
+	// ImportHistoricGames inserts a batch of games, handling games that already exist according to the mode.
+	// In DuplicateFail mode an error wrapping ErrDuplicateGame is returned with the games imported before the duplicate.
+	ImportHistoricGames(ctx context.Context, games []*model.HistoricGame, mode DuplicateMode) (*ImportResult, error)
+
+	SaveArchive(ctx context.Context, archive *model.Archive) error
+	// GetArchives returns the archives of the game mode containing games that ended in the range, oldest first.
+	// All parameters are optional.
+	GetArchives(ctx context.Context, gameModeId string, from *time.Time, to *time.Time) ([]*model.Archive, error)
+	DeleteArchive(ctx context.Context, id primitive.ObjectID) error
+
+	// ErasePlayer pseudonymises the player in every stored game, scrubbing their username,
+	// deletes all data derived from their games and saves an audit of what was changed.
+	// The audit is recorded by the player id's HashPlayerId hash, and a repeat erasure reuses the same pseudonym.
+	ErasePlayer(ctx context.Context, playerId uuid.UUID, playerIdHash string, requestedBy string) (*model.ErasureAudit, error)
+	// GetErasedPlayers returns the pseudonyms of the erased players among the player id hashes, keyed by hash
+	GetErasedPlayers(ctx context.Context, playerIdHashes []string) (map[string]uuid.UUID, error)
+	// HasErasures returns true if any player has been erased
+	HasErasures(ctx context.Context) (bool, error)
+
+	// SaveOutboxEvents queues events that aren't saved with a game
+	SaveOutboxEvents(ctx context.Context, events []*model.OutboxEvent) error
+	// GetUnsentOutboxEvents returns the oldest events that haven't been published, in the order they were queued
+	GetUnsentOutboxEvents(ctx context.Context, limit int64) ([]*model.OutboxEvent, error)
+	MarkOutboxEventsSent(ctx context.Context, ids []primitive.ObjectID, sentAt time.Time) error
+	// RecordOutboxFailure increments the attempts of events that failed to publish
+	RecordOutboxFailure(ctx context.Context, ids []primitive.ObjectID, reason string) error
+
+	SaveWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscription) error
+	GetWebhookSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error)
+	// DeleteWebhookSubscription returns ErrNotFound if no subscription has the id
+	DeleteWebhookSubscription(ctx context.Context, id primitive.ObjectID) error
+	// ClaimWebhookDelivery returns the pending delivery that has been due the longest, or nil if none are due.
+	// The delivery isn't due again until the lease expires, so it is only attempted by one tracker replica at a time.
+	ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	// RecordWebhookAttempt saves an attempt and the resulting status.
+	// A nil nextAttemptAt completes the delivery, otherwise it is retried then.
+	RecordWebhookAttempt(ctx context.Context, id primitive.ObjectID, attempt *model.WebhookAttempt,
+		status model.WebhookDeliveryStatus, nextAttemptAt *time.Time) error
+	// GetWebhookDeliveries returns the most recent deliveries of a subscription
+	GetWebhookDeliveries(ctx context.Context, subscriptionId primitive.ObjectID, limit int64) ([]*model.WebhookDelivery, error)
+
+	// WatchGames calls fn with every live game saved and every live game finished, from any tracker replica,
+	// until the context is cancelled or the change stream fails. Historic games inserted without finishing
+	// a live game, such as imports and archive restores, are left out. Change streams require MongoDB to run as a replica set.
+	WatchGames(ctx context.Context, fn func(change *GameChange)) error
+
+	// MigrateGames upgrades every stored game with an outdated schema version in batches, returning the number migrated.
+	MigrateGames(ctx context.Context, batchSize int) (int, error)
+}
+
+// GameChange is a saved live game or a newly finished historic game. Exactly one of the games is set.
+type GameChange struct {
+	// Created is true if the live game was saved for the first time
+	Created  bool
+	LiveGame *model.LiveGame
+	// Finished is true if the historic game was saved along with deleting its live game
+	Finished     bool
+	HistoricGame *model.HistoricGame
+}
+
+// DuplicateMode is how an import handles games with the same id as a stored game
+type DuplicateMode string
+
+const (
+	DuplicateUpsert DuplicateMode = "upsert"
+	DuplicateSkip   DuplicateMode = "skip"
+	DuplicateFail   DuplicateMode = "fail"
+)
+
+type ImportResult struct {
+	Inserted int64
+	Updated  int64
+	Skipped  int64
+}
+
+// LiveGameFilter narrows down live game queries. Zero value fields are ignored.
+type LiveGameFilter struct {
+	GameModeId string
+	ServerId   string
+	PlayerId   uuid.UUID
+}
+
+// HistoricGameFilter narrows down historic game queries. Zero value fields are ignored.
+type HistoricGameFilter struct {
+	GameModeId string
+	MapId      string
+	// PlayerId matches games the player took part in, including those they left early
+	PlayerId uuid.UUID
+	// LeftEarlyPlayerId matches games the player quit before they finished
+	LeftEarlyPlayerId uuid.UUID
+
+	// From and To bound the game's end time (inclusive, exclusive)
+	From *time.Time
+	To   *time.Time
 }
@@ -0,0 +1,236 @@
+package seasons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+const (
+	// HallOfFameSize is the number of players kept on each leaderboard of a season snapshot
+	HallOfFameSize = 10
+	// HallOfFameRatingMinGames is the number of rated games in the season a player needs for their rating to be kept,
+	// the same as the rating leaderboard's default
+	HallOfFameRatingMinGames = 10
+
+	// snapshotDelay gives games that finished just before the season ended time to be consumed
+	snapshotDelay = 15 * time.Minute
+	checkInterval = 5 * time.Minute
+)
+
+// Rollover saves the hall of fame of each season once it has ended. The season's stats are kept,
+// so a snapshot can be taken again at any time and replaces the previous one.
+type Rollover struct {
+	logger  *zap.SugaredLogger
+	repo    repository.Repository
+	seasons config.Seasons
+}
+
+func NewRollover(logger *zap.SugaredLogger, repo repository.Repository, seasons config.Seasons) *Rollover {
+	return &Rollover{
+		logger:  logger,
+		repo:    repo,
+		seasons: seasons,
+	}
+}
+
+// Start periodically snapshots the seasons that have ended but don't have a snapshot yet,
+// stopping when the context is cancelled
+func (r *Rollover) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := r.snapshotEnded(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				r.logger.Errorw("failed to snapshot ended seasons", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *Rollover) snapshotEnded(ctx context.Context, now time.Time) error {
+	for _, season := range r.seasons {
+		if now.Before(season.End.Add(snapshotDelay)) {
+			continue
+		}
+
+		_, err := r.repo.GetSeasonSnapshot(ctx, season.Id)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+
+		snapshot, err := r.Snapshot(ctx, season, now)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot season %s: %w", season.Id, err)
+		}
+		r.logger.Infow("saved season snapshot", "seasonId", season.Id, "gameModes", len(snapshot.GameModes),
+			"players", len(snapshot.PlayerIds))
+	}
+
+	return nil
+}
+
+// Snapshot saves the season's top players of every stats metric, and the highest season ratings,
+// in every game mode played during it. Players with equal values share a rank.
+func (r *Rollover) Snapshot(ctx context.Context, season *config.Season, now time.Time) (*model.SeasonSnapshot, error) {
+	gameModeIds, err := r.repo.GetSeasonGameModes(ctx, season.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &model.SeasonSnapshot{
+		SeasonId:  season.Id,
+		Start:     season.Start,
+		End:       season.End,
+		CreatedAt: now,
+		GameModes: make([]*model.SeasonGameModeSnapshot, 0, len(gameModeIds)),
+		PlayerIds: make([]uuid.UUID, 0),
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, gameModeId := range gameModeIds {
+		gameMode := &model.SeasonGameModeSnapshot{GameModeId: gameModeId}
+
+		for _, metric := range model.StatsMetrics {
+			stats, err := r.repo.GetStatsLeaderboard(ctx, season.Id, gameModeId, metric, HallOfFameSize)
+			if err != nil {
+				return nil, err
+			}
+
+			leaderboard := leaderboardFromStats(metric, stats)
+			if len(leaderboard.Entries) == 0 {
+				continue
+			}
+			gameMode.Leaderboards = append(gameMode.Leaderboards, leaderboard)
+
+			for _, e := range leaderboard.Entries {
+				if !seen[e.PlayerId] {
+					seen[e.PlayerId] = true
+					snapshot.PlayerIds = append(snapshot.PlayerIds, e.PlayerId)
+				}
+			}
+		}
+
+		ratings, err := r.repo.GetRatingLeaderboard(ctx, season.Id, gameModeId, HallOfFameRatingMinGames, HallOfFameSize)
+		if err != nil {
+			return nil, err
+		}
+
+		gameMode.Ratings = ratingEntries(ratings)
+		for _, e := range gameMode.Ratings {
+			if !seen[e.PlayerId] {
+				seen[e.PlayerId] = true
+				snapshot.PlayerIds = append(snapshot.PlayerIds, e.PlayerId)
+			}
+		}
+
+		if len(gameMode.Leaderboards) > 0 || len(gameMode.Ratings) > 0 {
+			snapshot.GameModes = append(snapshot.GameModes, gameMode)
+		}
+	}
+
+	if err := r.fillUsernames(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	if err := r.repo.SaveSeasonSnapshot(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// leaderboardFromStats ranks the stats, leaving out players who never scored in the metric
+func leaderboardFromStats(metric model.StatsMetric, stats []*model.PlayerStats) *model.SeasonLeaderboard {
+	leaderboard := &model.SeasonLeaderboard{Metric: metric}
+
+	for i, s := range stats {
+		value := metric.Value(s)
+		if value <= 0 {
+			break
+		}
+
+		rank := i + 1
+		if i > 0 {
+			if previous := leaderboard.Entries[i-1]; previous.Value == value {
+				rank = previous.Rank
+			}
+		}
+
+		leaderboard.Entries = append(leaderboard.Entries, &model.SeasonLeaderboardEntry{
+			Rank:     rank,
+			PlayerId: s.PlayerId,
+			Value:    value,
+		})
+	}
+
+	return leaderboard
+}
+
+// ratingEntries ranks the ratings, which are ordered highest first
+func ratingEntries(ratings []*model.PlayerRating) []*model.SeasonRatingEntry {
+	entries := make([]*model.SeasonRatingEntry, len(ratings))
+
+	for i, rating := range ratings {
+		rank := i + 1
+		if i > 0 && entries[i-1].Rating == rating.Rating {
+			rank = entries[i-1].Rank
+		}
+
+		entries[i] = &model.SeasonRatingEntry{
+			Rank:       rank,
+			PlayerId:   rating.PlayerId,
+			Rating:     rating.Rating,
+			PeakRating: rating.PeakRating,
+			Games:      rating.Games,
+		}
+	}
+
+	return entries
+}
+
+func (r *Rollover) fillUsernames(ctx context.Context, snapshot *model.SeasonSnapshot) error {
+	players, err := r.repo.GetPlayers(ctx, snapshot.PlayerIds)
+	if err != nil {
+		return fmt.Errorf("failed to get usernames: %w", err)
+	}
+
+	usernames := make(map[uuid.UUID]string, len(players))
+	for _, p := range players {
+		usernames[p.Id] = p.Username
+	}
+
+	for _, g := range snapshot.GameModes {
+		for _, l := range g.Leaderboards {
+			for _, e := range l.Entries {
+				e.Username = usernames[e.PlayerId]
+			}
+		}
+
+		for _, e := range g.Ratings {
+			e.Username = usernames[e.PlayerId]
+		}
+	}
+
+	return nil
+}
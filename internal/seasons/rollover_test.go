@@ -0,0 +1,168 @@
+package seasons
+
+import (
+	"context"
+	"game-tracker/internal/config"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"slices"
+	"testing"
+	"time"
+)
+
+// snapshotRepo serves fixed season stats and ratings and keeps the saved snapshot.
+// Other repository methods aren't used by the tests.
+type snapshotRepo struct {
+	repository.Repository
+
+	wins    map[string][]*model.PlayerStats
+	ratings map[string][]*model.PlayerRating
+	players []*model.Player
+	saved   *model.SeasonSnapshot
+}
+
+func (r *snapshotRepo) GetSeasonGameModes(_ context.Context, _ string) ([]string, error) {
+	var gameModeIds []string
+	for id := range r.wins {
+		gameModeIds = append(gameModeIds, id)
+	}
+	for id := range r.ratings {
+		if !slices.Contains(gameModeIds, id) {
+			gameModeIds = append(gameModeIds, id)
+		}
+	}
+	slices.Sort(gameModeIds)
+	return gameModeIds, nil
+}
+
+func (r *snapshotRepo) GetStatsLeaderboard(_ context.Context, _ string, gameModeId string, metric model.StatsMetric,
+	_ int64) ([]*model.PlayerStats, error) {
+
+	if metric != model.StatsMetricWins {
+		return nil, nil
+	}
+	return r.wins[gameModeId], nil
+}
+
+func (r *snapshotRepo) GetRatingLeaderboard(_ context.Context, _ string, gameModeId string, minGames int64,
+	_ int64) ([]*model.PlayerRating, error) {
+
+	var ratings []*model.PlayerRating
+	for _, rating := range r.ratings[gameModeId] {
+		if rating.Games >= minGames {
+			ratings = append(ratings, rating)
+		}
+	}
+	return ratings, nil
+}
+
+func (r *snapshotRepo) GetPlayers(_ context.Context, _ []uuid.UUID) ([]*model.Player, error) {
+	return r.players, nil
+}
+
+func (r *snapshotRepo) SaveSeasonSnapshot(_ context.Context, snapshot *model.SeasonSnapshot) error {
+	r.saved = snapshot
+	return nil
+}
+
+func TestSnapshot(t *testing.T) {
+	a := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	b := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	c := uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	season := &config.Season{Id: "s1", Start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		End: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}
+
+	type entry struct {
+		rank     int
+		playerId uuid.UUID
+		username string
+	}
+
+	tests := []struct {
+		name        string
+		repo        *snapshotRepo
+		wantModes   []string
+		wantWins    []entry
+		wantRatings []entry
+		wantPlayers []uuid.UUID
+	}{
+		{
+			name: "stats and ratings",
+			repo: &snapshotRepo{
+				wins: map[string][]*model.PlayerStats{"block-sumo": {
+					{PlayerId: a, Wins: 5}, {PlayerId: b, Wins: 5}, {PlayerId: c, Wins: 0},
+				}},
+				ratings: map[string][]*model.PlayerRating{"block-sumo": {
+					{PlayerId: c, Rating: 1100, Games: 12}, {PlayerId: a, Rating: 1050, Games: 30},
+				}},
+				players: []*model.Player{{Id: a, Username: "alice"}, {Id: c, Username: "carol"}},
+			},
+			wantModes:   []string{"block-sumo"},
+			wantWins:    []entry{{1, a, "alice"}, {1, b, ""}},
+			wantRatings: []entry{{1, c, "carol"}, {2, a, "alice"}},
+			wantPlayers: []uuid.UUID{a, b, c},
+		},
+		{
+			name: "too few rated games",
+			repo: &snapshotRepo{
+				ratings: map[string][]*model.PlayerRating{"tower-defence": {{PlayerId: a, Rating: 1200, Games: 3}}},
+			},
+			wantPlayers: []uuid.UUID{},
+		},
+		{
+			name: "ratings only",
+			repo: &snapshotRepo{
+				ratings: map[string][]*model.PlayerRating{"tower-defence": {
+					{PlayerId: a, Rating: 1010, Games: HallOfFameRatingMinGames},
+					{PlayerId: b, Rating: 1010, Games: HallOfFameRatingMinGames},
+				}},
+			},
+			wantModes:   []string{"tower-defence"},
+			wantRatings: []entry{{1, a, ""}, {1, b, ""}},
+			wantPlayers: []uuid.UUID{a, b},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRollover(zap.NewNop().Sugar(), tt.repo, config.Seasons{season})
+
+			snapshot, err := r.Snapshot(context.Background(), season, season.End)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.repo.saved != snapshot {
+				t.Error("snapshot wasn't saved")
+			}
+
+			var modes []string
+			var wins, ratings []entry
+			for _, g := range snapshot.GameModes {
+				modes = append(modes, g.GameModeId)
+				for _, l := range g.Leaderboards {
+					for _, e := range l.Entries {
+						wins = append(wins, entry{e.Rank, e.PlayerId, e.Username})
+					}
+				}
+				for _, e := range g.Ratings {
+					ratings = append(ratings, entry{e.Rank, e.PlayerId, e.Username})
+				}
+			}
+
+			if !slices.Equal(modes, tt.wantModes) {
+				t.Errorf("game modes = %v, want %v", modes, tt.wantModes)
+			}
+			if !slices.Equal(wins, tt.wantWins) {
+				t.Errorf("wins = %v, want %v", wins, tt.wantWins)
+			}
+			if !slices.Equal(ratings, tt.wantRatings) {
+				t.Errorf("ratings = %v, want %v", ratings, tt.wantRatings)
+			}
+			if !slices.Equal(snapshot.PlayerIds, tt.wantPlayers) {
+				t.Errorf("player ids = %v, want %v", snapshot.PlayerIds, tt.wantPlayers)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"game-tracker/internal/config"
+	"game-tracker/internal/erasure"
+	"game-tracker/internal/export"
+	pbservice "game-tracker/internal/gen/grpc/gametracker"
+	"game-tracker/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminService serves GameTrackerAdmin, the operations staff tooling performs on behalf of players
+type adminService struct {
+	pbservice.UnimplementedGameTrackerAdminServer
+
+	logger  *zap.SugaredLogger
+	repo    repository.Repository
+	erasure config.ErasureConfig
+}
+
+func newAdminService(logger *zap.SugaredLogger, repo repository.Repository, erasureCfg config.ErasureConfig) *adminService {
+	return &adminService{
+		logger:  logger,
+		repo:    repo,
+		erasure: erasureCfg,
+	}
+}
+
+// ErasePlayer erases the player like the erase-player command. The pseudonym isn't returned
+// so the response can't be used to link the player to their pseudonymised games.
+func (s *adminService) ErasePlayer(ctx context.Context, req *pbservice.ErasePlayerRequest) (*pbservice.ErasePlayerResponse, error) {
+	playerId, err := uuid.Parse(req.PlayerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid player id")
+	}
+	if req.RequestedBy == "" {
+		return nil, status.Error(codes.InvalidArgument, "requested_by is required for the audit record")
+	}
+
+	audit, err := erasure.ErasePlayer(ctx, s.repo, s.erasure, playerId, req.RequestedBy)
+	if err != nil {
+		return nil, statusError(s.logger, err, "failed to erase player")
+	}
+
+	s.logger.Infow("erased player", "auditId", audit.Id.Hex(), "requestedBy", audit.RequestedBy,
+		"liveGames", len(audit.LiveGameIds), "historicGames", len(audit.HistoricGameIds),
+		"archives", len(audit.ArchivePaths), "deletedPlayerRecords", audit.DeletedPlayerRecords,
+		"seasonSnapshots", audit.SeasonIds, "outboxEvents", audit.OutboxEvents)
+
+	return &pbservice.ErasePlayerResponse{
+		AuditId:              audit.Id.Hex(),
+		LiveGames:            int32(len(audit.LiveGameIds)),
+		HistoricGames:        int32(len(audit.HistoricGameIds)),
+		Archives:             int32(len(audit.ArchivePaths)),
+		DeletedPlayerRecords: audit.DeletedPlayerRecords,
+		OutboxEvents:         audit.OutboxEvents,
+		SeasonIds:            audit.SeasonIds,
+	}, nil
+}
+
+// exportChunkSize is the size of the export chunks, well below grpc's default 4 MiB message limit
+const exportChunkSize = 64 * 1024
+
+// ExportPlayer streams the player's bundle like the export-player command
+func (s *adminService) ExportPlayer(req *pbservice.ExportPlayerRequest, stream pbservice.GameTrackerAdmin_ExportPlayerServer) error {
+	playerId, err := uuid.Parse(req.PlayerId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid player id")
+	}
+
+	bundle, err := export.BuildPlayerBundle(stream.Context(), s.repo, playerId)
+	if err != nil {
+		return statusError(s.logger, err, "failed to build player export")
+	}
+
+	w := &chunkWriter{send: func(chunk []byte) error {
+		// grpc may still read the message after sending it
+		return stream.Send(&pbservice.ExportPlayerResponse{Chunk: bytes.Clone(chunk)})
+	}}
+	if err := export.WriteBundle(w, bundle, req.Zip); err != nil {
+		return statusError(s.logger, err, "failed to write player export")
+	}
+	if err := w.flush(); err != nil {
+		return statusError(s.logger, err, "failed to send player export")
+	}
+
+	s.logger.Infow("exported player", "games", len(bundle.Games), "zip", req.Zip)
+	return nil
+}
+
+// chunkWriter sends what is written to it in chunks of exportChunkSize
+type chunkWriter struct {
+	send func(chunk []byte) error
+	buf  []byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		size := min(exportChunkSize-len(w.buf), len(p))
+		w.buf = append(w.buf, p[:size]...)
+		p = p[size:]
+
+		if len(w.buf) == exportChunkSize {
+			if err := w.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// flush sends what hasn't been sent yet. The buffer is reused, so send must not keep the chunk.
+func (w *chunkWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	err := w.send(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
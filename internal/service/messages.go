@@ -0,0 +1,166 @@
+package service
+
+import (
+	"game-tracker/internal/export"
+	pbservice "game-tracker/internal/gen/grpc/gametracker"
+	"game-tracker/internal/summary"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// The game messages are built from the export records, so they have the same fields as the HTTP gateway's JSON
+
+func liveGameMessage(r *export.LiveGameRecord) *pbservice.LiveGame {
+	return &pbservice.LiveGame{
+		Id:          r.Id,
+		GameModeId:  r.GameModeId,
+		MapId:       optionalString(r.MapId),
+		ServerId:    r.ServerId,
+		StartTime:   optionalTimestamp(r.StartTime),
+		LastUpdated: timestamppb.New(r.LastUpdated),
+
+		Players: playerMessages(r.Players),
+		Teams:   teamMessages(r.Teams),
+
+		TowerDefence: towerDefenceMessage(r.TowerDefence),
+		BlockSumo:    blockSumoMessage(r.BlockSumo),
+	}
+}
+
+func historicGameMessage(r *export.GameRecord) *pbservice.HistoricGame {
+	m := &pbservice.HistoricGame{
+		Id:             r.Id,
+		GameModeId:     r.GameModeId,
+		MapId:          optionalString(r.MapId),
+		ServerId:       r.ServerId,
+		StartTime:      optionalTimestamp(r.StartTime),
+		EndTime:        timestamppb.New(r.EndTime),
+		DurationMillis: r.DurationMillis,
+
+		Players: playerMessages(r.Players),
+		Teams:   teamMessages(r.Teams),
+
+		WinnerIds:     r.WinnerIds,
+		LoserIds:      r.LoserIds,
+		WinningTeamId: optionalString(r.WinningTeamId),
+
+		TowerDefence: towerDefenceMessage(r.TowerDefence),
+		BlockSumo:    blockSumoMessage(r.BlockSumo),
+	}
+
+	for _, p := range r.Participation {
+		m.Participation = append(m.Participation, &pbservice.Participation{
+			PlayerId:         p.PlayerId,
+			Username:         p.Username,
+			FirstJoinTime:    timestamppb.New(p.FirstJoinTime),
+			LastLeaveTime:    timestamppb.New(p.LastLeaveTime),
+			TimeInGameMillis: p.TimeInGameMillis,
+			LeftEarly:        p.LeftEarly,
+		})
+	}
+
+	return m
+}
+
+func playerMessages(players []*export.PlayerRecord) []*pbservice.Player {
+	messages := make([]*pbservice.Player, len(players))
+	for i, p := range players {
+		messages[i] = &pbservice.Player{Id: p.Id, Username: p.Username}
+	}
+	return messages
+}
+
+func teamMessages(teams []*export.TeamRecord) []*pbservice.Team {
+	messages := make([]*pbservice.Team, len(teams))
+	for i, t := range teams {
+		messages[i] = &pbservice.Team{Id: t.Id, FriendlyName: t.FriendlyName, Color: t.Color, PlayerIds: t.PlayerIds}
+	}
+	return messages
+}
+
+func towerDefenceMessage(r *export.TowerDefenceRecord) *pbservice.TowerDefence {
+	if r == nil {
+		return nil
+	}
+	return &pbservice.TowerDefence{MaxHealth: r.MaxHealth, RedHealth: r.RedHealth, BlueHealth: r.BlueHealth}
+}
+
+func blockSumoMessage(r *export.BlockSumoRecord) *pbservice.BlockSumo {
+	if r == nil {
+		return nil
+	}
+
+	m := &pbservice.BlockSumo{Scoreboard: make(map[string]*pbservice.BlockSumoEntry, len(r.Scoreboard))}
+	for id, e := range r.Scoreboard {
+		m.Scoreboard[id] = &pbservice.BlockSumoEntry{RemainingLives: e.RemainingLives, Kills: e.Kills, FinalKills: e.FinalKills}
+	}
+	return m
+}
+
+// optionalString returns nil for the empty string, which the records leave out of their JSON
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func playerPairMessage(r *export.PlayerPairRecord) *pbservice.PlayerPair {
+	return &pbservice.PlayerPair{
+		PlayerId:   r.PlayerId,
+		OtherId:    r.OtherId,
+		GameModeId: optionalString(r.GameModeId),
+		SeasonId:   optionalString(r.SeasonId),
+
+		GamesAgainst:  r.GamesAgainst,
+		WinsAgainst:   r.WinsAgainst,
+		LossesAgainst: r.LossesAgainst,
+
+		GamesWith:   r.GamesWith,
+		WinsWith:    r.WinsWith,
+		LossesWith:  r.LossesWith,
+		WinRateWith: r.WinRateWith,
+
+		LastPlayed: timestamppb.New(r.LastPlayed),
+	}
+}
+
+func gameSummaryMessage(s *summary.Summary) *pbservice.GameSummary {
+	m := &pbservice.GameSummary{
+		GameId:     s.GameId,
+		GameModeId: s.GameModeId,
+		MapId:      optionalString(s.MapId),
+		ServerId:   s.ServerId,
+		EndTime:    timestamppb.New(s.EndTime),
+
+		PlayerCount: int32(s.PlayerCount),
+		Winners:     s.Winners,
+		WinningTeam: optionalString(s.WinningTeam),
+	}
+	if s.DurationMillis > 0 {
+		m.DurationMillis = &s.DurationMillis
+	}
+	if s.WinningTeam != "" {
+		m.WinningTeamColor = &s.WinningTeamColor
+	}
+
+	for _, k := range s.TopKillers {
+		m.TopKillers = append(m.TopKillers, &pbservice.GameSummaryKiller{Username: k.Username, Kills: k.Kills, FinalKills: k.FinalKills})
+	}
+	if s.TowerDefence != nil {
+		m.TowerDefence = &pbservice.TowerDefence{
+			MaxHealth:  s.TowerDefence.MaxHealth,
+			RedHealth:  s.TowerDefence.RedHealth,
+			BlueHealth: s.TowerDefence.BlueHealth,
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"game-tracker/internal/config"
+	"game-tracker/internal/export"
+	pbservice "game-tracker/internal/gen/grpc/gametracker"
+	"game-tracker/internal/live"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"game-tracker/internal/summary"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// queryService serves GameTrackerQuery
+type queryService struct {
+	pbservice.UnimplementedGameTrackerQueryServer
+
+	logger  *zap.SugaredLogger
+	repo    repository.Repository
+	hub     *live.Hub
+	seasons config.Seasons
+}
+
+func newQueryService(logger *zap.SugaredLogger, repo repository.Repository, hub *live.Hub,
+	seasons config.Seasons) *queryService {
+
+	return &queryService{
+		logger:  logger,
+		repo:    repo,
+		hub:     hub,
+		seasons: seasons,
+	}
+}
+
+var liveGameEventTypes = map[live.EventType]pbservice.LiveGameEventType{
+	live.EventCreated:  pbservice.LiveGameEventType_LIVE_GAME_EVENT_TYPE_CREATED,
+	live.EventUpdated:  pbservice.LiveGameEventType_LIVE_GAME_EVENT_TYPE_UPDATED,
+	live.EventFinished: pbservice.LiveGameEventType_LIVE_GAME_EVENT_TYPE_FINISHED,
+}
+
+// WatchLiveGames streams live games like the HTTP gateway's live game stream
+func (s *queryService) WatchLiveGames(req *pbservice.WatchLiveGamesRequest, stream pbservice.GameTrackerQuery_WatchLiveGamesServer) error {
+	ctx := stream.Context()
+
+	filter := repository.LiveGameFilter{GameModeId: req.GetGameModeId(), ServerId: req.GetServerId()}
+	if req.PlayerId != nil {
+		playerId, err := uuid.Parse(req.GetPlayerId())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid player id")
+		}
+		filter.PlayerId = playerId
+	}
+
+	// Subscribe before loading the snapshot so no change between the two is missed
+	events := s.hub.Subscribe(ctx, live.Filter{
+		GameModeId: filter.GameModeId,
+		ServerId:   filter.ServerId,
+		PlayerId:   filter.PlayerId,
+	})
+
+	games, err := s.repo.ListLiveGames(ctx, filter)
+	if err != nil {
+		return statusError(s.logger, err, "failed to list live games")
+	}
+
+	for _, g := range games {
+		err := stream.Send(&pbservice.LiveGameEvent{
+			Type: pbservice.LiveGameEventType_LIVE_GAME_EVENT_TYPE_SNAPSHOT,
+			Game: &pbservice.LiveGameEvent_LiveGame{LiveGame: liveGameMessage(export.LiveGameRecordFromModel(g))},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case e, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return status.FromContextError(ctx.Err()).Err()
+				}
+				return status.Error(codes.Aborted, "fell too far behind, reopen the stream")
+			}
+
+			res := &pbservice.LiveGameEvent{Type: liveGameEventTypes[e.Type]}
+			if e.HistoricGame != nil {
+				res.Game = &pbservice.LiveGameEvent_HistoricGame{HistoricGame: historicGameMessage(export.GameRecordFromModel(e.HistoricGame))}
+			} else {
+				res.Game = &pbservice.LiveGameEvent_LiveGame{LiveGame: liveGameMessage(export.LiveGameRecordFromModel(e.LiveGame))}
+			}
+
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkSeason returns an error if the season id is set and not a configured season
+func (s *queryService) checkSeason(seasonId string) error {
+	if seasonId != "" && s.seasons.Get(seasonId) == nil {
+		return status.Errorf(codes.InvalidArgument, "unknown season %q", seasonId)
+	}
+	return nil
+}
+
+func (s *queryService) GetHeadToHead(ctx context.Context, req *pbservice.GetHeadToHeadRequest) (*pbservice.GetHeadToHeadResponse, error) {
+	playerId, err := uuid.Parse(req.PlayerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid player id")
+	}
+	otherId, err := uuid.Parse(req.OtherId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid other player id")
+	}
+	if err := s.checkSeason(req.GetSeasonId()); err != nil {
+		return nil, err
+	}
+
+	pairs, err := s.repo.GetHeadToHead(ctx, playerId, otherId, req.GetSeasonId())
+	if err != nil {
+		return nil, statusError(s.logger, err, "failed to get head to head")
+	}
+
+	return &pbservice.GetHeadToHeadResponse{Pairs: pairMessages(pairs)}, nil
+}
+
+const (
+	defaultPlayerPairLimit = 10
+	maxPlayerPairLimit     = 100
+)
+
+var playerPairKinds = map[pbservice.PlayerPairKind]model.PlayerPairKind{
+	pbservice.PlayerPairKind_PLAYER_PAIR_KIND_RIVALS:   model.PlayerPairRivals,
+	pbservice.PlayerPairKind_PLAYER_PAIR_KIND_PARTNERS: model.PlayerPairPartners,
+}
+
+func (s *queryService) GetTopPlayerPairs(ctx context.Context, req *pbservice.GetTopPlayerPairsRequest) (*pbservice.GetTopPlayerPairsResponse, error) {
+	playerId, err := uuid.Parse(req.PlayerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid player id")
+	}
+	kind, ok := playerPairKinds[req.Kind]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "kind must be rivals or partners")
+	}
+	if err := s.checkSeason(req.GetSeasonId()); err != nil {
+		return nil, err
+	}
+
+	minGames := int64(req.GetMinGames())
+	if minGames <= 0 {
+		minGames = kind.DefaultMinGames()
+	}
+	limit := int64(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultPlayerPairLimit
+	}
+	if limit > maxPlayerPairLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be at most %d", maxPlayerPairLimit)
+	}
+
+	pairs, err := s.repo.GetTopPlayerPairs(ctx, playerId, req.GetSeasonId(), req.GetGameModeId(), kind, minGames, limit)
+	if err != nil {
+		return nil, statusError(s.logger, err, "failed to get top player pairs")
+	}
+
+	return &pbservice.GetTopPlayerPairsResponse{Pairs: pairMessages(pairs)}, nil
+}
+
+func pairMessages(pairs []*model.PlayerPairStats) []*pbservice.PlayerPair {
+	messages := make([]*pbservice.PlayerPair, len(pairs))
+	for i, p := range pairs {
+		messages[i] = playerPairMessage(export.PlayerPairRecordFromModel(p))
+	}
+	return messages
+}
+
+// GetGameSummary returns the summary in every format the HTTP gateway's summary endpoint has
+func (s *queryService) GetGameSummary(ctx context.Context, req *pbservice.GetGameSummaryRequest) (*pbservice.GetGameSummaryResponse, error) {
+	id, err := primitive.ObjectIDFromHex(req.GameId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid game id")
+	}
+
+	game, err := s.repo.GetHistoricGame(ctx, id)
+	if err != nil {
+		return nil, statusError(s.logger, err, "failed to get historic game")
+	}
+
+	sum := summary.FromGame(game)
+	payload, err := json.Marshal(sum.DiscordPayload())
+	if err != nil {
+		return nil, statusError(s.logger, err, "failed to encode discord payload")
+	}
+
+	return &pbservice.GetGameSummaryResponse{
+		Summary:        gameSummaryMessage(sum),
+		Markdown:       sum.Markdown(),
+		DiscordPayload: string(payload),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-tracker/internal/config"
+	"game-tracker/internal/erasure"
+	pbservice "game-tracker/internal/gen/grpc/gametracker"
+	"game-tracker/internal/live"
+	"game-tracker/internal/repository"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync"
+	"time"
+)
+
+// RunServices starts the gRPC services, stopping them when the context is cancelled
+func RunServices(ctx context.Context, logger *zap.SugaredLogger, wg *sync.WaitGroup, cfg config.Config,
+	repo repository.Repository, hub *live.Hub) {
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+	if err != nil {
+		logger.Fatalw("failed to listen", "error", err, "port", cfg.GRPCPort)
+	}
+
+	s := grpc.NewServer(grpc.StreamInterceptor(cancelStreams(ctx)))
+	pbservice.RegisterGameTrackerAdminServer(s, newAdminService(logger, repo, cfg.Erasure))
+	pbservice.RegisterGameTrackerQueryServer(s, newQueryService(logger, repo, hub, cfg.Seasons))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		logger.Infow("started grpc server", "port", cfg.GRPCPort)
+		if err := s.Serve(lis); err != nil {
+			logger.Errorw("grpc server stopped", "error", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		stopped := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(10 * time.Second):
+			logger.Warnw("grpc server didn't stop in time, closing remaining calls")
+			s.Stop()
+		}
+	}()
+}
+
+// cancelStreams cancels the context of open streams when ctx is, so they don't hold up stopping the server
+func cancelStreams(ctx context.Context) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		streamCtx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+		stop := context.AfterFunc(ctx, cancel)
+		defer stop()
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: streamCtx})
+	}
+}
+
+// serverStream is a stream with a different context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// statusError returns the error to respond with, logging it if it's unexpected
+func statusError(logger *zap.SugaredLogger, err error, msg string) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, erasure.ErrNoSecret):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	logger.Errorw(msg, "error", err)
+	return status.Error(codes.Internal, msg)
+}
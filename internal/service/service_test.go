@@ -0,0 +1,440 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"game-tracker/internal/config"
+	pbservice "game-tracker/internal/gen/grpc/gametracker"
+	"game-tracker/internal/live"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dial serves the services registered by register over an in-memory connection
+func dial(t *testing.T, register func(s *grpc.Server)) *grpc.ClientConn {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	register(s)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func dialQuery(t *testing.T, repo repository.Repository, hub *live.Hub, seasons config.Seasons) pbservice.GameTrackerQueryClient {
+	conn := dial(t, func(s *grpc.Server) {
+		pbservice.RegisterGameTrackerQueryServer(s, newQueryService(zap.NewNop().Sugar(), repo, hub, seasons))
+	})
+	return pbservice.NewGameTrackerQueryClient(conn)
+}
+
+func TestErasePlayer(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		req    *pbservice.ErasePlayerRequest
+		want   codes.Code
+	}{
+		{
+			name:   "invalid player id",
+			secret: "secret",
+			req:    &pbservice.ErasePlayerRequest{PlayerId: "not-a-uuid", RequestedBy: "ticket-1"},
+			want:   codes.InvalidArgument,
+		},
+		{
+			name:   "missing requested by",
+			secret: "secret",
+			req:    &pbservice.ErasePlayerRequest{PlayerId: uuid.NewString()},
+			want:   codes.InvalidArgument,
+		},
+		{
+			name: "no secret",
+			req:  &pbservice.ErasePlayerRequest{PlayerId: uuid.NewString(), RequestedBy: "ticket-1"},
+			want: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dial(t, func(s *grpc.Server) {
+				pbservice.RegisterGameTrackerAdminServer(s,
+					newAdminService(zap.NewNop().Sugar(), nil, config.ErasureConfig{Secret: tt.secret}))
+			})
+
+			_, err := pbservice.NewGameTrackerAdminClient(conn).ErasePlayer(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("code = %s, want %s (%v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestChunkWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []int
+		want   []int
+	}{
+		{name: "small", writes: []int{10, 20}, want: []int{30}},
+		{name: "exactly one chunk", writes: []int{exportChunkSize}, want: []int{exportChunkSize}},
+		{name: "spans chunks", writes: []int{exportChunkSize - 1, 2, exportChunkSize}, want: []int{exportChunkSize, exportChunkSize, 1}},
+		{name: "nothing", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			var sent bytes.Buffer
+			w := &chunkWriter{send: func(chunk []byte) error {
+				got = append(got, len(chunk))
+				sent.Write(chunk)
+				return nil
+			}}
+
+			var written bytes.Buffer
+			for i, n := range tt.writes {
+				p := bytes.Repeat([]byte{byte('a' + i)}, n)
+				written.Write(p)
+				if _, err := w.Write(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("chunk sizes = %v, want %v", got, tt.want)
+			}
+			if !bytes.Equal(sent.Bytes(), written.Bytes()) {
+				t.Error("sent bytes differ from written bytes")
+			}
+		})
+	}
+}
+
+// liveGameRepo lists fixed live games. Other repository methods aren't used by the tests.
+type liveGameRepo struct {
+	repository.Repository
+	games []*model.LiveGame
+}
+
+func (r *liveGameRepo) ListLiveGames(_ context.Context, filter repository.LiveGameFilter) ([]*model.LiveGame, error) {
+	var games []*model.LiveGame
+	for _, g := range r.games {
+		if filter.GameModeId == "" || g.GameModeId == filter.GameModeId {
+			games = append(games, g)
+		}
+	}
+	return games, nil
+}
+
+func TestWatchLiveGames(t *testing.T) {
+	liveGame := func(gameModeId string) *model.LiveGame {
+		return &model.LiveGame{Game: &model.Game{
+			Id:         primitive.NewObjectID(),
+			GameModeId: gameModeId,
+			Players:    []*model.BasicPlayer{{Id: uuid.New(), Username: "player"}},
+		}}
+	}
+
+	snapshot := liveGame("block-sumo")
+	repo := &liveGameRepo{games: []*model.LiveGame{snapshot, liveGame("tower-defence")}}
+	hub := live.NewHub(zap.NewNop().Sugar())
+	client := dialQuery(t, repo, hub, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchLiveGames(ctx, &pbservice.WatchLiveGamesRequest{GameModeId: proto.String("block-sumo")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recv := func() (pbservice.LiveGameEventType, string) {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if game := res.GetHistoricGame(); game != nil {
+			return res.Type, game.Id
+		}
+		return res.Type, res.GetLiveGame().GetId()
+	}
+
+	if eventType, id := recv(); eventType != pbservice.LiveGameEventType_LIVE_GAME_EVENT_TYPE_SNAPSHOT || id != snapshot.Id.Hex() {
+		t.Fatalf("first event = %s %s, want the snapshot of %s", eventType, id, snapshot.Id.Hex())
+	}
+
+	created := liveGame("block-sumo")
+	finished := &model.HistoricGame{Game: created.Game, EndTime: time.Now()}
+	tests := []struct {
+		event *live.Event
+		want  pbservice.LiveGameEventType
+		// filtered is true if the stream's filter leaves the event out
+		filtered bool
+	}{
+		{event: &live.Event{Type: live.EventCreated, LiveGame: liveGame("tower-defence")}, filtered: true},
+		{event: &live.Event{Type: live.EventCreated, LiveGame: created}, want: pbservice.LiveGameEventType_LIVE_GAME_EVENT_TYPE_CREATED},
+		{event: &live.Event{Type: live.EventFinished, HistoricGame: finished}, want: pbservice.LiveGameEventType_LIVE_GAME_EVENT_TYPE_FINISHED},
+	}
+
+	// The snapshot has been sent, so the stream is subscribed
+	for _, tt := range tests {
+		hub.Publish(tt.event)
+	}
+	for _, tt := range tests {
+		if tt.filtered {
+			continue
+		}
+		if eventType, id := recv(); eventType != tt.want || id != created.Id.Hex() {
+			t.Errorf("event = %s %s, want %s %s", eventType, id, tt.want, created.Id.Hex())
+		}
+	}
+}
+
+// playerPairRepo records the arguments of the player pair queries and returns a pair.
+// Other repository methods aren't used by the tests.
+type playerPairRepo struct {
+	repository.Repository
+
+	seasonId   string
+	gameModeId string
+	kind       model.PlayerPairKind
+	minGames   int64
+	limit      int64
+}
+
+func (r *playerPairRepo) GetHeadToHead(_ context.Context, playerId uuid.UUID, otherId uuid.UUID,
+	seasonId string) ([]*model.PlayerPairStats, error) {
+
+	r.seasonId = seasonId
+	return []*model.PlayerPairStats{{PlayerId: playerId, OtherId: otherId, GameModeId: "block-sumo", GamesAgainst: 3}}, nil
+}
+
+func (r *playerPairRepo) GetTopPlayerPairs(_ context.Context, playerId uuid.UUID, seasonId string, gameModeId string,
+	kind model.PlayerPairKind, minGames int64, limit int64) ([]*model.PlayerPairStats, error) {
+
+	r.seasonId, r.gameModeId, r.kind, r.minGames, r.limit = seasonId, gameModeId, kind, minGames, limit
+	return []*model.PlayerPairStats{{PlayerId: playerId, OtherId: uuid.New(), GamesWith: 4, WinsWith: 3}}, nil
+}
+
+func TestGetTopPlayerPairs(t *testing.T) {
+	playerId := uuid.NewString()
+	seasons := config.Seasons{{Id: "s1", Start: time.Unix(0, 0), End: time.Unix(1000, 0)}}
+	partners := pbservice.PlayerPairKind_PLAYER_PAIR_KIND_PARTNERS
+	rivals := pbservice.PlayerPairKind_PLAYER_PAIR_KIND_RIVALS
+
+	tests := []struct {
+		name string
+		req  *pbservice.GetTopPlayerPairsRequest
+		want codes.Code
+		// wantRepo is the arguments the repository is called with if the call succeeds
+		wantRepo playerPairRepo
+	}{
+		{
+			name:     "partners default",
+			req:      &pbservice.GetTopPlayerPairsRequest{PlayerId: playerId, Kind: partners},
+			wantRepo: playerPairRepo{kind: model.PlayerPairPartners, minGames: 5, limit: defaultPlayerPairLimit},
+		},
+		{
+			name:     "rivals default",
+			req:      &pbservice.GetTopPlayerPairsRequest{PlayerId: playerId, Kind: rivals},
+			wantRepo: playerPairRepo{kind: model.PlayerPairRivals, minGames: 1, limit: defaultPlayerPairLimit},
+		},
+		{
+			name: "every filter",
+			req: &pbservice.GetTopPlayerPairsRequest{PlayerId: playerId, Kind: partners,
+				GameModeId: proto.String("tower-defence"), SeasonId: proto.String("s1"), MinGames: proto.Int32(20), Limit: proto.Int32(3)},
+			wantRepo: playerPairRepo{seasonId: "s1", gameModeId: "tower-defence", kind: model.PlayerPairPartners,
+				minGames: 20, limit: 3},
+		},
+		{
+			name: "unspecified kind",
+			req:  &pbservice.GetTopPlayerPairsRequest{PlayerId: playerId},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "unknown season",
+			req:  &pbservice.GetTopPlayerPairsRequest{PlayerId: playerId, Kind: rivals, SeasonId: proto.String("s2")},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "limit too high",
+			req:  &pbservice.GetTopPlayerPairsRequest{PlayerId: playerId, Kind: rivals, Limit: proto.Int32(101)},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "invalid player id",
+			req:  &pbservice.GetTopPlayerPairsRequest{PlayerId: "not-a-uuid", Kind: rivals},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &playerPairRepo{}
+			client := dialQuery(t, repo, nil, seasons)
+
+			res, err := client.GetTopPlayerPairs(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %s, want %s (%v)", got, tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if *repo != tt.wantRepo {
+				t.Errorf("repository called with %+v, want %+v", *repo, tt.wantRepo)
+			}
+
+			if len(res.Pairs) != 1 {
+				t.Fatalf("pairs = %d, want 1", len(res.Pairs))
+			}
+			if got := res.Pairs[0].GetWinRateWith(); got != 0.75 {
+				t.Errorf("win rate with = %v, want 0.75", got)
+			}
+		})
+	}
+}
+
+func TestGetHeadToHead(t *testing.T) {
+	playerId, otherId := uuid.NewString(), uuid.NewString()
+
+	tests := []struct {
+		name string
+		req  *pbservice.GetHeadToHeadRequest
+		want codes.Code
+	}{
+		{name: "all-time", req: &pbservice.GetHeadToHeadRequest{PlayerId: playerId, OtherId: otherId}},
+		{
+			name: "invalid other id",
+			req:  &pbservice.GetHeadToHeadRequest{PlayerId: playerId, OtherId: "not-a-uuid"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "unknown season",
+			req:  &pbservice.GetHeadToHeadRequest{PlayerId: playerId, OtherId: otherId, SeasonId: proto.String("s1")},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dialQuery(t, &playerPairRepo{}, nil, nil)
+
+			res, err := client.GetHeadToHead(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %s, want %s (%v)", got, tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+
+			pair := res.Pairs[0]
+			if pair.OtherId != otherId {
+				t.Errorf("other id = %s, want %s", pair.OtherId, otherId)
+			}
+			if pair.GamesAgainst != 3 {
+				t.Errorf("games against = %d, want 3", pair.GamesAgainst)
+			}
+		})
+	}
+}
+
+// historicGameRepo returns a fixed historic game. Other repository methods aren't used by the tests.
+type historicGameRepo struct {
+	repository.Repository
+	game *model.HistoricGame
+}
+
+func (r *historicGameRepo) GetHistoricGame(_ context.Context, id primitive.ObjectID) (*model.HistoricGame, error) {
+	if id != r.game.Id {
+		return nil, repository.ErrNotFound
+	}
+	return r.game, nil
+}
+
+func TestGetGameSummary(t *testing.T) {
+	winner := uuid.New()
+	game := &model.HistoricGame{
+		Game: &model.Game{
+			Id:         primitive.NewObjectID(),
+			GameModeId: "block-sumo",
+			Players:    []*model.BasicPlayer{{Id: winner, Username: "@everyone"}, {Id: uuid.New(), Username: "loser"}},
+		},
+		EndTime:    time.Unix(1_700_000_000, 0),
+		Duration:   90 * time.Second,
+		WinnerData: &model.HistoricWinnerData{WinnerIds: []uuid.UUID{winner}},
+	}
+	client := dialQuery(t, &historicGameRepo{game: game}, nil, nil)
+
+	tests := []struct {
+		name   string
+		gameId string
+		want   codes.Code
+	}{
+		{name: "summary", gameId: game.Id.Hex()},
+		{name: "unknown game", gameId: primitive.NewObjectID().Hex(), want: codes.NotFound},
+		{name: "invalid game id", gameId: "not-an-id", want: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := client.GetGameSummary(context.Background(), &pbservice.GetGameSummaryRequest{GameId: tt.gameId})
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %s, want %s (%v)", got, tt.want, err)
+			}
+			if err != nil {
+				return
+			}
+
+			sum := res.Summary
+			if sum.PlayerCount != 2 {
+				t.Errorf("player count = %d, want 2", sum.PlayerCount)
+			}
+			if got := sum.GetDurationMillis(); got != 90_000 {
+				t.Errorf("duration = %d, want 90000", got)
+			}
+			if !slices.Equal(sum.Winners, []string{"@everyone"}) {
+				t.Errorf("winners = %v, want [@everyone]", sum.Winners)
+			}
+
+			if !strings.Contains(res.Markdown, "Winners: @everyone") {
+				t.Errorf("markdown = %q, want the winners", res.Markdown)
+			}
+			if !strings.Contains(res.DiscordPayload, `"allowed_mentions":{"parse":[]}`) {
+				t.Errorf("discord payload = %s, want mentions disabled", res.DiscordPayload)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package summary
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// defaultEmbedColor is used when there is no winning team colour
+	defaultEmbedColor = 0x5865f2
+	// maxFieldLength is Discord's limit on the length of an embed field value
+	maxFieldLength = 1024
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, ">", `\>`,
+)
+
+// escape stops usernames such as __init__ being formatted as Markdown
+func escape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func (s *Summary) winnersText() string {
+	winners := make([]string, len(s.Winners))
+	for i, w := range s.Winners {
+		winners[i] = escape(w)
+	}
+	players := strings.Join(winners, ", ")
+
+	switch {
+	case s.WinningTeam != "" && players != "":
+		return fmt.Sprintf("**%s** (%s)", escape(s.WinningTeam), players)
+	case s.WinningTeam != "":
+		return fmt.Sprintf("**%s**", escape(s.WinningTeam))
+	case players != "":
+		return players
+	default:
+		return "No winner"
+	}
+}
+
+func (s *Summary) durationText() string {
+	if s.Duration <= 0 {
+		return "Unknown"
+	}
+
+	return formatDuration(s.Duration)
+}
+
+func (s *Summary) towerHealthText() string {
+	td := s.TowerDefence
+	return fmt.Sprintf("Red %d/%d, Blue %d/%d", td.RedHealth, td.MaxHealth, td.BlueHealth, td.MaxHealth)
+}
+
+func (s *Summary) topKillerLines() []string {
+	lines := make([]string, len(s.TopKillers))
+	for i, k := range s.TopKillers {
+		lines[i] = fmt.Sprintf("%d. %s: %d kills (%d final)", i+1, escape(k.Username), k.Kills, k.FinalKills)
+	}
+
+	return lines
+}
+
+// Markdown renders the summary as Discord flavoured Markdown
+func (s *Summary) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "**%s**\n", escape(s.Title()))
+	fmt.Fprintf(&b, "Winners: %s\n", s.winnersText())
+	fmt.Fprintf(&b, "Duration: %s · Players: %d\n", s.durationText(), s.PlayerCount)
+
+	if s.TowerDefence != nil {
+		fmt.Fprintf(&b, "Remaining health: %s\n", s.towerHealthText())
+	}
+
+	if len(s.TopKillers) > 0 {
+		b.WriteString("Top killers:\n")
+		for _, line := range s.topKillerLines() {
+			b.WriteString(line + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// DiscordPayload is the body of a Discord webhook execution
+type DiscordPayload struct {
+	Embeds []*DiscordEmbed `json:"embeds"`
+	// AllowedMentions stops usernames such as @everyone from pinging anyone
+	AllowedMentions *DiscordAllowedMentions `json:"allowed_mentions"`
+}
+
+type DiscordAllowedMentions struct {
+	// Parse is the types of mention to ping, none if empty
+	Parse []string `json:"parse"`
+}
+
+type DiscordEmbed struct {
+	Title     string               `json:"title"`
+	Color     int32                `json:"color"`
+	Timestamp string               `json:"timestamp"`
+	Fields    []*DiscordEmbedField `json:"fields"`
+	Footer    *DiscordEmbedFooter  `json:"footer,omitempty"`
+}
+
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type DiscordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// DiscordEmbed renders the summary as an embed, which can be posted to a Discord webhook in a DiscordPayload
+func (s *Summary) DiscordEmbed() *DiscordEmbed {
+	color := s.WinningTeamColor
+	if color <= 0 {
+		color = defaultEmbedColor
+	}
+
+	embed := &DiscordEmbed{
+		Title:     s.Title(),
+		Color:     color,
+		Timestamp: s.EndTime.UTC().Format(time.RFC3339),
+		Fields: []*DiscordEmbedField{
+			{Name: "Winners", Value: truncate(s.winnersText())},
+			{Name: "Duration", Value: s.durationText(), Inline: true},
+			{Name: "Players", Value: fmt.Sprint(s.PlayerCount), Inline: true},
+		},
+		Footer: &DiscordEmbedFooter{Text: "Game " + s.GameId},
+	}
+
+	if s.TowerDefence != nil {
+		embed.Fields = append(embed.Fields, &DiscordEmbedField{Name: "Remaining health", Value: s.towerHealthText()})
+	}
+
+	if len(s.TopKillers) > 0 {
+		embed.Fields = append(embed.Fields, &DiscordEmbedField{
+			Name:  "Top killers",
+			Value: truncate(strings.Join(s.topKillerLines(), "\n")),
+		})
+	}
+
+	return embed
+}
+
+func (s *Summary) DiscordPayload() *DiscordPayload {
+	return &DiscordPayload{
+		Embeds:          []*DiscordEmbed{s.DiscordEmbed()},
+		AllowedMentions: &DiscordAllowedMentions{Parse: []string{}},
+	}
+}
+
+func truncate(value string) string {
+	if len(value) <= maxFieldLength {
+		return value
+	}
+
+	// Cut on a rune boundary so the value stays valid UTF-8
+	cut := maxFieldLength - len("…")
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+
+	return value[:cut] + "…"
+}
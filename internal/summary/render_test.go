@@ -0,0 +1,48 @@
+package summary
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiscordPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary *Summary
+		want    []string
+	}{
+		{
+			name:    "no mentions",
+			summary: &Summary{GameId: "game", GameModeId: "block-sumo", Winners: []string{"@everyone"}},
+			want:    []string{`"allowed_mentions":{"parse":[]}`, `"value":"@everyone"`},
+		},
+		{
+			name:    "markdown escaped",
+			summary: &Summary{GameId: "game", GameModeId: "block-sumo", Winners: []string{"__init__"}},
+			want:    []string{`"value":"\\_\\_init\\_\\_"`},
+		},
+		{
+			name: "winning team colour",
+			summary: &Summary{GameId: "game", GameModeId: "tower-defence", WinningTeam: "Red", WinningTeamColor: 0xff0000,
+				Duration: 90 * time.Second},
+			want: []string{`"color":16711680`, `"value":"**Red**"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.summary.DiscordPayload())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("payload %s doesn't contain %s", data, want)
+				}
+			}
+		})
+	}
+}
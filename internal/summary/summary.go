@@ -0,0 +1,179 @@
+package summary
+
+import (
+	"fmt"
+	"game-tracker/internal/repository/model"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// topKillerCount is the number of Block Sumo players listed by kills
+const topKillerCount = 3
+
+// Summary is the result of a game in the form posted to community channels
+type Summary struct {
+	GameId     string    `json:"gameId"`
+	GameModeId string    `json:"gameModeId"`
+	MapId      string    `json:"mapId,omitempty"`
+	ServerId   string    `json:"serverId"`
+	EndTime    time.Time `json:"endTime"`
+	// Duration is zero if the start time of the game is unknown
+	Duration       time.Duration `json:"-"`
+	DurationMillis int64         `json:"durationMillis,omitempty"`
+
+	PlayerCount int `json:"playerCount"`
+	// Winners are the usernames of the winning players
+	Winners []string `json:"winners"`
+	// WinningTeam is the friendly name of the winning team, empty if there isn't a single winning team
+	WinningTeam      string `json:"winningTeam,omitempty"`
+	WinningTeamColor int32  `json:"winningTeamColor,omitempty"`
+
+	// TopKillers are the Block Sumo players with the most kills, most first
+	TopKillers   []*Killer    `json:"topKillers,omitempty"`
+	TowerDefence *TowerHealth `json:"towerDefence,omitempty"`
+}
+
+type Killer struct {
+	Username   string `json:"username"`
+	Kills      int32  `json:"kills"`
+	FinalKills int32  `json:"finalKills"`
+}
+
+// TowerHealth is the health remaining of each Tower Defence tower
+type TowerHealth struct {
+	MaxHealth  int32 `json:"maxHealth"`
+	RedHealth  int32 `json:"redHealth"`
+	BlueHealth int32 `json:"blueHealth"`
+}
+
+func FromGame(game *model.HistoricGame) *Summary {
+	s := &Summary{
+		GameId:         game.Id.Hex(),
+		GameModeId:     game.GameModeId,
+		MapId:          game.MapId,
+		ServerId:       game.ServerId,
+		EndTime:        game.EndTime,
+		Duration:       game.Duration,
+		DurationMillis: game.Duration.Milliseconds(),
+		PlayerCount:    len(game.Players),
+	}
+
+	usernames := usernamesOf(game)
+
+	if game.WinnerData != nil {
+		for _, id := range game.WinnerData.WinnerIds {
+			s.Winners = append(s.Winners, usernames.get(id))
+		}
+	}
+
+	for _, t := range game.TeamStats {
+		if t.TeamId == game.WinningTeamId {
+			s.WinningTeam = t.FriendlyName
+			s.WinningTeamColor = t.Color
+		}
+	}
+
+	switch data := game.GameData.(type) {
+	case *model.HistoricBlockSumoData:
+		if data.Scoreboard == nil {
+			break
+		}
+
+		for id, e := range data.Scoreboard.Entries {
+			s.TopKillers = append(s.TopKillers, &Killer{
+				Username:   usernames.get(id),
+				Kills:      e.Kills,
+				FinalKills: e.FinalKills,
+			})
+		}
+
+		sort.Slice(s.TopKillers, func(i, j int) bool {
+			a, b := s.TopKillers[i], s.TopKillers[j]
+			if a.Kills != b.Kills {
+				return a.Kills > b.Kills
+			}
+			if a.FinalKills != b.FinalKills {
+				return a.FinalKills > b.FinalKills
+			}
+			return a.Username < b.Username
+		})
+		if len(s.TopKillers) > topKillerCount {
+			s.TopKillers = s.TopKillers[:topKillerCount]
+		}
+	case *model.HistoricTowerDefenceData:
+		s.TowerDefence = &TowerHealth{
+			MaxHealth:  data.MaxHealth,
+			RedHealth:  data.RedHealth,
+			BlueHealth: data.BlueHealth,
+		}
+	}
+
+	return s
+}
+
+type usernameLookup map[uuid.UUID]string
+
+// usernamesOf maps the ids of everyone who took part in the game to their usernames, including players who left early
+func usernamesOf(game *model.HistoricGame) usernameLookup {
+	usernames := make(usernameLookup)
+	for _, p := range game.Participation {
+		usernames[p.PlayerId] = p.Username
+	}
+	for _, p := range game.Players {
+		usernames[p.Id] = p.Username
+	}
+
+	return usernames
+}
+
+// get returns the player's username, or their id if the username isn't known
+func (u usernameLookup) get(id uuid.UUID) string {
+	if username, ok := u[id]; ok && username != "" {
+		return username
+	}
+
+	return id.String()
+}
+
+// Title is the game mode and map, e.g. "Tower Defence on Castle"
+func (s *Summary) Title() string {
+	title := displayName(s.GameModeId)
+	if s.MapId != "" {
+		title += " on " + displayName(s.MapId)
+	}
+
+	return title
+}
+
+// displayName turns an id such as tower-defence into Tower Defence
+func displayName(id string) string {
+	words := strings.FieldsFunc(id, func(r rune) bool {
+		return r == '-' || r == '_' || r == ' '
+	})
+
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// formatDuration formats durations like 1h 02m 05s, dropping leading zero units
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h, m, sec := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh %02dm %02ds", h, m, sec)
+	case m > 0:
+		return fmt.Sprintf("%dm %02ds", m, sec)
+	default:
+		return fmt.Sprintf("%ds", sec)
+	}
+}
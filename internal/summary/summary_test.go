@@ -0,0 +1,23 @@
+package summary
+
+import "testing"
+
+func TestDisplayName(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "tower-defence", want: "Tower Defence"},
+		{id: "block_sumo", want: "Block Sumo"},
+		{id: "école-map", want: "École Map"},
+		{id: "--", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := displayName(tt.id); got != tt.want {
+				t.Errorf("displayName(%q) = %q, want %q", tt.id, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+)
+
+var ErrBlockedAddress = errors.New("webhooks can't be sent to loopback, private or link-local addresses")
+
+// sharedAddressSpace is the carrier-grade NAT range, which isn't reachable from the internet either
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// publicAddress returns false for addresses a webhook must not reach, such as the tracker's own host,
+// other services in its network and cloud metadata endpoints
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified() && !sharedAddressSpace.Contains(ip)
+}
+
+// newClient returns the client deliveries are sent with. Addresses are checked with allowed when connecting,
+// after the host has been resolved, so a public name can't resolve to an internal address. Redirects aren't
+// followed, as they could lead anywhere, and proxies aren't used as they would connect on the tracker's behalf.
+func newClient(allowed func(ip net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: requestTimeout,
+		Control: func(_ string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   requestTimeout,
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// CheckURL rejects URLs that can be seen to point at an internal address without resolving them.
+// Deliveries are checked again when they are sent.
+func CheckURL(u *url.URL) error {
+	host := u.Hostname()
+	if host == "localhost" {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !publicAddress(ip) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"game-tracker/internal/export"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"game-tracker/internal/summary"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	EventGameFinished = "game.finished"
+
+	// Headers sent with every delivery. The signature is sha256= followed by the hex HMAC-SHA256
+	// of the timestamp, a '.' and the body, keyed with the subscription's secret.
+	HeaderDeliveryId = "X-Webhook-Id"
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+const (
+	maxAttempts  = 8
+	firstBackoff = 30 * time.Second
+
+	requestTimeout = 10 * time.Second
+	// claimLease must be longer than a request so a delivery isn't claimed again while it is being attempted
+	claimLease = time.Minute
+
+	pollInterval         = 5 * time.Second
+	subscriptionsRefresh = 30 * time.Second
+)
+
+// Payload is the JSON body POSTed to subscribers using the game format
+type Payload struct {
+	Event          string             `json:"event"`
+	DeliveryId     string             `json:"deliveryId"`
+	SubscriptionId string             `json:"subscriptionId"`
+	Game           *export.GameRecord `json:"game"`
+}
+
+// Dispatcher creates webhook deliveries for finished games and sends them, retrying failures with exponential backoff.
+// Deliveries are saved with their game, so they are sent at least once even if the tracker restarts.
+type Dispatcher struct {
+	logger *zap.SugaredLogger
+	repo   repository.Repository
+	client *http.Client
+
+	mu sync.RWMutex
+	// subscriptions is refreshed periodically, so a new subscription may miss games finishing just after it is added
+	subscriptions map[primitive.ObjectID]*model.WebhookSubscription
+
+	// wake is signalled when deliveries are queued so they are sent without waiting for the next poll
+	wake chan struct{}
+}
+
+func NewDispatcher(ctx context.Context, wg *sync.WaitGroup, logger *zap.SugaredLogger, repo repository.Repository) *Dispatcher {
+	d := &Dispatcher{
+		logger: logger,
+		repo:   repo,
+		client: newClient(publicAddress),
+
+		subscriptions: make(map[primitive.ObjectID]*model.WebhookSubscription),
+		wake:          make(chan struct{}, 1),
+	}
+
+	if err := d.refreshSubscriptions(ctx); err != nil {
+		logger.Errorw("failed to load webhook subscriptions", "error", err)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.run(ctx)
+	}()
+
+	return d
+}
+
+// Deliveries creates a delivery of the game to every matching subscription, to be saved with the game
+func (d *Dispatcher) Deliveries(game *model.HistoricGame, now time.Time) ([]*model.WebhookDelivery, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var deliveries []*model.WebhookDelivery
+	for _, s := range d.subscriptions {
+		if !s.Matches(game) {
+			continue
+		}
+
+		delivery := &model.WebhookDelivery{
+			Id:             primitive.NewObjectID(),
+			SubscriptionId: s.Id,
+			GameId:         game.Id,
+			PlayerIds:      game.ReferencedPlayerIds(),
+			Status:         model.WebhookDeliveryPending,
+			NextAttemptAt:  &now,
+			CreatedAt:      now,
+		}
+
+		payload, err := encodePayload(s, delivery, game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+		}
+		delivery.Payload = payload
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func encodePayload(s *model.WebhookSubscription, delivery *model.WebhookDelivery, game *model.HistoricGame) ([]byte, error) {
+	if s.Format == model.WebhookFormatDiscord {
+		return json.Marshal(summary.FromGame(game).DiscordPayload())
+	}
+
+	return json.Marshal(&Payload{
+		Event:          EventGameFinished,
+		DeliveryId:     delivery.Id.Hex(),
+		SubscriptionId: s.Id.Hex(),
+		Game:           export.GameRecordFromModel(game),
+	})
+}
+
+// Notify wakes the dispatcher to send newly queued deliveries
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	refresh := time.NewTicker(subscriptionsRefresh)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-refresh.C:
+			if err := d.refreshSubscriptions(ctx); err != nil {
+				d.logger.Errorw("failed to refresh webhook subscriptions", "error", err)
+			}
+			continue
+		case <-d.wake:
+		case <-time.After(pollInterval):
+		}
+
+		if err := d.sendDue(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Errorw("failed to send webhook deliveries", "error", err)
+		}
+	}
+}
+
+func (d *Dispatcher) refreshSubscriptions(ctx context.Context) error {
+	subscriptions, err := d.repo.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	byId := make(map[primitive.ObjectID]*model.WebhookSubscription, len(subscriptions))
+	for _, s := range subscriptions {
+		byId[s.Id] = s
+	}
+
+	d.mu.Lock()
+	d.subscriptions = byId
+	d.mu.Unlock()
+
+	return nil
+}
+
+// subscription returns the subscription, reloading them if it was created since the last refresh.
+// Nil is returned if it has been removed.
+func (d *Dispatcher) subscription(ctx context.Context, id primitive.ObjectID) (*model.WebhookSubscription, error) {
+	d.mu.RLock()
+	s, ok := d.subscriptions[id]
+	d.mu.RUnlock()
+	if ok {
+		return s, nil
+	}
+
+	if err := d.refreshSubscriptions(ctx); err != nil {
+		return nil, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.subscriptions[id], nil
+}
+
+// sendDue sends deliveries until none are due
+func (d *Dispatcher) sendDue(ctx context.Context) error {
+	for {
+		delivery, err := d.repo.ClaimWebhookDelivery(ctx, time.Now(), claimLease)
+		if err != nil {
+			return err
+		}
+		if delivery == nil {
+			return nil
+		}
+
+		if err := d.attempt(ctx, delivery); err != nil {
+			return err
+		}
+	}
+}
+
+// attempt sends the delivery once and records the outcome
+func (d *Dispatcher) attempt(ctx context.Context, delivery *model.WebhookDelivery) error {
+	subscription, err := d.subscription(ctx, delivery.SubscriptionId)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	attempt := &model.WebhookAttempt{Time: start}
+
+	if subscription == nil {
+		attempt.Error = "subscription removed"
+		return d.repo.RecordWebhookAttempt(ctx, delivery.Id, attempt, model.WebhookDeliveryFailed, nil)
+	}
+
+	attempt.StatusCode, err = d.post(ctx, subscription, delivery, start)
+	attempt.Duration = time.Since(start)
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	if err == nil {
+		return d.repo.RecordWebhookAttempt(ctx, delivery.Id, attempt, model.WebhookDeliverySucceeded, nil)
+	}
+
+	attempts := len(delivery.Attempts) + 1
+	if attempts >= maxAttempts {
+		d.logger.Warnw("webhook delivery failed, giving up", "deliveryId", delivery.Id.Hex(),
+			"subscriptionId", subscription.Id.Hex(), "attempts", attempts, "error", err)
+		return d.repo.RecordWebhookAttempt(ctx, delivery.Id, attempt, model.WebhookDeliveryFailed, nil)
+	}
+
+	next := time.Now().Add(retryBackoff(attempts))
+	d.logger.Infow("webhook delivery failed, retrying", "deliveryId", delivery.Id.Hex(),
+		"subscriptionId", subscription.Id.Hex(), "attempts", attempts, "retryAt", next, "error", err)
+	return d.repo.RecordWebhookAttempt(ctx, delivery.Id, attempt, model.WebhookDeliveryPending, &next)
+}
+
+// retryBackoff is how long to wait before retrying a delivery that has failed the number of attempts
+func retryBackoff(attempts int) time.Duration {
+	return firstBackoff << (attempts - 1)
+}
+
+// post sends the payload, returning the response status. Any status other than 2xx is an error.
+func (d *Dispatcher) post(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery,
+	now time.Time) (int, error) {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "game-tracker-webhooks")
+	req.Header.Set(HeaderDeliveryId, delivery.Id.Hex())
+	req.Header.Set(HeaderEvent, EventGameFinished)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected response status %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
+
+// Sign returns the signature header value of a body sent at the timestamp
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
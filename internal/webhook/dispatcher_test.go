@@ -0,0 +1,370 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"game-tracker/internal/repository"
+	"game-tracker/internal/repository/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{
+			name:      "payload",
+			secret:    "secret",
+			timestamp: "1700000000",
+			body:      `{"event":"game.finished"}`,
+			want:      "sha256=ff80f2916cc730f630cd25024d9dbdbf2e891aa946bd4b95f1c1d5f8ddae6011",
+		},
+		{
+			name:      "empty",
+			timestamp: "0",
+			want:      "sha256=b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// deliveryRepo keeps deliveries in memory, claiming them like the Mongo repository.
+// Other repository methods aren't used by the tests.
+type deliveryRepo struct {
+	repository.Repository
+
+	mu            sync.Mutex
+	deliveries    []*model.WebhookDelivery
+	subscriptions []*model.WebhookSubscription
+	statuses      map[primitive.ObjectID]model.WebhookDeliveryStatus
+}
+
+func (r *deliveryRepo) GetWebhookSubscriptions(_ context.Context) ([]*model.WebhookSubscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *deliveryRepo) ClaimWebhookDelivery(_ context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due *model.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status != model.WebhookDeliveryPending || d.NextAttemptAt.After(now) {
+			continue
+		}
+		if due == nil || d.NextAttemptAt.Before(*due.NextAttemptAt) {
+			due = d
+		}
+	}
+	if due == nil {
+		return nil, nil
+	}
+
+	next := now.Add(lease)
+	due.NextAttemptAt = &next
+
+	claimed := *due
+	claimed.Attempts = append([]*model.WebhookAttempt(nil), due.Attempts...)
+	return &claimed, nil
+}
+
+func (r *deliveryRepo) RecordWebhookAttempt(_ context.Context, id primitive.ObjectID, attempt *model.WebhookAttempt,
+	status model.WebhookDeliveryStatus, nextAttemptAt *time.Time) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.deliveries {
+		if d.Id != id {
+			continue
+		}
+
+		d.Attempts = append(d.Attempts, attempt)
+		d.Status = status
+		d.NextAttemptAt = nextAttemptAt
+		return nil
+	}
+
+	return repository.ErrNotFound
+}
+
+// newTestDispatcher returns a dispatcher that can send to the loopback test servers
+func newTestDispatcher(repo *deliveryRepo) *Dispatcher {
+	d := &Dispatcher{
+		logger:        zap.NewNop().Sugar(),
+		repo:          repo,
+		client:        newClient(func(net.IP) bool { return true }),
+		subscriptions: make(map[primitive.ObjectID]*model.WebhookSubscription),
+	}
+	for _, s := range repo.subscriptions {
+		d.subscriptions[s.Id] = s
+	}
+
+	return d
+}
+
+func newDelivery(subscriptionId primitive.ObjectID, attempts int) *model.WebhookDelivery {
+	now := time.Now()
+	return &model.WebhookDelivery{
+		Id:             primitive.NewObjectID(),
+		SubscriptionId: subscriptionId,
+		Payload:        []byte(`{"event":"game.finished"}`),
+		Status:         model.WebhookDeliveryPending,
+		Attempts:       make([]*model.WebhookAttempt, attempts),
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+	}
+}
+
+func TestAttempt(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		redirect      bool
+		removed       bool
+		prevAttempts  int
+		wantStatus    model.WebhookDeliveryStatus
+		wantCode      int
+		wantBackoff   time.Duration
+		wantRequested bool
+	}{
+		{name: "success", status: http.StatusNoContent, wantStatus: model.WebhookDeliverySucceeded,
+			wantCode: http.StatusNoContent, wantRequested: true},
+		{name: "first failure", status: http.StatusInternalServerError, wantStatus: model.WebhookDeliveryPending,
+			wantCode: http.StatusInternalServerError, wantBackoff: 30 * time.Second, wantRequested: true},
+		{name: "third failure", status: http.StatusBadGateway, prevAttempts: 2, wantStatus: model.WebhookDeliveryPending,
+			wantCode: http.StatusBadGateway, wantBackoff: 2 * time.Minute, wantRequested: true},
+		{name: "last attempt", status: http.StatusInternalServerError, prevAttempts: maxAttempts - 1,
+			wantStatus: model.WebhookDeliveryFailed, wantCode: http.StatusInternalServerError, wantRequested: true},
+		{name: "redirect isn't followed", redirect: true, wantStatus: model.WebhookDeliveryPending,
+			wantCode: http.StatusFound, wantBackoff: 30 * time.Second, wantRequested: true},
+		{name: "subscription removed", removed: true, wantStatus: model.WebhookDeliveryFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redirected := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				t.Error("redirect was followed")
+			}))
+			defer redirected.Close()
+
+			subscription := &model.WebhookSubscription{Id: primitive.NewObjectID(), Secret: "secret"}
+			delivery := newDelivery(subscription.Id, tt.prevAttempts)
+
+			var requested bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = true
+
+				body, _ := io.ReadAll(r.Body)
+				if got, want := r.Header.Get(HeaderSignature), Sign("secret", r.Header.Get(HeaderTimestamp), body); got != want {
+					t.Errorf("signature = %s, want %s", got, want)
+				}
+				if got := r.Header.Get(HeaderDeliveryId); got != delivery.Id.Hex() {
+					t.Errorf("delivery id = %s, want %s", got, delivery.Id.Hex())
+				}
+
+				if tt.redirect {
+					http.Redirect(w, r, redirected.URL, http.StatusFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			subscription.Url = server.URL
+			repo := &deliveryRepo{deliveries: []*model.WebhookDelivery{delivery}}
+			if !tt.removed {
+				repo.subscriptions = []*model.WebhookSubscription{subscription}
+			}
+			d := newTestDispatcher(repo)
+
+			start := time.Now()
+			if err := d.attempt(context.Background(), delivery); err != nil {
+				t.Fatal(err)
+			}
+
+			if requested != tt.wantRequested {
+				t.Errorf("requested = %v, want %v", requested, tt.wantRequested)
+			}
+			if delivery.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", delivery.Status, tt.wantStatus)
+			}
+			if got := delivery.Attempts[len(delivery.Attempts)-1].StatusCode; got != tt.wantCode {
+				t.Errorf("status code = %d, want %d", got, tt.wantCode)
+			}
+
+			if tt.wantBackoff == 0 {
+				if delivery.NextAttemptAt != nil {
+					t.Errorf("next attempt = %s, want none", delivery.NextAttemptAt)
+				}
+				return
+			}
+			if delivery.NextAttemptAt == nil {
+				t.Fatal("next attempt not set")
+			}
+			if backoff := delivery.NextAttemptAt.Sub(start); backoff < tt.wantBackoff || backoff > tt.wantBackoff+5*time.Second {
+				t.Errorf("backoff = %s, want %s", backoff, tt.wantBackoff)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: maxAttempts - 1, want: 32 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := retryBackoff(tt.attempts); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:4700::1111", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.1.2.3", want: false},
+		{ip: "172.16.0.1", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "fd00::1", want: false},
+		{ip: "100.64.0.1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "224.0.0.1", want: false},
+		{ip: "::ffff:127.0.0.1", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := publicAddress(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicAddress(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestClientBlocksPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("request reached a loopback server")
+	}))
+	defer server.Close()
+
+	// localhost is resolved before the address is checked, so names don't get around it
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.Host = net.JoinHostPort("localhost", u.Port())
+
+	for _, target := range []string{server.URL, u.String()} {
+		res, err := newClient(publicAddress).Post(target, "application/json", nil)
+		if err == nil {
+			res.Body.Close()
+		}
+		if !errors.Is(err, ErrBlockedAddress) {
+			t.Errorf("post to %s: error = %v, want %v", target, err, ErrBlockedAddress)
+		}
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://discord.com/api/webhooks/1/token"},
+		{url: "https://93.184.216.34/hook"},
+		{url: "http://localhost:8080/hook", wantErr: true},
+		{url: "http://127.0.0.1/hook", wantErr: true},
+		{url: "http://[::1]/hook", wantErr: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "http://10.0.0.5/hook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := CheckURL(u); (err != nil) != tt.wantErr {
+			t.Errorf("CheckURL(%s) = %v, want error %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+// Two trackers sending at once must each post a due delivery only once between them
+func TestSendDueClaimsOnce(t *testing.T) {
+	if claimLease <= requestTimeout {
+		t.Fatalf("claim lease %s must be longer than the request timeout %s", claimLease, requestTimeout)
+	}
+
+	var mu sync.Mutex
+	posts := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		posts[r.Header.Get(HeaderDeliveryId)]++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	subscription := &model.WebhookSubscription{Id: primitive.NewObjectID(), Url: server.URL, Secret: "secret"}
+	repo := &deliveryRepo{subscriptions: []*model.WebhookSubscription{subscription}}
+	for i := 0; i < 50; i++ {
+		repo.deliveries = append(repo.deliveries, newDelivery(subscription.Id, 0))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		d := newTestDispatcher(repo)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.sendDue(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, delivery := range repo.deliveries {
+		if n := posts[delivery.Id.Hex()]; n != 1 {
+			t.Errorf("delivery %s posted %d times, want once", delivery.Id.Hex(), n)
+		}
+		if delivery.Status != model.WebhookDeliverySucceeded {
+			t.Errorf("delivery %s status = %s, want %s", delivery.Id.Hex(), delivery.Status, model.WebhookDeliverySucceeded)
+		}
+	}
+}
@@ -41,7 +41,10 @@ type app struct {
 }
 
 func main() {
-	cfg := config.LoadGlobalConfig()
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	w := &kafka.Writer{
 		Addr:     kafka.TCP(fmt.Sprintf("%s:%d", cfg.Kafka.Host, cfg.Kafka.Port)),